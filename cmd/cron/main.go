@@ -36,24 +36,38 @@ func main() {
 	}
 
 	// Initialize Cacabot client
-	cacabotClient := cacabot.NewCacabotClient(
-		config.Cacabot.URL,
-		config.Cacabot.Username,
-		config.Cacabot.Password,
-		config.Cacabot.Enabled,
-	)
+	cacabotClient := cacabot.NewCacabotClient(&config.Cacabot)
 
 	// Initialize repositories
-	productRepo := repositories.NewProductBillerRepository(dbConn)
+	pbRepo := repositories.NewProductBillerRepository(dbConn)
+	productRepo := repositories.NewProductRepository(dbConn)
+	billerRepo := repositories.NewBillerRepository(dbConn)
+	summaryRepo := repositories.NewProductBillerSummaryRepository(dbConn)
+	notificationRepo := repositories.NewNotificationRepository(dbConn)
+	statRepo := repositories.NewProductBillerStatRepository(dbConn)
 
 	// Initialize notification infrastructure
-	notif := notification.NewNotification(cacabotClient)
+	renderer, err := notification.NewRenderer(config.Notification.TemplateDir)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load notification templates")
+	}
+	channels, err := notification.NewChannels(&config.Notification, cacabotClient, renderer)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure notification channels")
+	}
+	router, err := notification.NewRouter(channels, config.Notification.Routes)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure notification routes")
+	}
+	notif := notification.NewNotification(notificationRepo)
 
 	// Initialize use case layer
-	cronUseCase := usecases.NewCronUseCase(productRepo, notif)
+	cronUseCase := usecases.NewCronUseCase(pbRepo, productRepo, billerRepo, summaryRepo, notif)
+	notificationUseCase := usecases.NewNotificationUseCase(notificationRepo, router, config.Notification.Queue)
+	purgeUseCase := usecases.NewPurgeUseCase(pbRepo, productRepo, billerRepo, statRepo, config.Purge)
 
 	// Initialize controller layer
-	cronController := controllers.NewCronController(cronUseCase, logger)
+	cronController := controllers.NewCronController(cronUseCase, notificationUseCase, purgeUseCase, logger)
 
 	// Schedule the daily cron job for sending product biller summaries
 	cronJob := &utils.CronJob{
@@ -64,6 +78,21 @@ func main() {
 		config.Service.NotificationMinute,
 	)
 
+	// Deliver queued notifications, retrying failed deliveries
+	dispatchJob := &utils.CronJob{
+		Task: cronController.DispatchNotifications,
+	}
+	go dispatchJob.ScheduleEvery(config.Notification.Queue.DispatchInterval)
+
+	// Purge soft-deleted rows, transaction stats and recorded transactions once their retention period is over
+	purgeJob := &utils.CronJob{
+		Task: func() {
+			cronController.PurgeDeleted()
+			cronController.PurgeStats()
+		},
+	}
+	go purgeJob.ScheduleDaily(config.Purge.Hour, config.Purge.Minute)
+
 	// Keep the application running indefinitely
 	select {}
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"net"
 	"os"
 	"os/signal"
 	"time"
@@ -12,8 +13,10 @@ import (
 
 	"golang-boilerplate/internal/app/http/config"
 	"golang-boilerplate/internal/app/http/routes"
+	"golang-boilerplate/internal/app/http/rpc"
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/rbac"
 )
 
 func main() {
@@ -32,23 +35,41 @@ func main() {
 		appLogger.Fatal().Err(err).Msg("Failed to connect to the database")
 	}
 
+	// Initialize UseCases, shared by the HTTP and gRPC servers
+	useCases := routes.NewUseCases(dbConn, appLogger, appConfig)
+
+	// Initialize Role Based Access Control
+	roles := rbac.NewRolesManager(dbConn.DB)
+
 	// Initialize Echo server
 	server := echo.New()
-	routes.RegisterRoutes(server, dbConn, appLogger, appConfig)
+	routes.RegisterRoutes(server, useCases, roles, appLogger, appConfig)
+
+	// Initialize gRPC server
+	grpcServer := routes.NewGRPCServer(useCases, appLogger, appConfig)
 
-	// Run the server in a separate goroutine
+	// Run the servers in separate goroutines
 	go func() {
 		if err := server.Start(":" + appConfig.Service.Port); err != nil {
 			appLogger.Fatal().Err(err).Msg("Failed to start the server")
 		}
 	}()
+	go func() {
+		listener, err := net.Listen("tcp", ":"+appConfig.Service.GRPCPort)
+		if err != nil {
+			appLogger.Fatal().Err(err).Msg("Failed to listen for the gRPC server")
+		}
+		if err := grpcServer.Serve(listener); err != nil {
+			appLogger.Fatal().Err(err).Msg("Failed to start the gRPC server")
+		}
+	}()
 
 	// Graceful shutdown
-	gracefulShutdown(server, appLogger)
+	gracefulShutdown(server, grpcServer, appLogger)
 }
 
 // gracefulShutdown handles server shutdown on receiving termination signals
-func gracefulShutdown(server *echo.Echo, appLogger *zerolog.Logger) {
+func gracefulShutdown(server *echo.Echo, grpcServer *rpc.Server, appLogger *zerolog.Logger) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
@@ -58,9 +79,21 @@ func gracefulShutdown(server *echo.Echo, appLogger *zerolog.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Stop the gRPC server alongside the HTTP one, within the same deadline
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	go func() {
+		<-ctx.Done()
+		grpcServer.Stop()
+	}()
+
 	if err := server.Shutdown(ctx); err != nil {
 		appLogger.Error().Err(err).Msg("Error during server shutdown")
 	} else {
 		appLogger.Info().Msg("Server shutdown completed")
 	}
+	<-stopped
 }
@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -9,16 +12,22 @@ import (
 	"golang-boilerplate/internal/app/worker/config"
 	"golang-boilerplate/internal/app/worker/controllers"
 	"golang-boilerplate/internal/app/worker/usecases"
+	"golang-boilerplate/internal/pkg/catalog"
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/connections/kafka"
 	"golang-boilerplate/internal/pkg/connections/redis"
 	"golang-boilerplate/internal/pkg/infrastructure/lock"
+	"golang-boilerplate/internal/pkg/infrastructure/notification"
+	"golang-boilerplate/internal/pkg/infrastructure/ratelimit"
 	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/utils"
 )
 
 func main() {
-	ctx := context.Background()
+	// Stop consuming on termination signals, so pending alerts are flushed before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Load configuration
 	appConfig, err := config.NewConfig()
@@ -52,13 +61,41 @@ func main() {
 	}
 
 	pbRepo := repositories.NewProductBillerRepository(dbConn)
+	productRepo := repositories.NewProductRepository(dbConn)
+	billerRepo := repositories.NewBillerRepository(dbConn)
+	statRepo := repositories.NewProductBillerStatRepository(dbConn)
+	notificationRepo := repositories.NewNotificationRepository(dbConn)
 	lock := lock.NewLock(redis, appConfig.Lock.TTL*time.Millisecond, appConfig.Lock.MaxRetryTime*time.Millisecond, appConfig.Lock.RetryInterval*time.Millisecond)
 
+	// Initialize deactivation alerts, queued for delivery by the cron dispatcher.
+	notif := notification.NewNotification(notificationRepo)
+	alertLimiter := ratelimit.NewRateLimiter(redis, appConfig.Alert.RateLimit)
+	alerter := notification.NewAlertGrouper(notif, alertLimiter, appConfig.Alert.GroupWindow)
+
 	// Initialize usecase and controller.
-	usecase := usecases.NewTransactionUseCase(pbRepo, lock)
+	usecase := usecases.NewTransactionUseCase(pbRepo, productRepo, billerRepo, statRepo, lock, alerter)
 	controller := controllers.NewTransactionController(usecase)
 
+	// Run the background jobs queued by the HTTP service, polling for new ones once the queue is empty.
+	jobRepo := repositories.NewJobRepository(dbConn)
+	uow := repositories.NewUnitOfWork(dbConn)
+	productBillers := catalog.NewProductBillers(pbRepo, uow)
+	jobUseCase := usecases.NewJobUseCase(jobRepo, usecases.NewProductBillerJobHandlers(productBillers), appConfig.Job)
+	jobController := controllers.NewJobController(jobUseCase)
+	jobRunner := &utils.CronJob{
+		Task: func() { jobController.RunJobs(ctx) },
+	}
+	go jobRunner.ScheduleEvery(appConfig.Job.PollInterval)
+
 	// Start consuming messages.
 	log.Info().Msg("Starting Kafka consumer...")
 	consumer.Consume(ctx, controller.HandleMessage)
+
+	// Send any alerts still waiting for their group window to close, with a context of their own since ctx
+	// is done by now.
+	flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := alerter.Flush(flushCtx); err != nil {
+		appLogger.Error().Err(err).Msg("Failed to flush deactivation alerts")
+	}
 }
@@ -10,11 +10,13 @@ import (
 )
 
 type Config struct {
-	App     config.App
-	Service Service
-	DB      config.DB
-	Cacabot config.Cacabot
-	Logger  config.Logger
+	App          config.App
+	Service      Service
+	DB           config.DB
+	Cacabot      config.Cacabot
+	Notification config.Notification
+	Purge        config.Purge
+	Logger       config.Logger
 }
 
 type Service struct {
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -10,14 +11,23 @@ import (
 )
 
 type CronController struct {
-	usecase *usecases.CronUseCase
-	logger  *zerolog.Logger
+	usecase             *usecases.CronUseCase
+	notificationUseCase *usecases.NotificationUseCase
+	purgeUseCase        *usecases.PurgeUseCase
+	logger              *zerolog.Logger
 }
 
-func NewCronController(usecase *usecases.CronUseCase, logger *zerolog.Logger) *CronController {
+func NewCronController(
+	usecase *usecases.CronUseCase,
+	notificationUseCase *usecases.NotificationUseCase,
+	purgeUseCase *usecases.PurgeUseCase,
+	logger *zerolog.Logger,
+) *CronController {
 	return &CronController{
-		usecase: usecase,
-		logger:  logger,
+		usecase:             usecase,
+		notificationUseCase: notificationUseCase,
+		purgeUseCase:        purgeUseCase,
+		logger:              logger,
 	}
 }
 
@@ -32,3 +42,33 @@ func (c *CronController) NotifyProductBillerSummary() {
 		logger.Error(ctx, eventClassCron, "NotifyProductBillerSummary", err.Error())
 	}
 }
+
+func (c *CronController) DispatchNotifications() {
+	ctx, logger := logger.NewAppLogger(context.Background(), c.logger)
+
+	if err := c.notificationUseCase.DispatchDue(ctx); err != nil {
+		logger.Error(ctx, eventClassCron, "DispatchNotifications", err.Error())
+	}
+}
+
+func (c *CronController) PurgeDeleted() {
+	ctx, logger := logger.NewAppLogger(context.Background(), c.logger)
+
+	report, err := c.purgeUseCase.PurgeDeleted(ctx)
+	if err != nil {
+		logger.Error(ctx, eventClassCron, "PurgeDeleted", err.Error())
+	}
+	logger.Info(ctx, eventClassCron, "PurgeDeleted", "purged rows deleted before %s: %d product billers, %d products, %d billers",
+		report.Before.Format(time.RFC3339), report.ProductBillers, report.Products, report.Billers)
+}
+
+func (c *CronController) PurgeStats() {
+	ctx, logger := logger.NewAppLogger(context.Background(), c.logger)
+
+	report, err := c.purgeUseCase.PurgeStats(ctx)
+	if err != nil {
+		logger.Error(ctx, eventClassCron, "PurgeStats", err.Error())
+	}
+	logger.Info(ctx, eventClassCron, "PurgeStats", "purged %d product biller stats of buckets started before %s and %d transactions recorded before %s",
+		report.Stats, report.StatsBefore.Format(time.RFC3339), report.Transactions, report.TransactionsBefore.Format(time.RFC3339))
+}
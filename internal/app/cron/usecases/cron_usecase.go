@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"golang-boilerplate/internal/pkg/infrastructure/notification"
@@ -10,45 +11,136 @@ import (
 	"golang-boilerplate/internal/pkg/models"
 )
 
+// deactivationWindow is how far back the summary looks for deactivated product billers.
+const deactivationWindow = 24 * time.Hour
+
 type CronUseCase struct {
-	pbRepo repositories.ProductBillerRepository
-	notif  notification.Notification
+	pbRepo      repositories.ProductBillerRepository
+	productRepo repositories.ProductRepository
+	billerRepo  repositories.BillerRepository
+	summaryRepo repositories.ProductBillerSummaryRepository
+	notif       notification.Notification
 }
 
-func NewCronUseCase(pbRepo repositories.ProductBillerRepository, notif notification.Notification) *CronUseCase {
+func NewCronUseCase(
+	pbRepo repositories.ProductBillerRepository,
+	productRepo repositories.ProductRepository,
+	billerRepo repositories.BillerRepository,
+	summaryRepo repositories.ProductBillerSummaryRepository,
+	notif notification.Notification,
+) *CronUseCase {
 	return &CronUseCase{
-		pbRepo: pbRepo,
-		notif:  notif,
+		pbRepo:      pbRepo,
+		productRepo: productRepo,
+		billerRepo:  billerRepo,
+		summaryRepo: summaryRepo,
+		notif:       notif,
 	}
 }
 
 func (uc *CronUseCase) NotifyProductBillerSummary(ctx context.Context) error {
-	// Fetch all product billers
-	productBillers, err := uc.pbRepo.FetchMany(ctx, map[string]interface{}{})
+	now := time.Now()
+
+	// Aggregate product biller counts in the database
+	aggregate, err := uc.pbRepo.Summarize(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to summarize product billers: %w", err)
+	}
+
+	// Fetch product billers deactivated within the window
+	deactivatedSince := now.Add(-deactivationWindow)
+	deactivated, err := uc.pbRepo.FetchMany(ctx, models.ProductBillerFilter{
+		DeactivatedSince: &deactivatedSince,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to fetch product billers: %w", err)
+		return fmt.Errorf("failed to fetch deactivated product billers: %w", err)
 	}
 
 	// Initialize the summary with current timestamp
 	summary := models.ProductBillerSummaryNotification{
-		DateTime: time.Now(),
-		Total:    len(productBillers),
+		DateTime:    now,
+		Total:       aggregate.Total,
+		Active:      aggregate.Active,
+		Inactive:    aggregate.Inactive,
+		Billers:     aggregate.ByBiller,
+		Products:    aggregate.ByProduct,
+		Deactivated: make([]models.DeactivatedProductBiller, 0, len(deactivated)),
 	}
 
-	// Calculate active and inactive counts
-	for _, productBiller := range productBillers {
-		if productBiller.IsActive {
-			summary.Active++
-		}
+	// Label the deactivated product billers, fetching their products and billers together
+	productLabels, billerLabels, err := uc.labels(ctx, deactivated)
+	if err != nil {
+		return err
+	}
+	for _, productBiller := range deactivated {
+		summary.Deactivated = append(summary.Deactivated, models.DeactivatedProductBiller{
+			ID:            productBiller.ID,
+			ProductID:     productBiller.ProductID,
+			ProductLabel:  productLabels[productBiller.ProductID],
+			BillerID:      productBiller.BillerID,
+			BillerLabel:   billerLabels[productBiller.BillerID],
+			DeactivatedAt: *productBiller.DeactivatedAt,
+			DeactivatedBy: productBiller.DeactivatedBy,
+			Reason:        productBiller.DeactivationReason,
+		})
 	}
 
-	// Inactive count is derived directly for efficiency
-	summary.Inactive = summary.Total - summary.Active
+	// Compare against the previous snapshot, if any
+	previous, err := uc.summaryRepo.FetchLatest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch previous product biller summary: %w", err)
+	}
+	if previous != nil {
+		summary.Changes = summary.DiffFrom(previous)
+	}
 
-	// Send the summary notification
+	// Queue the summary notification for delivery
 	if err := uc.notif.SendProductBillerSummary(ctx, summary); err != nil {
 		return fmt.Errorf("failed to send product biller summary notification: %w", err)
 	}
 
+	// Persist the snapshot only once queued, so the next deltas are relative to what ops will see
+	if err := uc.summaryRepo.Create(ctx, &summary); err != nil {
+		return fmt.Errorf("failed to store product biller summary: %w", err)
+	}
+
 	return nil
 }
+
+// labels maps the IDs of the products and billers of the product billers to their labels. Products and billers deleted
+// since are missing from the maps, so they label as "".
+func (uc *CronUseCase) labels(ctx context.Context, productBillers []*models.ProductBiller) (map[int]string, map[int]string, error) {
+	productLabels := make(map[int]string)
+	billerLabels := make(map[int]string)
+	if len(productBillers) == 0 {
+		return productLabels, billerLabels, nil
+	}
+
+	var productIDs, billerIDs []int
+	for _, productBiller := range productBillers {
+		if !slices.Contains(productIDs, productBiller.ProductID) {
+			productIDs = append(productIDs, productBiller.ProductID)
+		}
+		if !slices.Contains(billerIDs, productBiller.BillerID) {
+			billerIDs = append(billerIDs, productBiller.BillerID)
+		}
+	}
+
+	products, err := uc.productRepo.FetchMany(ctx, models.ProductFilter{IDs: productIDs})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch products of deactivated product billers: %w", err)
+	}
+	for _, product := range products {
+		productLabels[product.ID] = product.Label
+	}
+
+	billers, err := uc.billerRepo.FetchMany(ctx, models.BillerFilter{IDs: billerIDs})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch billers of deactivated product billers: %w", err)
+	}
+	for _, biller := range billers {
+		billerLabels[biller.ID] = biller.Label
+	}
+
+	return productLabels, billerLabels, nil
+}
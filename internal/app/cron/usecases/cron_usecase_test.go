@@ -0,0 +1,171 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/app/cron/usecases"
+	notificationMocks "golang-boilerplate/internal/pkg/infrastructure/notification/mocks"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestCronUseCase_NotifyProductBillerSummary(t *testing.T) {
+	ctx := context.Background()
+	deactivatedAt := time.Now().Add(-time.Hour)
+
+	deactivated := []*models.ProductBiller{
+		{ID: 2, ProductID: 1, BillerID: 2, IsActive: false, DeactivatedAt: &deactivatedAt, DeactivatedBy: "worker", DeactivationReason: "transaction 9 returned status \"failed\""},
+	}
+	aggregate := &models.ProductBillerSummary{
+		Total:    3,
+		Active:   2,
+		Inactive: 1,
+		ByBiller: []models.ProductBillerGroupSummary{
+			{ID: 1, Label: "Biller A", Total: 1, Active: 1},
+			{ID: 2, Label: "Biller B", Total: 2, Active: 1, Inactive: 1},
+		},
+		ByProduct: []models.ProductBillerGroupSummary{
+			{ID: 1, Label: "Pulsa 10K", Total: 2, Active: 1, Inactive: 1},
+			{ID: 2, Label: "PLN 20K", Total: 1, Active: 1},
+		},
+	}
+
+	setup := func() (*mocks.MockProductBillerRepository, *mocks.MockProductBillerSummaryRepository, *notificationMocks.MockNotification, *usecases.CronUseCase) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		productRepo := new(mocks.MockProductRepository)
+		billerRepo := new(mocks.MockBillerRepository)
+		summaryRepo := new(mocks.MockProductBillerSummaryRepository)
+		notif := new(notificationMocks.MockNotification)
+
+		pbRepo.On("Summarize", ctx).Return(aggregate, nil)
+		pbRepo.On("FetchMany", ctx, mock.MatchedBy(func(filter models.ProductBillerFilter) bool {
+			return filter.DeactivatedSince != nil
+		})).Return(deactivated, nil)
+		productRepo.On("FetchMany", ctx, models.ProductFilter{IDs: []int{1}}).Return([]*models.Product{{ID: 1, Label: "Pulsa 10K"}}, nil).Once()
+		billerRepo.On("FetchMany", ctx, models.BillerFilter{IDs: []int{2}}).Return([]*models.Biller{{ID: 2, Label: "Biller B"}}, nil).Once()
+
+		return pbRepo, summaryRepo, notif, usecases.NewCronUseCase(pbRepo, productRepo, billerRepo, summaryRepo, notif)
+	}
+
+	t.Run("first summary has breakdowns and no changes", func(t *testing.T) {
+		_, summaryRepo, notif, useCase := setup()
+
+		summaryRepo.On("FetchLatest", ctx).Return(nil, nil)
+		notif.On("SendProductBillerSummary", ctx, mock.MatchedBy(func(s models.ProductBillerSummaryNotification) bool {
+			return s.Total == 3 && s.Active == 2 && s.Inactive == 1 &&
+				assert.Equal(t, []models.ProductBillerGroupSummary{
+					{ID: 1, Label: "Biller A", Total: 1, Active: 1},
+					{ID: 2, Label: "Biller B", Total: 2, Active: 1, Inactive: 1},
+				}, s.Billers) &&
+				assert.Equal(t, []models.ProductBillerGroupSummary{
+					{ID: 1, Label: "Pulsa 10K", Total: 2, Active: 1, Inactive: 1},
+					{ID: 2, Label: "PLN 20K", Total: 1, Active: 1},
+				}, s.Products) &&
+				len(s.Deactivated) == 1 && s.Deactivated[0].ProductLabel == "Pulsa 10K" && s.Deactivated[0].BillerLabel == "Biller B" && s.Deactivated[0].DeactivatedBy == "worker" &&
+				s.Changes == nil
+		})).Return(nil)
+		summaryRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		err := useCase.NotifyProductBillerSummary(ctx)
+		assert.NoError(t, err)
+
+		notif.AssertExpectations(t)
+		summaryRepo.AssertExpectations(t)
+	})
+
+	t.Run("changes against the previous summary", func(t *testing.T) {
+		_, summaryRepo, notif, useCase := setup()
+
+		previous := &models.ProductBillerSummaryNotification{
+			DateTime: time.Now().Add(-24 * time.Hour),
+			Total:    3,
+			Active:   3,
+			Billers: []models.ProductBillerGroupSummary{
+				{ID: 1, Label: "Biller A", Total: 1, Active: 1},
+				{ID: 2, Label: "Biller B", Total: 2, Active: 2},
+			},
+			Products: []models.ProductBillerGroupSummary{
+				{ID: 1, Label: "Pulsa 10K", Total: 2, Active: 2},
+				{ID: 2, Label: "PLN 20K", Total: 1, Active: 1},
+			},
+		}
+		summaryRepo.On("FetchLatest", ctx).Return(previous, nil)
+		notif.On("SendProductBillerSummary", ctx, mock.MatchedBy(func(s models.ProductBillerSummaryNotification) bool {
+			return s.Changes != nil &&
+				s.Changes.Since.Equal(previous.DateTime) &&
+				s.Changes.Total == 0 && s.Changes.Active == -1 && s.Changes.Inactive == 1 &&
+				assert.Equal(t, []models.ProductBillerGroupSummary{{ID: 2, Label: "Biller B", Active: -1, Inactive: 1}}, s.Changes.Billers) &&
+				assert.Equal(t, []models.ProductBillerGroupSummary{{ID: 1, Label: "Pulsa 10K", Active: -1, Inactive: 1}}, s.Changes.Products)
+		})).Return(nil)
+		summaryRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		err := useCase.NotifyProductBillerSummary(ctx)
+		assert.NoError(t, err)
+
+		notif.AssertExpectations(t)
+		summaryRepo.AssertExpectations(t)
+	})
+
+	t.Run("fetches the products and billers of the deactivated product billers together", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		productRepo := new(mocks.MockProductRepository)
+		billerRepo := new(mocks.MockBillerRepository)
+		summaryRepo := new(mocks.MockProductBillerSummaryRepository)
+		notif := new(notificationMocks.MockNotification)
+		useCase := usecases.NewCronUseCase(pbRepo, productRepo, billerRepo, summaryRepo, notif)
+
+		pbRepo.On("Summarize", ctx).Return(aggregate, nil)
+		pbRepo.On("FetchMany", ctx, mock.Anything).Return([]*models.ProductBiller{
+			{ID: 2, ProductID: 1, BillerID: 2, DeactivatedAt: &deactivatedAt},
+			{ID: 3, ProductID: 3, BillerID: 2, DeactivatedAt: &deactivatedAt},
+		}, nil)
+		// Product 3 was deleted since its product biller was deactivated.
+		productRepo.On("FetchMany", ctx, models.ProductFilter{IDs: []int{1, 3}}).Return([]*models.Product{{ID: 1, Label: "Pulsa 10K"}}, nil).Once()
+		billerRepo.On("FetchMany", ctx, models.BillerFilter{IDs: []int{2}}).Return([]*models.Biller{{ID: 2, Label: "Biller B"}}, nil).Once()
+		summaryRepo.On("FetchLatest", ctx).Return(nil, nil)
+		notif.On("SendProductBillerSummary", ctx, mock.MatchedBy(func(s models.ProductBillerSummaryNotification) bool {
+			return assert.Equal(t, []string{"Pulsa 10K", ""}, []string{s.Deactivated[0].ProductLabel, s.Deactivated[1].ProductLabel}) &&
+				assert.Equal(t, []string{"Biller B", "Biller B"}, []string{s.Deactivated[0].BillerLabel, s.Deactivated[1].BillerLabel})
+		})).Return(nil)
+		summaryRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		err := useCase.NotifyProductBillerSummary(ctx)
+		assert.NoError(t, err)
+
+		productRepo.AssertNumberOfCalls(t, "FetchMany", 1)
+		billerRepo.AssertNumberOfCalls(t, "FetchMany", 1)
+		notif.AssertExpectations(t)
+	})
+
+	t.Run("error summarizing", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		summaryRepo := new(mocks.MockProductBillerSummaryRepository)
+		notif := new(notificationMocks.MockNotification)
+		useCase := usecases.NewCronUseCase(pbRepo, nil, nil, summaryRepo, notif)
+
+		pbRepo.On("Summarize", ctx).Return(nil, errors.New("query failed"))
+
+		err := useCase.NotifyProductBillerSummary(ctx)
+		assert.EqualError(t, err, "failed to summarize product billers: query failed")
+
+		notif.AssertNotCalled(t, "SendProductBillerSummary", mock.Anything, mock.Anything)
+	})
+
+	t.Run("snapshot is not stored when sending fails", func(t *testing.T) {
+		_, summaryRepo, notif, useCase := setup()
+
+		summaryRepo.On("FetchLatest", ctx).Return(nil, nil)
+		notif.On("SendProductBillerSummary", ctx, mock.Anything).Return(errors.New("cacabot down"))
+
+		err := useCase.NotifyProductBillerSummary(ctx)
+		assert.EqualError(t, err, "failed to send product biller summary notification: cacabot down")
+
+		summaryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
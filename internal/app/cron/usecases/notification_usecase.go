@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/infrastructure/notification"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// maxLastErrorLength bounds the delivery error stored with a notification.
+const maxLastErrorLength = 2000
+
+type NotificationUseCase struct {
+	repo       repositories.NotificationRepository
+	dispatcher notification.Dispatcher
+	config     config.NotificationQueue
+	now        func() time.Time
+}
+
+func NewNotificationUseCase(
+	repo repositories.NotificationRepository,
+	dispatcher notification.Dispatcher,
+	config config.NotificationQueue,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		repo:       repo,
+		dispatcher: dispatcher,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// DispatchDue claims and delivers the queued notifications whose next attempt is due. Failed deliveries
+// are rescheduled with exponential backoff until MaxAttempts is reached, after which they are marked
+// failed and wait for a manual resend. Retries skip the channels a notification was already sent to.
+//
+// Claimed notifications are not claimed again until ClaimTimeout elapses, so overlapping dispatchers
+// never deliver the same notification twice.
+func (uc *NotificationUseCase) DispatchDue(ctx context.Context) error {
+	token := uuid.NewString()
+	now := uc.now()
+	queued, err := uc.repo.Claim(ctx, token, now, now.Add(uc.config.ClaimTimeout), uc.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+
+	// Every notification is delivered under its own claim, so one failed update does not hold back the others.
+	var errs []error
+	for _, n := range queued {
+		uc.deliver(ctx, n)
+
+		if err := uc.repo.UpdateDelivery(ctx, n, token); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update notification with ID %d: %w", n.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver attempts delivery of n and records the outcome on it.
+func (uc *NotificationUseCase) deliver(ctx context.Context, n *models.QueuedNotification) {
+	n.Attempts++
+
+	message, err := notification.DecodeMessage(n)
+	if err == nil {
+		var sent []string
+		sent, err = uc.dispatcher.Dispatch(ctx, message, n.Delivered())
+		n.MarkDelivered(sent...)
+	}
+
+	now := uc.now()
+	if err == nil {
+		n.Status = models.NotificationStatusSent
+		n.SentAt = &now
+		n.NextAttemptAt = nil
+		n.LastError = nil
+		return
+	}
+
+	lastError := err.Error()
+	if len(lastError) > maxLastErrorLength {
+		lastError = lastError[:maxLastErrorLength]
+	}
+	n.LastError = &lastError
+
+	if n.Attempts >= uc.config.MaxAttempts {
+		n.Status = models.NotificationStatusFailed
+		n.NextAttemptAt = nil
+		return
+	}
+
+	next := now.Add(uc.backoff(n.Attempts))
+	n.NextAttemptAt = &next
+}
+
+// backoff returns the delay before the retry following the given number of attempts.
+func (uc *NotificationUseCase) backoff(attempts int) time.Duration {
+	delay := uc.config.RetryBackoff
+	for i := 1; i < attempts && delay < uc.config.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, uc.config.MaxRetryBackoff)
+}
@@ -0,0 +1,170 @@
+package usecases_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/app/cron/usecases"
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/infrastructure/notification"
+	notificationMocks "golang-boilerplate/internal/pkg/infrastructure/notification/mocks"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestNotificationUseCase_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+	queueConfig := config.NotificationQueue{
+		BatchSize:       10,
+		ClaimTimeout:    10 * time.Minute,
+		MaxAttempts:     3,
+		RetryBackoff:    time.Minute,
+		MaxRetryBackoff: time.Hour,
+	}
+	payload, _ := json.Marshal(models.ProductBillerSummaryNotification{Total: 3})
+
+	claim := func(repo *mocks.MockNotificationRepository) *mock.Call {
+		return repo.On("Claim", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.MatchedBy(func(leaseUntil time.Time) bool {
+			return leaseUntil.After(time.Now().Add(9 * time.Minute))
+		}), 10)
+	}
+
+	queued := func(attempts int) *models.QueuedNotification {
+		return &models.QueuedNotification{
+			ID:       1,
+			Type:     notification.TypeProductBillerSummary,
+			Payload:  payload,
+			Status:   models.NotificationStatusPending,
+			Attempts: attempts,
+		}
+	}
+
+	t.Run("Delivered", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		n := queued(0)
+		claim(repo).Return([]*models.QueuedNotification{n}, nil)
+		dispatcher.On("Dispatch", ctx, mock.MatchedBy(func(m notification.Message) bool {
+			summary, ok := m.Payload.(*models.ProductBillerSummaryNotification)
+			return ok && summary.Total == 3
+		}), []string{}).Return([]string{notification.ChannelCacabot}, nil)
+		repo.On("UpdateDelivery", ctx, n, mock.AnythingOfType("string")).Return(nil)
+
+		assert.NoError(t, uc.DispatchDue(ctx))
+		assert.Equal(t, models.NotificationStatusSent, n.Status)
+		assert.Equal(t, 1, n.Attempts)
+		assert.NotNil(t, n.SentAt)
+		assert.Nil(t, n.NextAttemptAt)
+		assert.Equal(t, notification.ChannelCacabot, n.DeliveredChannels)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RetriedWithBackoff", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		n := queued(1)
+		claim(repo).Return([]*models.QueuedNotification{n}, nil)
+		dispatcher.On("Dispatch", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("cacabot down"))
+		repo.On("UpdateDelivery", ctx, n, mock.AnythingOfType("string")).Return(nil)
+
+		before := time.Now()
+		assert.NoError(t, uc.DispatchDue(ctx))
+		assert.Equal(t, models.NotificationStatusPending, n.Status)
+		assert.Equal(t, 2, n.Attempts)
+		assert.Equal(t, "cacabot down", *n.LastError)
+		if assert.NotNil(t, n.NextAttemptAt) {
+			assert.WithinDuration(t, before.Add(2*time.Minute), *n.NextAttemptAt, 5*time.Second)
+		}
+	})
+
+	t.Run("FailedAfterMaxAttempts", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		n := queued(2)
+		claim(repo).Return([]*models.QueuedNotification{n}, nil)
+		dispatcher.On("Dispatch", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("cacabot down"))
+		repo.On("UpdateDelivery", ctx, n, mock.AnythingOfType("string")).Return(nil)
+
+		assert.NoError(t, uc.DispatchDue(ctx))
+		assert.Equal(t, models.NotificationStatusFailed, n.Status)
+		assert.Nil(t, n.NextAttemptAt)
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		n := queued(0)
+		n.Type = "unknown"
+		claim(repo).Return([]*models.QueuedNotification{n}, nil)
+		repo.On("UpdateDelivery", ctx, n, mock.AnythingOfType("string")).Return(nil)
+
+		assert.NoError(t, uc.DispatchDue(ctx))
+		assert.Contains(t, *n.LastError, "unknown notification type")
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("RetriedOnlyOnFailedChannels", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		n := queued(1)
+		n.DeliveredChannels = notification.ChannelSlack
+		claim(repo).Return([]*models.QueuedNotification{n}, nil)
+		dispatcher.On("Dispatch", ctx, mock.Anything, []string{notification.ChannelSlack}).
+			Return([]string{notification.ChannelEmail}, errors.New("channel telegram: timeout"))
+		repo.On("UpdateDelivery", ctx, n, mock.AnythingOfType("string")).Return(nil)
+
+		assert.NoError(t, uc.DispatchDue(ctx))
+		assert.Equal(t, models.NotificationStatusPending, n.Status)
+		assert.Equal(t, "slack,email", n.DeliveredChannels)
+	})
+
+	t.Run("UpdatedUnderClaimToken", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		first, second := queued(0), queued(0)
+		second.ID = 2
+		var token string
+		claim(repo).
+			Run(func(args mock.Arguments) { token = args.String(1) }).
+			Return([]*models.QueuedNotification{first, second}, nil)
+		dispatcher.On("Dispatch", ctx, mock.Anything, mock.Anything).Return([]string{notification.ChannelCacabot}, nil)
+		repo.On("UpdateDelivery", ctx, first, mock.AnythingOfType("string")).Return(sql.ErrNoRows)
+		repo.On("UpdateDelivery", ctx, second, mock.AnythingOfType("string")).Return(nil)
+
+		// A claim lost to another dispatcher does not stop the rest of the batch.
+		err := uc.DispatchDue(ctx)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.ErrorContains(t, err, "notification with ID 1")
+		repo.AssertCalled(t, "UpdateDelivery", ctx, first, token)
+		repo.AssertCalled(t, "UpdateDelivery", ctx, second, token)
+	})
+
+	t.Run("NothingDue", func(t *testing.T) {
+		repo := new(mocks.MockNotificationRepository)
+		dispatcher := new(notificationMocks.MockDispatcher)
+		uc := usecases.NewNotificationUseCase(repo, dispatcher, queueConfig)
+
+		claim(repo).Return(nil, nil)
+
+		assert.NoError(t, uc.DispatchDue(ctx))
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type PurgeUseCase struct {
+	pbRepo      repositories.ProductBillerRepository
+	productRepo repositories.ProductRepository
+	billerRepo  repositories.BillerRepository
+	statRepo    repositories.ProductBillerStatRepository
+	config      config.Purge
+	now         func() time.Time
+}
+
+func NewPurgeUseCase(
+	pbRepo repositories.ProductBillerRepository,
+	productRepo repositories.ProductRepository,
+	billerRepo repositories.BillerRepository,
+	statRepo repositories.ProductBillerStatRepository,
+	config config.Purge,
+) *PurgeUseCase {
+	return &PurgeUseCase{
+		pbRepo:      pbRepo,
+		productRepo: productRepo,
+		billerRepo:  billerRepo,
+		statRepo:    statRepo,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+// PurgeDeleted deletes for good the rows soft-deleted longer ago than the retention period, in batches
+// of BatchSize rows. Product billers go first, along with their stats, as products and billers are kept
+// while referenced.
+func (uc *PurgeUseCase) PurgeDeleted(ctx context.Context) (*models.PurgeReport, error) {
+	before := uc.now().Add(-uc.config.Retention)
+	report := &models.PurgeReport{Before: before}
+
+	var err error
+	if report.ProductBillers, err = uc.purge(ctx, uc.pbRepo.Purge, before); err != nil {
+		return report, fmt.Errorf("failed to purge product billers: %w", err)
+	}
+	if report.Products, err = uc.purge(ctx, uc.productRepo.Purge, before); err != nil {
+		return report, fmt.Errorf("failed to purge products: %w", err)
+	}
+	if report.Billers, err = uc.purge(ctx, uc.billerRepo.Purge, before); err != nil {
+		return report, fmt.Errorf("failed to purge billers: %w", err)
+	}
+
+	return report, nil
+}
+
+// PurgeStats deletes the transaction stats and the recorded transactions older than their retention periods,
+// in batches of BatchSize rows.
+func (uc *PurgeUseCase) PurgeStats(ctx context.Context) (*models.StatPurgeReport, error) {
+	now := uc.now()
+	report := &models.StatPurgeReport{
+		StatsBefore:        now.Add(-uc.config.StatRetention),
+		TransactionsBefore: now.Add(-uc.config.StatTransactionRetention),
+	}
+
+	var err error
+	if report.Stats, err = uc.purge(ctx, uc.statRepo.Purge, report.StatsBefore); err != nil {
+		return report, fmt.Errorf("failed to purge product biller stats: %w", err)
+	}
+	if report.Transactions, err = uc.purge(ctx, uc.statRepo.PurgeTransactions, report.TransactionsBefore); err != nil {
+		return report, fmt.Errorf("failed to purge product biller stat transactions: %w", err)
+	}
+
+	return report, nil
+}
+
+// purge calls purgeBatch until a batch comes back short, returning the total purged.
+func (uc *PurgeUseCase) purge(ctx context.Context, purgeBatch func(context.Context, time.Time, int) (int64, error), before time.Time) (int64, error) {
+	var total int64
+	for {
+		purged, err := purgeBatch(ctx, before, uc.config.BatchSize)
+		total += purged
+		if err != nil {
+			return total, err
+		}
+		if purged < int64(uc.config.BatchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/cron/usecases"
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+)
+
+func TestPurgeUseCase_PurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	purgeConfig := config.Purge{Retention: 30 * 24 * time.Hour, BatchSize: 2}
+
+	t.Run("purges in batches, product billers first", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		productRepo := new(mocks.MockProductRepository)
+		billerRepo := new(mocks.MockBillerRepository)
+		useCase := usecases.NewPurgeUseCase(pbRepo, productRepo, billerRepo, nil, purgeConfig)
+
+		var order []string
+		record := func(name string) func(mock.Arguments) {
+			return func(mock.Arguments) { order = append(order, name) }
+		}
+		pbRepo.On("Purge", ctx, mock.Anything, 2).Run(record("product billers")).Return(int64(2), nil).Twice()
+		pbRepo.On("Purge", ctx, mock.Anything, 2).Run(record("product billers")).Return(int64(1), nil).Once()
+		productRepo.On("Purge", ctx, mock.Anything, 2).Run(record("products")).Return(int64(0), nil).Once()
+		billerRepo.On("Purge", ctx, mock.Anything, 2).Run(record("billers")).Return(int64(1), nil).Once()
+
+		started := time.Now()
+		report, err := useCase.PurgeDeleted(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(5), report.ProductBillers)
+		assert.Equal(t, int64(0), report.Products)
+		assert.Equal(t, int64(1), report.Billers)
+		assert.WithinDuration(t, started.Add(-purgeConfig.Retention), report.Before, time.Minute)
+		assert.Equal(t, []string{"product billers", "product billers", "product billers", "products", "billers"}, order)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		productRepo := new(mocks.MockProductRepository)
+		useCase := usecases.NewPurgeUseCase(pbRepo, productRepo, nil, nil, purgeConfig)
+
+		pbRepo.On("Purge", ctx, mock.Anything, 2).Return(int64(0), nil)
+		productRepo.On("Purge", ctx, mock.Anything, 2).Return(int64(0), errors.New("lock wait timeout"))
+
+		_, err := useCase.PurgeDeleted(ctx)
+		assert.ErrorContains(t, err, "failed to purge products")
+	})
+}
+
+func TestPurgeUseCase_PurgeStats(t *testing.T) {
+	ctx := context.Background()
+	purgeConfig := config.Purge{StatRetention: 90 * 24 * time.Hour, StatTransactionRetention: 7 * 24 * time.Hour, BatchSize: 2}
+
+	t.Run("purges stats and recorded transactions past their retention", func(t *testing.T) {
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		useCase := usecases.NewPurgeUseCase(nil, nil, nil, statRepo, purgeConfig)
+
+		var statsBefore, transactionsBefore time.Time
+		statRepo.On("Purge", ctx, mock.Anything, 2).
+			Run(func(args mock.Arguments) { statsBefore = args.Get(1).(time.Time) }).
+			Return(int64(2), nil).Once()
+		statRepo.On("Purge", ctx, mock.Anything, 2).Return(int64(1), nil).Once()
+		statRepo.On("PurgeTransactions", ctx, mock.Anything, 2).
+			Run(func(args mock.Arguments) { transactionsBefore = args.Get(1).(time.Time) }).
+			Return(int64(0), nil).Once()
+
+		started := time.Now()
+		report, err := useCase.PurgeStats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(3), report.Stats)
+		assert.Equal(t, int64(0), report.Transactions)
+		assert.WithinDuration(t, started.Add(-purgeConfig.StatRetention), statsBefore, time.Minute)
+		assert.WithinDuration(t, started.Add(-purgeConfig.StatTransactionRetention), transactionsBefore, time.Minute)
+		assert.Equal(t, statsBefore, report.StatsBefore)
+		assert.Equal(t, transactionsBefore, report.TransactionsBefore)
+		statRepo.AssertExpectations(t)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		useCase := usecases.NewPurgeUseCase(nil, nil, nil, statRepo, purgeConfig)
+
+		statRepo.On("Purge", ctx, mock.Anything, 2).Return(int64(0), errors.New("lock wait timeout"))
+
+		_, err := useCase.PurgeStats(ctx)
+		assert.ErrorContains(t, err, "failed to purge product biller stats")
+		statRepo.AssertNotCalled(t, "PurgeTransactions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
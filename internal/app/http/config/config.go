@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -21,6 +22,25 @@ type Service struct {
 	Name      string `env:"HTTP_SERVICE_NAME" env-default:"http"`
 	Port      string `env:"HTTP_SERVICE_PORT" env-default:"8080"`
 	JwtSecret string `env:"HTTP_SERVICE_JWT_SECRET" env-required:"true"`
+	// CursorSecret signs pagination cursors. It is separate from JwtSecret so neither key signs the
+	// other's tokens.
+	CursorSecret string `env:"HTTP_SERVICE_CURSOR_SECRET" env-required:"true"`
+
+	// GRPCPort serves the gRPC API alongside the HTTP one, authenticated by the same JWTs.
+	GRPCPort string `env:"HTTP_SERVICE_GRPC_PORT" env-default:"9090"`
+
+	// RoutingStrategy selects between "weighted" and "round_robin" biller routing.
+	RoutingStrategy string `env:"HTTP_SERVICE_ROUTING_STRATEGY" env-default:"weighted"`
+
+	// ProductDeletionPolicy and BillerDeletionPolicy select between "cascade", "restrict" and "detach"
+	// handling of the product billers of a deleted product or biller.
+	ProductDeletionPolicy string `env:"HTTP_SERVICE_PRODUCT_DELETION_POLICY" env-default:"cascade"`
+	BillerDeletionPolicy  string `env:"HTTP_SERVICE_BILLER_DELETION_POLICY" env-default:"cascade"`
+
+	// V1DeprecatedAt and V1SunsetAt, dates in 2006-01-02 form, are announced on every /api/v1 response in
+	// the Deprecation and Sunset headers.
+	V1DeprecatedAt time.Time `env:"HTTP_SERVICE_V1_DEPRECATED_AT" env-layout:"2006-01-02" env-default:"2026-10-19"`
+	V1SunsetAt     time.Time `env:"HTTP_SERVICE_V1_SUNSET_AT" env-layout:"2006-01-02" env-default:"2027-04-19"`
 }
 
 // NewConfig initializes and returns the application configuration.
@@ -35,5 +55,9 @@ func NewConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to load environment variables: %w", err)
 	}
 
+	if cfg.Service.CursorSecret == cfg.Service.JwtSecret {
+		return nil, fmt.Errorf("HTTP_SERVICE_CURSOR_SECRET must differ from HTTP_SERVICE_JWT_SECRET")
+	}
+
 	return cfg, nil
 }
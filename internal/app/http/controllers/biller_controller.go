@@ -1,14 +1,17 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 
 	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/logger"
 	"golang-boilerplate/internal/pkg/models"
 	"golang-boilerplate/internal/pkg/utils"
@@ -16,15 +19,19 @@ import (
 
 // BillerController defines the HTTP layer for Biller entities.
 type BillerController struct {
-	usecases usecases.BillerUseCase
-	logger   *zerolog.Logger
+	usecases       usecases.BillerUseCase
+	productBillers usecases.ProductBillerUseCase
+	cursors        *db.CursorSigner
+	logger         *zerolog.Logger
 }
 
 // NewBillerController creates a new instance of BillerController.
-func NewBillerController(usecases usecases.BillerUseCase, logger *zerolog.Logger) *BillerController {
+func NewBillerController(usecases usecases.BillerUseCase, productBillers usecases.ProductBillerUseCase, cursors *db.CursorSigner, logger *zerolog.Logger) *BillerController {
 	return &BillerController{
-		usecases: usecases,
-		logger:   logger,
+		usecases:       usecases,
+		productBillers: productBillers,
+		cursors:        cursors,
+		logger:         logger,
 	}
 }
 
@@ -34,22 +41,22 @@ const eventClassBiller = "controller.biller"
 func (c *BillerController) Create(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
-	var biller *models.CreateBillerRequest
-	if err := ctx.Bind(biller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
-	}
-
-	validate := validator.New()
-	if err := validate.Struct(biller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	biller, err := bindRequest[models.CreateBillerRequest](ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := c.usecases.Create(reqCtx, biller.ToEntity()); err != nil {
+	createdBiller, err := c.usecases.Create(reqCtx, biller.ToEntity())
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassBiller, "Create", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusCreated, map[string]string{"message": "Biller created successfully"})
+	setETag(ctx, createdBiller.Version)
+	return created(ctx, createdBiller.ID, createdBiller.ToResponse())
 }
 
 // Update handles PUT requests to update an existing Biller.
@@ -61,22 +68,69 @@ func (c *BillerController) Update(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	var biller *models.UpdateBillerRequest
-	if err := ctx.Bind(biller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(biller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	biller, err := bindRequest[models.UpdateBillerRequest](ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := c.usecases.Update(reqCtx, id, biller.ToEntity()); err != nil {
+	if err := c.usecases.Update(reqCtx, id, version, biller.ToEntity()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassBiller, "Update", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"message": "Biller updated successfully"})
+	setETag(ctx, version+1)
+	return respondMessage(ctx, http.StatusOK, "Biller updated successfully")
+}
+
+// Patch handles PATCH requests to update some fields of an existing Biller.
+func (c *BillerController) Patch(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	var request models.PatchBillerRequest
+	if err := bindMergePatch(ctx, &request); err != nil {
+		return err
+	}
+
+	if err := c.usecases.Patch(reqCtx, id, version, request.ToPatch()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassBiller, "Patch", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	setETag(ctx, version+1)
+	return respondMessage(ctx, http.StatusOK, "Biller updated successfully")
 }
 
 // Delete handles DELETE requests to remove a Biller.
@@ -88,15 +142,60 @@ func (c *BillerController) Delete(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	if err := c.usecases.Delete(reqCtx, id); err != nil {
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.usecases.Delete(reqCtx, id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, usecases.ErrDeleteRestricted) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassBiller, "Delete", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"message": "Biller deleted successfully"})
+	return respondMessage(ctx, http.StatusOK, "Biller deleted successfully")
+}
+
+// Restore handles POST requests to restore a deleted Biller. With cascade=true, the ProductBillers
+// deleted along with it are restored too.
+func (c *BillerController) Restore(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	cascade, err := boolQueryParam(ctx, "cascade")
+	if err != nil {
+		return err
+	}
+
+	biller, err := c.usecases.Restore(reqCtx, id, cascade)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrNotDeleted) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassBiller, "Restore", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	setETag(ctx, biller.Version)
+	return respond(ctx, http.StatusOK, biller.ToResponse())
 }
 
-// FetchOne handles GET requests to retrieve a single Biller.
+// FetchOne handles GET requests to retrieve a single Biller, including a deleted one with include_deleted=true.
 func (c *BillerController) FetchOne(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
@@ -105,22 +204,36 @@ func (c *BillerController) FetchOne(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	biller, err := c.usecases.FetchOne(reqCtx, id)
+	includeDeleted, err := boolQueryParam(ctx, "include_deleted")
+	if err != nil {
+		return err
+	}
+
+	var biller *models.Biller
+	if includeDeleted {
+		biller, err = first(c.usecases.FetchMany(reqCtx, models.BillerFilter{ID: &id, IncludeDeleted: true}))
+	} else {
+		biller, err = c.usecases.FetchOne(reqCtx, id)
+	}
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		logger.Error(reqCtx, eventClassBiller, "FetchOne", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, biller.ToResponse())
+	setETag(ctx, biller.Version)
+	return respond(ctx, http.StatusOK, biller.ToResponse())
 }
 
 // FetchMany handles GET requests to retrieve multiple Billers based on filters.
 func (c *BillerController) FetchMany(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
-	filter := make(map[string]interface{})
-	if label := ctx.QueryParam("label"); label != "" {
-		filter["label"] = label
+	var filter models.BillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.BillerSortColumns); err != nil {
+		return err
 	}
 
 	billers, err := c.usecases.FetchMany(reqCtx, filter)
@@ -132,7 +245,42 @@ func (c *BillerController) FetchMany(ctx echo.Context) error {
 	response := utils.TransformSlice(billers, func(biller *models.Biller) *models.BillerResponse {
 		return biller.ToResponse()
 	})
-	return ctx.JSON(http.StatusOK, response)
+	return respondList(ctx, response, nil)
+}
+
+// billerExportColumns are the columns of a Biller export.
+var billerExportColumns = []string{
+	"id", "label", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version",
+}
+
+func billerExportRecord(biller *models.Biller) []interface{} {
+	return []interface{}{
+		biller.ID, biller.Label, biller.CreatedAt, biller.CreatedBy, biller.UpdatedAt, biller.UpdatedBy, biller.DeletedAt, biller.DeletedBy,
+		biller.Version,
+	}
+}
+
+// Export handles GET requests to download the Billers matching the filters as a csv, json or xlsx file.
+// Rows are streamed to the client as they are read.
+func (c *BillerController) Export(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	var filter models.BillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.BillerSortColumns); err != nil {
+		return err
+	}
+
+	return streamExport(ctx, reqCtx, logger, eventClassBiller, "Export", format, "billers", billerExportColumns,
+		func(write func(record []interface{}) error) error {
+			return c.usecases.Export(reqCtx, filter, func(biller *models.Biller) error {
+				return write(billerExportRecord(biller))
+			})
+		})
 }
 
 // FetchManyWithPagination handles GET requests to retrieve paginated Billers.
@@ -149,13 +297,28 @@ func (c *BillerController) FetchManyWithPagination(ctx echo.Context) error {
 		limit = 10
 	}
 
-	filter := make(map[string]interface{})
-	if label := ctx.QueryParam("label"); label != "" {
-		filter["label"] = label
+	var filter models.BillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.BillerSortColumns); err != nil {
+		return err
 	}
 
-	billers, pagination, err := c.usecases.FetchManyWithPagination(reqCtx, filter, page, limit)
+	cursorPage, err := cursorPagination(ctx, c.cursors, limit)
 	if err != nil {
+		return err
+	}
+
+	var billers []*models.Biller
+	var pagination interface{}
+	if cursorPage != nil {
+		billers, err = c.usecases.FetchManyWithCursor(reqCtx, filter, cursorPage)
+		pagination = cursorPage
+	} else {
+		billers, pagination, err = c.usecases.FetchManyWithPagination(reqCtx, filter, page, limit)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
 		logger.Error(reqCtx, eventClassBiller, "FetchManyWithPagination", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -163,8 +326,38 @@ func (c *BillerController) FetchManyWithPagination(ctx echo.Context) error {
 	response := utils.TransformSlice(billers, func(biller *models.Biller) *models.BillerResponse {
 		return biller.ToResponse()
 	})
-	return ctx.JSON(http.StatusOK, map[string]interface{}{
-		"data":       response,
-		"pagination": pagination,
-	})
+	return respondList(ctx, response, pagination)
+}
+
+// FetchProducts handles GET requests to list the products of a Biller, as its product billers embedding their
+// product. The filters and pagination of the product biller list apply, and expand=biller embeds the biller too.
+func (c *BillerController) FetchProducts(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	var filter models.ProductBillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductBillerSortColumns); err != nil {
+		return err
+	}
+	filter.BillerID = &id
+
+	expand, err := parseExpand(ctx)
+	if err != nil {
+		return err
+	}
+	expand.Product = true
+
+	if _, err := c.usecases.FetchOne(reqCtx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		logger.Error(reqCtx, eventClassBiller, "FetchProducts", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return productBillerPage(ctx, c.productBillers, c.cursors, c.logger, filter, expand, eventClassBiller, "FetchProducts")
 }
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+func TestBillerController_FetchProducts(t *testing.T) {
+	nop := zerolog.Nop()
+	billerID := func(filter models.ProductBillerFilter) *int { return filter.BillerID }
+
+	newController := func() (*BillerController, *mocks.MockBillerRepository, *mocks.MockProductBillerRepository) {
+		mockUow := new(mocks.MockUnitOfWork)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		controller := NewBillerController(
+			usecases.NewBillerUseCase(mockBillerRepo, mockUow, usecases.DeletionPolicyCascade),
+			usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, mockUow),
+			db.NewCursorSigner("cursor-secret"),
+			&nop,
+		)
+		return controller, mockBillerRepo, mockProductBillerRepo
+	}
+
+	fetch := func(controller *BillerController, query string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		e.Validator = validation.New()
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/billers/3/products"+query, nil), rec)
+		ctx.SetParamNames("id")
+		ctx.SetParamValues("3")
+		return rec, controller.FetchProducts(ctx)
+	}
+
+	t.Run("answers 404 when the biller is missing", func(t *testing.T) {
+		controller, mockBillerRepo, mockProductBillerRepo := newController()
+		mockBillerRepo.On("FetchOne", mock.Anything, 3).Return(nil, fmt.Errorf("failed to fetch biller: %w", sql.ErrNoRows))
+
+		_, err := fetch(controller, "")
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		mockProductBillerRepo.AssertNotCalled(t, "FetchManyDetailedWithPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("lists the product billers of the biller with their products", func(t *testing.T) {
+		controller, mockBillerRepo, mockProductBillerRepo := newController()
+		biller := &models.Biller{ID: 3, Label: "Telkomsel"}
+		mockBillerRepo.On("FetchOne", mock.Anything, 3).Return(biller, nil)
+		mockProductBillerRepo.On("FetchManyDetailedWithPagination", mock.Anything, ofParent(billerID, 3), 2, 1).Return([]*models.ProductBillerDetail{{
+			ProductBiller: models.ProductBiller{ID: 7, ProductID: 1, BillerID: 3, IsActive: true},
+			Product:       &models.Product{ID: 1, Label: "Pulsa"},
+			Biller:        biller,
+		}}, &db.Pagination{Page: 2, Limit: 1, TotalRows: 2, TotalPages: 2}, nil)
+
+		rec, err := fetch(controller, "?page=2&limit=1")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var page productBillerList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		require.Len(t, page.Data, 1)
+		assert.Equal(t, 7, page.Data[0].ID)
+		require.NotNil(t, page.Data[0].Product)
+		assert.Equal(t, "Pulsa", page.Data[0].Product.Label)
+		assert.Nil(t, page.Data[0].Biller, "the biller is only embedded with expand=biller")
+		assert.Equal(t, db.Pagination{Page: 2, Limit: 1, TotalRows: 2, TotalPages: 2}, page.Pagination)
+	})
+
+	t.Run("embeds the biller with expand=biller", func(t *testing.T) {
+		controller, mockBillerRepo, mockProductBillerRepo := newController()
+		biller := &models.Biller{ID: 3, Label: "Telkomsel"}
+		mockBillerRepo.On("FetchOne", mock.Anything, 3).Return(biller, nil)
+		mockProductBillerRepo.On("FetchManyDetailedWithPagination", mock.Anything, ofParent(billerID, 3), 1, 10).Return([]*models.ProductBillerDetail{{
+			ProductBiller: models.ProductBiller{ID: 7, ProductID: 1, BillerID: 3},
+			Product:       &models.Product{ID: 1, Label: "Pulsa"},
+			Biller:        biller,
+		}}, &db.Pagination{Page: 1, Limit: 10, TotalRows: 1, TotalPages: 1}, nil)
+
+		rec, err := fetch(controller, "?expand=biller")
+		require.NoError(t, err)
+
+		var page productBillerList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		require.Len(t, page.Data, 1)
+		require.NotNil(t, page.Data[0].Biller)
+		assert.Equal(t, "Telkomsel", page.Data[0].Biller.Label)
+		assert.NotNil(t, page.Data[0].Product)
+	})
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+// DocsController serves the OpenAPI document of the API and a Swagger UI browsing it.
+type DocsController struct {
+	spec []byte
+	ui   []byte
+}
+
+// NewDocsController creates a new instance of DocsController serving doc, which the UI loads from specURL, along
+// with its assets from assetsURL.
+func NewDocsController(doc *openapi.Document, specURL, assetsURL string) (*DocsController, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	ui, err := openapi.SwaggerUI(doc.Info.Title, specURL, assetsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Swagger UI: %w", err)
+	}
+
+	return &DocsController{
+		spec: spec,
+		ui:   ui,
+	}, nil
+}
+
+// Spec handles GET requests for the OpenAPI document.
+func (c *DocsController) Spec(ctx echo.Context) error {
+	return ctx.Blob(http.StatusOK, echo.MIMEApplicationJSON, c.spec)
+}
+
+// UI handles GET requests for the Swagger UI.
+func (c *DocsController) UI(ctx echo.Context) error {
+	return ctx.HTMLBlob(http.StatusOK, c.ui)
+}
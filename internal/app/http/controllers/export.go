@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/pkg/export"
+	"golang-boilerplate/internal/pkg/logger"
+)
+
+// exportFlushRows is the number of exported rows after which the response is flushed to the client.
+const exportFlushRows = 500
+
+// exportFormat returns the export format requested by the format query parameter, csv by default.
+func exportFormat(ctx echo.Context) (export.Format, error) {
+	raw := ctx.QueryParam("format")
+	if raw == "" {
+		return export.FormatCSV, nil
+	}
+
+	format, err := export.ParseFormat(raw)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format: must be one of %v", export.Formats))
+	}
+	return format, nil
+}
+
+// streamExport downloads the records fetch passes to write as a file named name in the given format.
+//
+// The response is only committed once fetch produces its first record, or returns without any, so a query
+// that fails to run still gets an error response. Once records are streamed the status can no longer
+// change: a later failure aborts the connection, so the client sees a truncated download rather than a
+// complete file.
+func streamExport(
+	ctx echo.Context,
+	reqCtx context.Context,
+	logger *logger.AppLogger,
+	eventClass, operation string,
+	format export.Format,
+	name string,
+	columns []string,
+	fetch func(write func(record []interface{}) error) error,
+) error {
+	response := ctx.Response()
+
+	var writer export.Writer
+	start := func() error {
+		response.Header().Set(echo.HeaderContentType, format.ContentType())
+		response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+		response.WriteHeader(http.StatusOK)
+
+		var err error
+		writer, err = export.NewWriter(format, response, columns)
+		return err
+	}
+
+	rows := 0
+	err := fetch(func(record []interface{}) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushRows == 0 {
+			response.Flush()
+		}
+		return nil
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return nil
+	}
+
+	logger.Error(reqCtx, eventClass, operation, err.Error())
+	if !response.Committed {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	panic(http.ErrAbortHandler)
+}
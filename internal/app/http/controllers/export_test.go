@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/export"
+	"golang-boilerplate/internal/pkg/logger"
+)
+
+func TestStreamExport(t *testing.T) {
+	nop := zerolog.Nop()
+	columns := []string{"id", "label"}
+
+	stream := func(fetch func(write func(record []interface{}) error) error) (*httptest.ResponseRecorder, error) {
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/products/export", nil), rec)
+		reqCtx, log := logger.NewAppLoggerEcho(ctx, &nop)
+		return rec, streamExport(ctx, reqCtx, log, eventClassProduct, "Export", export.FormatCSV, "products", columns, fetch)
+	}
+
+	t.Run("streams the records", func(t *testing.T) {
+		rec, err := stream(func(write func(record []interface{}) error) error {
+			if err := write([]interface{}{1, "Pulsa"}); err != nil {
+				return err
+			}
+			return write([]interface{}{2, "Data"})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="products.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "id,label\n1,Pulsa\n2,Data\n", rec.Body.String())
+	})
+
+	t.Run("writes the header of an empty export", func(t *testing.T) {
+		rec, err := stream(func(func(record []interface{}) error) error { return nil })
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "id,label\n", rec.Body.String())
+	})
+
+	t.Run("answers an error when the query fails", func(t *testing.T) {
+		rec, err := stream(func(func(record []interface{}) error) error { return errors.New("connection refused") })
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		assert.False(t, rec.Flushed)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("aborts the connection when streaming fails", func(t *testing.T) {
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			_, _ = stream(func(write func(record []interface{}) error) error {
+				if err := write([]interface{}{1, "Pulsa"}); err != nil {
+					return err
+				}
+				return errors.New("connection reset")
+			})
+		})
+	})
+}
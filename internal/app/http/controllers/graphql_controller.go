@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/graph"
+	"golang-boilerplate/internal/pkg/logger"
+)
+
+// GraphQLController defines the HTTP layer of the GraphQL schema of the catalog.
+type GraphQLController struct {
+	schema *graph.Schema
+	logger *zerolog.Logger
+}
+
+// NewGraphQLController creates a new instance of GraphQLController.
+func NewGraphQLController(schema *graph.Schema, logger *zerolog.Logger) *GraphQLController {
+	return &GraphQLController{
+		schema: schema,
+		logger: logger,
+	}
+}
+
+const eventClassGraphQL = "controller.graphql"
+
+// graphQLRequest is a GraphQL query, sent as a JSON body or, with GET, as query parameters holding the
+// variables as JSON.
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query handles GET and POST requests executing a GraphQL query. Errors of the query are reported in the
+// errors of the response body, as GraphQL responses do.
+func (c *GraphQLController) Query(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	var request graphQLRequest
+	if ctx.Request().Method == http.MethodGet {
+		request.Query = ctx.QueryParam("query")
+		request.OperationName = ctx.QueryParam("operationName")
+		if variables := ctx.QueryParam("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid variables: must be a JSON object")
+			}
+		}
+	} else if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if request.Query == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request: query is required")
+	}
+
+	response := c.schema.Exec(reqCtx, request.Query, request.OperationName, request.Variables)
+	for _, err := range response.Errors {
+		// Errors raised by resolvers, unlike those of the query itself or of its arguments, are failures of the service.
+		if err.ResolverError != nil && !errors.Is(err.ResolverError, graph.ErrInvalidArgument) {
+			logger.Error(reqCtx, eventClassGraphQL, "Query", err.Error())
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/logger"
+)
+
+// JobController defines the HTTP layer for following and canceling background jobs.
+type JobController struct {
+	usecases usecases.JobUseCase
+	logger   *zerolog.Logger
+}
+
+// NewJobController creates a new instance of JobController.
+func NewJobController(usecases usecases.JobUseCase, logger *zerolog.Logger) *JobController {
+	return &JobController{
+		usecases: usecases,
+		logger:   logger,
+	}
+}
+
+const eventClassJob = "controller.job"
+
+// FetchOne handles GET requests to poll the status, progress and result of a Job.
+func (c *JobController) FetchOne(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	job, err := c.usecases.FetchOne(reqCtx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		logger.Error(reqCtx, eventClassJob, "FetchOne", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(ctx, http.StatusOK, job.ToResponse())
+}
+
+// Cancel handles POST requests to cancel a Job. A pending job is canceled right away, while a running
+// job stops at its next progress report, so clients poll the job until its status is final.
+func (c *JobController) Cancel(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	job, err := c.usecases.Cancel(reqCtx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrJobFinished) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassJob, "Cancel", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(ctx, http.StatusAccepted, job.ToResponse())
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// bindFilter binds and validates the query parameters of a list endpoint into filter, then parses
+// the sort parameter against sortColumns into list, the ListFilter embedded in filter.
+func bindFilter(ctx echo.Context, filter interface{}, list *models.ListFilter, sortColumns map[string]string) error {
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, filter); err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid query parameters: %v", httpErr.Message))
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid query parameters: %s", err.Error()))
+	}
+
+	if err := validate(ctx, filter); err != nil {
+		return err
+	}
+
+	sort, err := db.ParseSort(ctx.QueryParam("sort"), sortColumns)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid sort: %s", err.Error()))
+	}
+	list.Sort = sort
+
+	return nil
+}
+
+// cursorPagination reads the keyset pagination parameters of a list endpoint. It returns nil when the
+// request has no cursor parameter and so uses page based pagination; an empty cursor requests the first page.
+// Counting the total rows is opt-in through count=true, since skipping it is the point of cursors.
+func cursorPagination(ctx echo.Context, signer *db.CursorSigner, limit int) (*db.CursorPagination, error) {
+	params := ctx.QueryParams()
+	if !params.Has("cursor") {
+		return nil, nil
+	}
+	if params.Has("page") {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters: page and cursor cannot be combined")
+	}
+
+	var countRows bool
+	if raw := params.Get("count"); raw != "" {
+		var err error
+		if countRows, err = strconv.ParseBool(raw); err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters: count must be a boolean")
+		}
+	}
+
+	return &db.CursorPagination{
+		Signer:    signer,
+		Cursor:    params.Get("cursor"),
+		Limit:     limit,
+		CountRows: countRows,
+	}, nil
+}
+
+// boolQueryParam reads an optional boolean query parameter, which is false when absent.
+func boolQueryParam(ctx echo.Context, name string) (bool, error) {
+	raw := ctx.QueryParam(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid query parameters: %s must be a boolean", name))
+	}
+	return value, nil
+}
+
+// parseExpand reads the expand query parameter of the product biller lists, a comma-separated list of the
+// related resources to embed in each product biller: product and biller.
+func parseExpand(ctx echo.Context) (models.ProductBillerExpand, error) {
+	var expand models.ProductBillerExpand
+	raw := ctx.QueryParam("expand")
+	if raw == "" {
+		return expand, nil
+	}
+
+	for _, resource := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(resource) {
+		case "product":
+			expand.Product = true
+		case "biller":
+			expand.Biller = true
+		default:
+			return expand, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid expand: unknown resource %q, must be product or biller", resource))
+		}
+	}
+	return expand, nil
+}
+
+// first returns the first of the entities listed by a filter on ID, or sql.ErrNoRows when there is none.
+func first[T any](entities []*T, err error) (*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return entities[0], nil
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func newQueryContext(query string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return newTestEcho().NewContext(req, httptest.NewRecorder())
+}
+
+func TestBindFilter(t *testing.T) {
+	t.Run("binds typed values", func(t *testing.T) {
+		var filter models.ProductBillerFilter
+		ctx := newQueryContext("product_id=1&is_active=false&created_from=2026-10-01T00:00:00Z&sort=-priority")
+
+		require.NoError(t, bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductBillerSortColumns))
+		require.NotNil(t, filter.ProductID)
+		assert.Equal(t, 1, *filter.ProductID)
+		assert.Nil(t, filter.BillerID)
+		require.NotNil(t, filter.IsActive)
+		assert.False(t, *filter.IsActive)
+		require.NotNil(t, filter.CreatedFrom)
+		assert.Equal(t, "priority DESC, id ASC", filter.Sort.OrderOr("id ASC"))
+	})
+
+	for name, query := range map[string]string{
+		"non numeric id":    "product_id=abc",
+		"non positive id":   "biller_id=0",
+		"invalid bool":      "is_active=maybe",
+		"invalid timestamp": "created_to=yesterday",
+		"unknown sort":      "sort=password",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var filter models.ProductBillerFilter
+			err := bindFilter(newQueryContext(query), &filter, &filter.ListFilter, repositories.ProductBillerSortColumns)
+
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		})
+	}
+
+	t.Run("validates notification status", func(t *testing.T) {
+		var filter models.NotificationFilter
+		err := bindFilter(newQueryContext("status=lost"), &filter, &filter.ListFilter, repositories.NotificationSortColumns)
+		assert.Error(t, err)
+	})
+}
+
+func TestCursorPagination(t *testing.T) {
+	signer := db.NewCursorSigner("secret")
+
+	pagination, err := cursorPagination(newQueryContext("page=2"), signer, 10)
+	require.NoError(t, err)
+	assert.Nil(t, pagination, "page based requests keep offset pagination")
+
+	pagination, err = cursorPagination(newQueryContext("cursor=&count=true"), signer, 25)
+	require.NoError(t, err)
+	require.NotNil(t, pagination)
+	assert.Empty(t, pagination.Cursor)
+	assert.Equal(t, 25, pagination.Limit)
+	assert.True(t, pagination.CountRows)
+
+	for name, query := range map[string]string{
+		"page and cursor": "cursor=abc&page=2",
+		"invalid count":   "cursor=&count=maybe",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := cursorPagination(newQueryContext(query), signer, 10)
+
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		})
+	}
+}
+
+func TestParseExpand(t *testing.T) {
+	expand, err := parseExpand(newQueryContext(""))
+	require.NoError(t, err)
+	assert.False(t, expand.Any())
+
+	expand, err = parseExpand(newQueryContext("expand=biller"))
+	require.NoError(t, err)
+	assert.Equal(t, models.ProductBillerExpand{Biller: true}, expand)
+
+	expand, err = parseExpand(newQueryContext("expand=product,%20biller"))
+	require.NoError(t, err)
+	assert.Equal(t, models.ProductBillerExpand{Product: true, Biller: true}, expand)
+
+	_, err = parseExpand(newQueryContext("expand=product,owner"))
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+)
+
+// mimeMergePatch is the media type of JSON Merge Patch documents (RFC 7386).
+const mimeMergePatch = "application/merge-patch+json"
+
+// bindMergePatch binds and validates a JSON Merge Patch body into patch, a struct of pointer fields that
+// stay nil for the members the body leaves out. The body must be an object; since none of the patchable
+// fields can be removed, members set to null are rejected rather than treated as removals. Plain
+// application/json is accepted as well for clients that cannot set the merge patch media type.
+func bindMergePatch(ctx echo.Context, patch interface{}) error {
+	mediaType, _, err := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || (mediaType != mimeMergePatch && mediaType != echo.MIMEApplicationJSON) {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, fmt.Sprintf("Content-Type must be %s", mimeMergePatch))
+	}
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input: merge patch must be a JSON object")
+	}
+	var nulls []string
+	for name, value := range members {
+		if string(value) == "null" {
+			nulls = append(nulls, name)
+		}
+	}
+	if len(nulls) > 0 {
+		sort.Strings(nulls)
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid input: %v cannot be removed", nulls))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patch); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid input: %s", err.Error()))
+	}
+
+	return validate(ctx, patch)
+}
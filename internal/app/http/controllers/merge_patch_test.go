@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestBindMergePatch(t *testing.T) {
+	newContext := func(contentType, body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/product-billers/1", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		return newTestEcho().NewContext(req, httptest.NewRecorder())
+	}
+
+	t.Run("binds the present members only", func(t *testing.T) {
+		var request models.PatchProductBillerRequest
+		require.NoError(t, bindMergePatch(newContext(mimeMergePatch, `{"is_active": false}`), &request))
+		require.NotNil(t, request.IsActive)
+		assert.False(t, *request.IsActive)
+		assert.Nil(t, request.Priority)
+		assert.Nil(t, request.Weight)
+	})
+
+	for name, tc := range map[string]struct {
+		contentType string
+		body        string
+		status      int
+	}{
+		"unsupported media type": {contentType: echo.MIMETextPlain, body: `{}`, status: http.StatusUnsupportedMediaType},
+		"not an object":          {contentType: mimeMergePatch, body: `[1]`, status: http.StatusBadRequest},
+		"null member":            {contentType: mimeMergePatch, body: `{"weight": null}`, status: http.StatusBadRequest},
+		"unknown member":         {contentType: mimeMergePatch, body: `{"biller_id": 2}`, status: http.StatusBadRequest},
+		"invalid value":          {contentType: echo.MIMEApplicationJSON, body: `{"weight": -1}`, status: http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var request models.PatchProductBillerRequest
+			err := bindMergePatch(newContext(tc.contentType, tc.body), &request)
+
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tc.status, httpErr.Code)
+		})
+	}
+}
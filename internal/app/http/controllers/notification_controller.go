@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/utils"
+)
+
+// NotificationController defines the HTTP layer for administering the notification queue.
+type NotificationController struct {
+	usecases usecases.NotificationUseCase
+	cursors  *db.CursorSigner
+	logger   *zerolog.Logger
+}
+
+// NewNotificationController creates a new instance of NotificationController.
+func NewNotificationController(usecases usecases.NotificationUseCase, cursors *db.CursorSigner, logger *zerolog.Logger) *NotificationController {
+	return &NotificationController{
+		usecases: usecases,
+		cursors:  cursors,
+		logger:   logger,
+	}
+}
+
+const eventClassNotification = "controller.notification"
+
+// FetchOne handles GET requests to retrieve a single queued notification.
+func (c *NotificationController) FetchOne(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	notification, err := c.usecases.FetchOne(reqCtx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		logger.Error(reqCtx, eventClassNotification, "FetchOne", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(ctx, http.StatusOK, notification.ToResponse())
+}
+
+// FetchManyWithPagination handles GET requests to list queued notifications, newest first.
+func (c *NotificationController) FetchManyWithPagination(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	var filter models.NotificationFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.NotificationSortColumns); err != nil {
+		return err
+	}
+
+	cursorPage, err := cursorPagination(ctx, c.cursors, limit)
+	if err != nil {
+		return err
+	}
+
+	var notifications []*models.QueuedNotification
+	var pagination interface{}
+	if cursorPage != nil {
+		notifications, err = c.usecases.FetchManyWithCursor(reqCtx, filter, cursorPage)
+		pagination = cursorPage
+	} else {
+		notifications, pagination, err = c.usecases.FetchManyWithPagination(reqCtx, filter, page, limit)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		logger.Error(reqCtx, eventClassNotification, "FetchManyWithPagination", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := utils.TransformSlice(notifications, func(n *models.QueuedNotification) *models.QueuedNotificationResponse {
+		return n.ToResponse()
+	})
+	return respondList(ctx, response, pagination)
+}
+
+// Resend handles POST requests to queue a failed notification for delivery again.
+func (c *NotificationController) Resend(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	if err := c.usecases.Resend(reqCtx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrNotificationNotFailed) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassNotification, "Resend", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respondMessage(ctx, http.StatusAccepted, "Notification queued for resend")
+}
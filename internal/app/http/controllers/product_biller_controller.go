@@ -1,14 +1,18 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 
 	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/auth"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/logger"
 	"golang-boilerplate/internal/pkg/models"
 	"golang-boilerplate/internal/pkg/utils"
@@ -17,13 +21,17 @@ import (
 // ProductBillerController defines the HTTP layer for ProductBiller entities.
 type ProductBillerController struct {
 	usecases usecases.ProductBillerUseCase
+	jobs     usecases.JobUseCase
+	cursors  *db.CursorSigner
 	logger   *zerolog.Logger
 }
 
 // NewProductBillerController creates a new instance of ProductBillerController.
-func NewProductBillerController(usecases usecases.ProductBillerUseCase, logger *zerolog.Logger) *ProductBillerController {
+func NewProductBillerController(usecases usecases.ProductBillerUseCase, jobs usecases.JobUseCase, cursors *db.CursorSigner, logger *zerolog.Logger) *ProductBillerController {
 	return &ProductBillerController{
 		usecases: usecases,
+		jobs:     jobs,
+		cursors:  cursors,
 		logger:   logger,
 	}
 }
@@ -34,22 +42,22 @@ const eventClassProductBiller = "controller.productBiller"
 func (c *ProductBillerController) Create(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
-	var productBiller *models.CreateProductBillerRequest
-	if err := ctx.Bind(productBiller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
-	}
-
-	validate := validator.New()
-	if err := validate.Struct(productBiller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	productBiller, err := bindRequest[models.CreateProductBillerRequest](ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := c.usecases.Create(reqCtx, productBiller.ToEntity()); err != nil {
+	createdProductBiller, err := c.usecases.Create(reqCtx, productBiller.ToEntity())
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProductBiller, "Create", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusCreated, map[string]string{"message": "Product Biller created successfully"})
+	setETag(ctx, createdProductBiller.Version)
+	return created(ctx, createdProductBiller.ID, createdProductBiller.ToResponse())
 }
 
 // Update handles PUT requests to update an existing ProductBiller.
@@ -61,22 +69,77 @@ func (c *ProductBillerController) Update(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	var productBiller *models.UpdateProductBillerRequest
-	if err := ctx.Bind(productBiller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(productBiller); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	productBiller, err := bindRequest[models.UpdateProductBillerRequest](ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := c.usecases.Update(reqCtx, id, productBiller.ToEntity()); err != nil {
+	// Record who made the change so manual deactivations can be attributed.
+	entity := productBiller.ToEntity()
+	entity.UpdatedBy = auth.GetUser(ctx).Username
+
+	if err := c.usecases.Update(reqCtx, id, version, entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProductBiller, "Update", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"message": "Product Biller updated successfully"})
+	setETag(ctx, version+1)
+	return respondMessage(ctx, http.StatusOK, "Product Biller updated successfully")
+}
+
+// Patch handles PATCH requests to update some fields of an existing ProductBiller.
+func (c *ProductBillerController) Patch(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	var request models.PatchProductBillerRequest
+	if err := bindMergePatch(ctx, &request); err != nil {
+		return err
+	}
+
+	// Record who made the change so manual deactivations can be attributed.
+	patch := request.ToPatch()
+	patch.UpdatedBy = auth.GetUser(ctx).Username
+
+	if err := c.usecases.Patch(reqCtx, id, version, patch); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassProductBiller, "Patch", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	setETag(ctx, version+1)
+	return respondMessage(ctx, http.StatusOK, "Product Biller updated successfully")
 }
 
 // Delete handles DELETE requests to remove a ProductBiller.
@@ -88,15 +151,51 @@ func (c *ProductBillerController) Delete(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	if err := c.usecases.Delete(reqCtx, id); err != nil {
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.usecases.Delete(reqCtx, id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProductBiller, "Delete", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"message": "Product Biller deleted successfully"})
+	return respondMessage(ctx, http.StatusOK, "Product Biller deleted successfully")
 }
 
-// FetchOne handles GET requests to retrieve a single ProductBiller.
+// Restore handles POST requests to restore a deleted ProductBiller, provided its Product and Biller are not deleted.
+func (c *ProductBillerController) Restore(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	productBiller, err := c.usecases.Restore(reqCtx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrNotDeleted) || errors.Is(err, usecases.ErrParentDeleted) || errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassProductBiller, "Restore", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	setETag(ctx, productBiller.Version)
+	return respond(ctx, http.StatusOK, productBiller.ToResponse())
+}
+
+// FetchOne handles GET requests to retrieve a single ProductBiller, including a deleted one with include_deleted=true.
 func (c *ProductBillerController) FetchOne(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
@@ -105,42 +204,93 @@ func (c *ProductBillerController) FetchOne(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	productBiller, err := c.usecases.FetchOne(reqCtx, id)
+	includeDeleted, err := boolQueryParam(ctx, "include_deleted")
 	if err != nil {
+		return err
+	}
+
+	var productBiller *models.ProductBiller
+	if includeDeleted {
+		productBiller, err = first(c.usecases.FetchMany(reqCtx, models.ProductBillerFilter{ID: &id, IncludeDeleted: true}))
+	} else {
+		productBiller, err = c.usecases.FetchOne(reqCtx, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProductBiller, "FetchOne", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, productBiller.ToResponse())
+	setETag(ctx, productBiller.Version)
+	return respond(ctx, http.StatusOK, productBiller.ToResponse())
 }
 
-// FetchMany handles GET requests to retrieve multiple ProductBillers based on filters.
+// FetchMany handles GET requests to retrieve multiple ProductBillers based on filters, embedding the
+// resources selected by expand.
 func (c *ProductBillerController) FetchMany(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
-	filter := make(map[string]interface{})
-	if productID := ctx.QueryParam("product_id"); productID != "" {
-		filter["product_id"] = productID
+	var filter models.ProductBillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductBillerSortColumns); err != nil {
+		return err
 	}
-	if billerID := ctx.QueryParam("biller_id"); billerID != "" {
-		filter["biller_id"] = billerID
+
+	expand, err := parseExpand(ctx)
+	if err != nil {
+		return err
 	}
 
-	productBillers, err := c.usecases.FetchMany(reqCtx, filter)
+	var response []*models.ProductBillerResponse
+	if expand.Any() {
+		var details []*models.ProductBillerDetail
+		details, err = c.usecases.FetchManyDetailed(reqCtx, filter)
+		response = detailResponses(details, expand)
+	} else {
+		var productBillers []*models.ProductBiller
+		productBillers, err = c.usecases.FetchMany(reqCtx, filter)
+		response = utils.TransformSlice(productBillers, func(pb *models.ProductBiller) *models.ProductBillerResponse {
+			return pb.ToResponse()
+		})
+	}
 	if err != nil {
 		logger.Error(reqCtx, eventClassProductBiller, "FetchMany", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	response := utils.TransformSlice(productBillers, func(pb *models.ProductBiller) *models.ProductBillerResponse {
-		return pb.ToResponse()
-	})
-	return ctx.JSON(http.StatusOK, response)
+	return respondList(ctx, response, nil)
 }
 
 // FetchManyWithPagination handles GET requests to retrieve paginated ProductBillers.
 func (c *ProductBillerController) FetchManyWithPagination(ctx echo.Context) error {
-	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+	var filter models.ProductBillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductBillerSortColumns); err != nil {
+		return err
+	}
+
+	expand, err := parseExpand(ctx)
+	if err != nil {
+		return err
+	}
+
+	return productBillerPage(ctx, c.usecases, c.cursors, c.logger, filter, expand, eventClassProductBiller, "FetchManyWithPagination")
+}
+
+// productBillerPage responds with a page of the product billers matching the filter, by page number or by
+// cursor, embedding the resources selected by expand. Expanded pages are fetched with their product and
+// biller in a single query. It serves the product biller list as well as the lists nested under a product
+// or a biller, whose errors are logged under their own event class and operation.
+func productBillerPage(
+	ctx echo.Context,
+	productBillers usecases.ProductBillerUseCase,
+	cursors *db.CursorSigner,
+	log *zerolog.Logger,
+	filter models.ProductBillerFilter,
+	expand models.ProductBillerExpand,
+	eventClass, operation string,
+) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, log)
 
 	page, err := strconv.Atoi(ctx.QueryParam("page"))
 	if err != nil || page < 1 {
@@ -152,25 +302,137 @@ func (c *ProductBillerController) FetchManyWithPagination(ctx echo.Context) erro
 		limit = 10
 	}
 
-	filter := make(map[string]interface{})
-	if productID := ctx.QueryParam("product_id"); productID != "" {
-		filter["product_id"] = productID
-	}
-	if billerID := ctx.QueryParam("biller_id"); billerID != "" {
-		filter["biller_id"] = billerID
+	cursorPage, err := cursorPagination(ctx, cursors, limit)
+	if err != nil {
+		return err
 	}
 
-	productBillers, pagination, err := c.usecases.FetchManyWithPagination(reqCtx, filter, page, limit)
+	var response []*models.ProductBillerResponse
+	var pagination interface{}
+	if expand.Any() {
+		var details []*models.ProductBillerDetail
+		if cursorPage != nil {
+			details, err = productBillers.FetchManyDetailedWithCursor(reqCtx, filter, cursorPage)
+			pagination = cursorPage
+		} else {
+			details, pagination, err = productBillers.FetchManyDetailedWithPagination(reqCtx, filter, page, limit)
+		}
+		response = detailResponses(details, expand)
+	} else {
+		var entities []*models.ProductBiller
+		if cursorPage != nil {
+			entities, err = productBillers.FetchManyWithCursor(reqCtx, filter, cursorPage)
+			pagination = cursorPage
+		} else {
+			entities, pagination, err = productBillers.FetchManyWithPagination(reqCtx, filter, page, limit)
+		}
+		response = utils.TransformSlice(entities, func(pb *models.ProductBiller) *models.ProductBillerResponse {
+			return pb.ToResponse()
+		})
+	}
 	if err != nil {
-		logger.Error(reqCtx, eventClassProductBiller, "FetchManyWithPagination", err.Error())
+		if errors.Is(err, db.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		logger.Error(reqCtx, eventClass, operation, err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	response := utils.TransformSlice(productBillers, func(pb *models.ProductBiller) *models.ProductBillerResponse {
-		return pb.ToResponse()
-	})
-	return ctx.JSON(http.StatusOK, map[string]interface{}{
-		"data":       response,
-		"pagination": pagination,
+	return respondList(ctx, response, pagination)
+}
+
+func detailResponses(details []*models.ProductBillerDetail, expand models.ProductBillerExpand) []*models.ProductBillerResponse {
+	return utils.TransformSlice(details, func(d *models.ProductBillerDetail) *models.ProductBillerResponse {
+		return d.ToResponse(expand)
 	})
 }
+
+// Import handles POST requests to create ProductBillers in bulk, from a JSON array or a CSV file,
+// responding with the outcome of every row. With async=true the import is queued as a job instead,
+// and the outcome becomes its result.
+func (c *ProductBillerController) Import(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	async, err := boolQueryParam(ctx, "async")
+	if err != nil {
+		return err
+	}
+
+	rows, err := parseProductBillerImport(ctx)
+	if err != nil {
+		return err
+	}
+
+	if async {
+		job, err := c.jobs.Enqueue(reqCtx, models.JobTypeProductBillerImport, models.ProductBillerImportJob{Rows: rows}, auth.GetUser(ctx).Username)
+		if err != nil {
+			logger.Error(reqCtx, eventClassProductBiller, "Import", err.Error())
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return accepted(ctx, job)
+	}
+
+	report, err := c.usecases.Import(reqCtx, rows)
+	if err != nil {
+		logger.Error(reqCtx, eventClassProductBiller, "Import", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(ctx, http.StatusOK, report)
+}
+
+// Activate handles POST requests to activate or deactivate all ProductBillers of a product or of a biller.
+// The change is queued as a job, which is polled for its progress and result.
+func (c *ProductBillerController) Activate(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	request, err := bindRequest[models.ProductBillerActivationRequest](ctx)
+	if err != nil {
+		return err
+	}
+
+	job, err := c.jobs.Enqueue(reqCtx, models.JobTypeProductBillerActivation, request, auth.GetUser(ctx).Username)
+	if err != nil {
+		logger.Error(reqCtx, eventClassProductBiller, "Activate", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return accepted(ctx, job)
+}
+
+// productBillerExportColumns are the columns of a ProductBiller export. Exports can be imported back,
+// as the import reads the columns it needs by name.
+var productBillerExportColumns = []string{
+	"id", "product_id", "biller_id", "is_active", "priority", "weight", "deactivated_at", "deactivated_by",
+	"deactivation_reason", "created_at", "created_by", "updated_at", "updated_by", "version",
+}
+
+func productBillerExportRecord(pb *models.ProductBiller) []interface{} {
+	return []interface{}{
+		pb.ID, pb.ProductID, pb.BillerID, pb.IsActive, pb.Priority, pb.Weight, pb.DeactivatedAt, pb.DeactivatedBy,
+		pb.DeactivationReason, pb.CreatedAt, pb.CreatedBy, pb.UpdatedAt, pb.UpdatedBy, pb.Version,
+	}
+}
+
+// Export handles GET requests to download the ProductBillers matching the filters as a csv, json or
+// xlsx file. Rows are streamed to the client as they are read.
+func (c *ProductBillerController) Export(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	var filter models.ProductBillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductBillerSortColumns); err != nil {
+		return err
+	}
+
+	return streamExport(ctx, reqCtx, logger, eventClassProductBiller, "Export", format, "product-billers", productBillerExportColumns,
+		func(write func(record []interface{}) error) error {
+			return c.usecases.Export(reqCtx, filter, func(pb *models.ProductBiller) error {
+				return write(productBillerExportRecord(pb))
+			})
+		})
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+func TestProductBillerController_Create(t *testing.T) {
+	nop := zerolog.Nop()
+
+	newController := func() (*ProductBillerController, *mocks.MockProductBillerRepository) {
+		mockUow := new(mocks.MockUnitOfWork)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		mockProductRepo.On("FetchOne", mock.Anything, 1).Return(&models.Product{ID: 1}, nil)
+		mockBillerRepo.On("FetchOne", mock.Anything, 3).Return(&models.Biller{ID: 3}, nil)
+		controller := NewProductBillerController(
+			usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, mockUow),
+			nil,
+			db.NewCursorSigner("cursor-secret"),
+			&nop,
+		)
+		return controller, mockProductBillerRepo
+	}
+
+	create := func(controller *ProductBillerController) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		e.Validator = validation.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/product-billers", strings.NewReader(`{"product_id":1,"biller_id":3,"is_active":true}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		return rec, controller.Create(e.NewContext(req, rec))
+	}
+
+	t.Run("creates the product biller with the default priority and weight", func(t *testing.T) {
+		controller, mockProductBillerRepo := newController()
+		mockProductBillerRepo.On("Create", mock.Anything, mock.MatchedBy(func(pb *models.ProductBiller) bool {
+			return pb.Priority == models.DefaultProductBillerPriority && pb.Weight == models.DefaultProductBillerWeight
+		})).Return(&models.ProductBiller{ID: 7, ProductID: 1, BillerID: 3, IsActive: true, Weight: 1, Version: 1}, nil)
+
+		rec, err := create(controller)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("answers 409 when the product is already mapped to the biller", func(t *testing.T) {
+		controller, mockProductBillerRepo := newController()
+		mockProductBillerRepo.On("Create", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: product_biller_unique", repositories.ErrDuplicateEntry))
+
+		_, err := create(controller)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusConflict, httpErr.Code)
+	})
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+// maxImportRows bounds the rows of a bulk import, which are all read into memory before being imported.
+const maxImportRows = 10000
+
+// parseProductBillerImport reads the rows of a bulk import from the request body, either a JSON array of
+// create requests or a CSV file with a header line, sent as the body or as the "file" field of a multipart
+// form. Rows that cannot be parsed or fail validation are returned with their Error set, so they are
+// reported rather than failing the whole import.
+func parseProductBillerImport(ctx echo.Context) ([]*models.ProductBillerImportRow, error) {
+	mediaType, _, err := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be application/json, text/csv or multipart/form-data")
+	}
+
+	var rows []*models.ProductBillerImportRow
+	switch mediaType {
+	case echo.MIMEApplicationJSON:
+		rows, err = parseJSONImport(ctx.Request().Body)
+	case "text/csv":
+		rows, err = parseCSVImport(ctx.Request().Body)
+	case echo.MIMEMultipartForm:
+		rows, err = parseImportFile(ctx)
+	default:
+		return nil, echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be application/json, text/csv or multipart/form-data")
+	}
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return nil, httpErr
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid input: %s", err.Error()))
+	}
+
+	if len(rows) == 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid input: no rows to import")
+	}
+	if len(rows) > maxImportRows {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Too many rows: at most %d can be imported at once", maxImportRows))
+	}
+
+	// The import checks that products and billers exist itself, in batches.
+	reqCtx := validation.SkipLookups(ctx.Request().Context())
+	for _, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		if err := validateStruct(ctx, reqCtx, &row.Request); err != nil {
+			row.Error = err.Error()
+		}
+	}
+
+	return rows, nil
+}
+
+// parseImportFile reads the rows of the uploaded file, as JSON when its name ends in .json and as CSV otherwise.
+func parseImportFile(ctx echo.Context) ([]*models.ProductBillerImportRow, error) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid input: the import must be uploaded as the file field")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(header.Filename), ".json") {
+		return parseJSONImport(file)
+	}
+	return parseCSVImport(file)
+}
+
+func parseJSONImport(r io.Reader) ([]*models.ProductBillerImportRow, error) {
+	var elements []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&elements); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of product billers: %w", err)
+	}
+
+	rows := make([]*models.ProductBillerImportRow, len(elements))
+	for i, element := range elements {
+		rows[i] = &models.ProductBillerImportRow{Row: i + 1}
+		if err := json.Unmarshal(element, &rows[i].Request); err != nil {
+			rows[i].Error = err.Error()
+		}
+	}
+
+	return rows, nil
+}
+
+// parseCSVImport reads a CSV file whose header names the product_id, biller_id, is_active, priority and
+// weight columns, in any order. Other columns, such as those of an export, are ignored.
+func parseCSVImport(r io.Reader) ([]*models.ProductBillerImportRow, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("expected a CSV header line: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Byte order mark written by spreadsheet applications.
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"product_id", "biller_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the %s column", required)
+		}
+	}
+
+	var rows []*models.ProductBillerImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := &models.ProductBillerImportRow{Row: len(rows) + 1}
+		rows = append(rows, row)
+		if err != nil {
+			// A row with the wrong number of fields is still read; other errors leave the reader lost.
+			if !errors.Is(err, csv.ErrFieldCount) {
+				return nil, err
+			}
+			row.Error = err.Error()
+			continue
+		}
+		if len(rows) > maxImportRows {
+			break
+		}
+
+		row.Error = parseCSVRecord(record, columns, &row.Request)
+	}
+
+	return rows, nil
+}
+
+// parseCSVRecord fills request from the fields of a record, returning the problems found, if any.
+func parseCSVRecord(record []string, columns map[string]int, request *models.CreateProductBillerRequest) string {
+	var problems []string
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	parseInt := func(name string, dst *int) {
+		if value := field(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not an integer", name, value))
+			}
+			*dst = n
+		}
+	}
+	parseOptionalInt := func(name string, dst **int) {
+		if field(name) != "" {
+			*dst = new(int)
+			parseInt(name, *dst)
+		}
+	}
+
+	parseInt("product_id", &request.ProductID)
+	parseInt("biller_id", &request.BillerID)
+	parseOptionalInt("priority", &request.Priority)
+	parseOptionalInt("weight", &request.Weight)
+	if value := field("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("is_active: %q is not a boolean", value))
+		}
+		request.IsActive = &isActive
+	}
+
+	return strings.Join(problems, "; ")
+}
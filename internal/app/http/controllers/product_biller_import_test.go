@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportContext(contentType string, body *bytes.Buffer) echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/product-billers/bulk", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	return newTestEcho().NewContext(req, httptest.NewRecorder())
+}
+
+func TestParseProductBillerImport(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		body := bytes.NewBufferString("\ufeffid,Biller_ID,product_id,is_active,weight\n" +
+			"9,2,1,true,5\n" +
+			",3,1,false,\n" +
+			",4,x,true,1\n" +
+			",5,1\n" +
+			",6,1,,1\n")
+
+		rows, err := parseProductBillerImport(newImportContext("text/csv", body))
+		require.NoError(t, err)
+		require.Len(t, rows, 5)
+
+		assert.Empty(t, rows[0].Error)
+		assert.Equal(t, 1, rows[0].Request.ProductID)
+		assert.Equal(t, 2, rows[0].Request.BillerID)
+		assert.True(t, *rows[0].Request.IsActive)
+		assert.Equal(t, 5, *rows[0].Request.Weight)
+		assert.Nil(t, rows[0].Request.Priority)
+
+		assert.Empty(t, rows[1].Error)
+		assert.False(t, *rows[1].Request.IsActive)
+		assert.Nil(t, rows[1].Request.Weight, "an empty weight takes the default")
+
+		assert.Contains(t, rows[2].Error, `product_id: "x" is not an integer`)
+		assert.Contains(t, rows[3].Error, "wrong number of fields")
+		assert.Contains(t, rows[4].Error, "is_active is required")
+		assert.Equal(t, 5, rows[4].Row)
+	})
+
+	t.Run("csv missing a required column", func(t *testing.T) {
+		_, err := parseProductBillerImport(newImportContext("text/csv", bytes.NewBufferString("product_id,weight\n1,1\n")))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		body := bytes.NewBufferString(`[{"product_id":1,"biller_id":2,"is_active":false},{"product_id":"1"},{"biller_id":2,"is_active":true}]`)
+
+		rows, err := parseProductBillerImport(newImportContext(echo.MIMEApplicationJSON, body))
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		assert.Empty(t, rows[0].Error)
+		assert.False(t, *rows[0].Request.IsActive)
+		assert.Contains(t, rows[1].Error, "cannot unmarshal")
+		assert.Contains(t, rows[2].Error, "product_id is required")
+	})
+
+	t.Run("multipart upload", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("file", "billers.csv")
+		require.NoError(t, err)
+		_, err = file.Write([]byte("product_id,biller_id,is_active\n1,2,1\n"))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		rows, err := parseProductBillerImport(newImportContext(form.FormDataContentType(), &body))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Empty(t, rows[0].Error)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := parseProductBillerImport(newImportContext(echo.MIMEApplicationJSON, bytes.NewBufferString(`[]`)))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		body := bytes.NewBufferString("product_id,biller_id,is_active\n" + strings.Repeat("1,2,true\n", maxImportRows+1))
+		_, err := parseProductBillerImport(newImportContext("text/csv", body))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	})
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ProductBillerStatController defines the HTTP layer for ProductBiller transaction statistics.
+type ProductBillerStatController struct {
+	usecases usecases.ProductBillerStatUseCase
+	logger   *zerolog.Logger
+}
+
+// NewProductBillerStatController creates a new instance of ProductBillerStatController.
+func NewProductBillerStatController(usecases usecases.ProductBillerStatUseCase, logger *zerolog.Logger) *ProductBillerStatController {
+	return &ProductBillerStatController{
+		usecases: usecases,
+		logger:   logger,
+	}
+}
+
+const eventClassProductBillerStat = "controller.productBillerStat"
+
+// FetchMany handles GET requests to retrieve time-bucketed transaction statistics of a ProductBiller.
+// The range defaults to the last 24 hours and the bucket to "hour".
+func (c *ProductBillerStatController) FetchMany(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	to := time.Now()
+	if raw := ctx.QueryParam("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid to: must be an RFC 3339 timestamp")
+		}
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if raw := ctx.QueryParam("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid from: must be an RFC 3339 timestamp")
+		}
+	}
+
+	bucket := ctx.QueryParam("bucket")
+	if bucket == "" {
+		bucket = models.StatBucketHour
+	}
+	if !models.IsValidStatBucket(bucket) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid bucket: must be one of minute, hour")
+	}
+	if !from.Before(to) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid range: from must be before to")
+	}
+
+	stats, err := c.usecases.FetchMany(reqCtx, id, bucket, from, to)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		logger.Error(reqCtx, eventClassProductBillerStat, "FetchMany", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(ctx, http.StatusOK, models.NewProductBillerStatsResponse(id, bucket, from, to, stats))
+}
@@ -1,14 +1,17 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 
 	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/logger"
 	"golang-boilerplate/internal/pkg/models"
 	"golang-boilerplate/internal/pkg/utils"
@@ -16,15 +19,19 @@ import (
 
 // ProductController defines the HTTP layer for Product entities.
 type ProductController struct {
-	usecases usecases.ProductUseCase
-	logger   *zerolog.Logger
+	usecases       usecases.ProductUseCase
+	productBillers usecases.ProductBillerUseCase
+	cursors        *db.CursorSigner
+	logger         *zerolog.Logger
 }
 
 // NewProductController creates a new instance of ProductController.
-func NewProductController(usecases usecases.ProductUseCase, logger *zerolog.Logger) *ProductController {
+func NewProductController(usecases usecases.ProductUseCase, productBillers usecases.ProductBillerUseCase, cursors *db.CursorSigner, logger *zerolog.Logger) *ProductController {
 	return &ProductController{
-		usecases: usecases,
-		logger:   logger,
+		usecases:       usecases,
+		productBillers: productBillers,
+		cursors:        cursors,
+		logger:         logger,
 	}
 }
 
@@ -34,22 +41,22 @@ const eventClassProduct = "controller.product"
 func (c *ProductController) Create(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
-	var product *models.CreateProductRequest
-	if err := ctx.Bind(product); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
-	}
-
-	validate := validator.New()
-	if err := validate.Struct(product); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	product, err := bindRequest[models.CreateProductRequest](ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := c.usecases.Create(reqCtx, product.ToEntity()); err != nil {
+	createdProduct, err := c.usecases.Create(reqCtx, product.ToEntity())
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProduct, "Create", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusCreated, map[string]string{"message": "Product created successfully"})
+	setETag(ctx, createdProduct.Version)
+	return created(ctx, createdProduct.ID, createdProduct.ToResponse())
 }
 
 // Update handles PUT requests to update an existing Product.
@@ -61,22 +68,69 @@ func (c *ProductController) Update(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	var product *models.UpdateProductRequest
-	if err := ctx.Bind(product); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(product); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	product, err := bindRequest[models.UpdateProductRequest](ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := c.usecases.Update(reqCtx, id, product.ToEntity()); err != nil {
+	if err := c.usecases.Update(reqCtx, id, version, product.ToEntity()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProduct, "Update", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"message": "Product updated successfully"})
+	setETag(ctx, version+1)
+	return respondMessage(ctx, http.StatusOK, "Product updated successfully")
+}
+
+// Patch handles PATCH requests to update some fields of an existing Product.
+func (c *ProductController) Patch(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	var request models.PatchProductRequest
+	if err := bindMergePatch(ctx, &request); err != nil {
+		return err
+	}
+
+	if err := c.usecases.Patch(reqCtx, id, version, request.ToPatch()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassProduct, "Patch", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	setETag(ctx, version+1)
+	return respondMessage(ctx, http.StatusOK, "Product updated successfully")
 }
 
 // Delete handles DELETE requests to remove a Product.
@@ -88,15 +142,60 @@ func (c *ProductController) Delete(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	if err := c.usecases.Delete(reqCtx, id); err != nil {
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.usecases.Delete(reqCtx, id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrVersionConflict) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		}
+		if errors.Is(err, usecases.ErrDeleteRestricted) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProduct, "Delete", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"message": "Product deleted successfully"})
+	return respondMessage(ctx, http.StatusOK, "Product deleted successfully")
+}
+
+// Restore handles POST requests to restore a deleted Product. With cascade=true, the ProductBillers
+// deleted along with it are restored too.
+func (c *ProductController) Restore(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	cascade, err := boolQueryParam(ctx, "cascade")
+	if err != nil {
+		return err
+	}
+
+	product, err := c.usecases.Restore(reqCtx, id, cascade)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, usecases.ErrNotDeleted) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		logger.Error(reqCtx, eventClassProduct, "Restore", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	setETag(ctx, product.Version)
+	return respond(ctx, http.StatusOK, product.ToResponse())
 }
 
-// FetchOne handles GET requests to retrieve a single Product.
+// FetchOne handles GET requests to retrieve a single Product, including a deleted one with include_deleted=true.
 func (c *ProductController) FetchOne(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
@@ -105,22 +204,36 @@ func (c *ProductController) FetchOne(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
 	}
 
-	product, err := c.usecases.FetchOne(reqCtx, id)
+	includeDeleted, err := boolQueryParam(ctx, "include_deleted")
+	if err != nil {
+		return err
+	}
+
+	var product *models.Product
+	if includeDeleted {
+		product, err = first(c.usecases.FetchMany(reqCtx, models.ProductFilter{ID: &id, IncludeDeleted: true}))
+	} else {
+		product, err = c.usecases.FetchOne(reqCtx, id)
+	}
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		logger.Error(reqCtx, eventClassProduct, "FetchOne", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, product.ToResponse())
+	setETag(ctx, product.Version)
+	return respond(ctx, http.StatusOK, product.ToResponse())
 }
 
 // FetchMany handles GET requests to retrieve multiple Products based on filters.
 func (c *ProductController) FetchMany(ctx echo.Context) error {
 	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
 
-	filter := make(map[string]interface{})
-	if label := ctx.QueryParam("label"); label != "" {
-		filter["label"] = label
+	var filter models.ProductFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductSortColumns); err != nil {
+		return err
 	}
 
 	products, err := c.usecases.FetchMany(reqCtx, filter)
@@ -132,7 +245,42 @@ func (c *ProductController) FetchMany(ctx echo.Context) error {
 	response := utils.TransformSlice(products, func(product *models.Product) *models.ProductResponse {
 		return product.ToResponse()
 	})
-	return ctx.JSON(http.StatusOK, response)
+	return respondList(ctx, response, nil)
+}
+
+// productExportColumns are the columns of a Product export.
+var productExportColumns = []string{
+	"id", "label", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version",
+}
+
+func productExportRecord(product *models.Product) []interface{} {
+	return []interface{}{
+		product.ID, product.Label, product.CreatedAt, product.CreatedBy, product.UpdatedAt, product.UpdatedBy, product.DeletedAt, product.DeletedBy,
+		product.Version,
+	}
+}
+
+// Export handles GET requests to download the Products matching the filters as a csv, json or xlsx file.
+// Rows are streamed to the client as they are read.
+func (c *ProductController) Export(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	var filter models.ProductFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductSortColumns); err != nil {
+		return err
+	}
+
+	return streamExport(ctx, reqCtx, logger, eventClassProduct, "Export", format, "products", productExportColumns,
+		func(write func(record []interface{}) error) error {
+			return c.usecases.Export(reqCtx, filter, func(product *models.Product) error {
+				return write(productExportRecord(product))
+			})
+		})
 }
 
 // FetchManyWithPagination handles GET requests to retrieve paginated Products.
@@ -149,13 +297,28 @@ func (c *ProductController) FetchManyWithPagination(ctx echo.Context) error {
 		limit = 10
 	}
 
-	filter := make(map[string]interface{})
-	if label := ctx.QueryParam("label"); label != "" {
-		filter["label"] = label
+	var filter models.ProductFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductSortColumns); err != nil {
+		return err
 	}
 
-	products, pagination, err := c.usecases.FetchManyWithPagination(reqCtx, filter, page, limit)
+	cursorPage, err := cursorPagination(ctx, c.cursors, limit)
 	if err != nil {
+		return err
+	}
+
+	var products []*models.Product
+	var pagination interface{}
+	if cursorPage != nil {
+		products, err = c.usecases.FetchManyWithCursor(reqCtx, filter, cursorPage)
+		pagination = cursorPage
+	} else {
+		products, pagination, err = c.usecases.FetchManyWithPagination(reqCtx, filter, page, limit)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
 		logger.Error(reqCtx, eventClassProduct, "FetchManyWithPagination", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -163,8 +326,38 @@ func (c *ProductController) FetchManyWithPagination(ctx echo.Context) error {
 	response := utils.TransformSlice(products, func(product *models.Product) *models.ProductResponse {
 		return product.ToResponse()
 	})
-	return ctx.JSON(http.StatusOK, map[string]interface{}{
-		"data":       response,
-		"pagination": pagination,
-	})
+	return respondList(ctx, response, pagination)
+}
+
+// FetchBillers handles GET requests to list the billers of a Product, as its product billers embedding their
+// biller. The filters and pagination of the product biller list apply, and expand=product embeds the product too.
+func (c *ProductController) FetchBillers(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	var filter models.ProductBillerFilter
+	if err := bindFilter(ctx, &filter, &filter.ListFilter, repositories.ProductBillerSortColumns); err != nil {
+		return err
+	}
+	filter.ProductID = &id
+
+	expand, err := parseExpand(ctx)
+	if err != nil {
+		return err
+	}
+	expand.Biller = true
+
+	if _, err := c.usecases.FetchOne(reqCtx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		logger.Error(reqCtx, eventClassProduct, "FetchBillers", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return productBillerPage(ctx, c.productBillers, c.cursors, c.logger, filter, expand, eventClassProduct, "FetchBillers")
 }
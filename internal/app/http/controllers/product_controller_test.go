@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+// productBillerList is the body of a page based product biller list in version 1.
+type productBillerList struct {
+	Data       []*models.ProductBillerResponse `json:"data"`
+	Pagination db.Pagination                   `json:"pagination"`
+}
+
+// ofParent matches product biller filters listing the product billers of the product or biller ID id.
+func ofParent(parent func(filter models.ProductBillerFilter) *int, id int) interface{} {
+	return mock.MatchedBy(func(filter models.ProductBillerFilter) bool {
+		parentID := parent(filter)
+		return parentID != nil && *parentID == id
+	})
+}
+
+func TestProductController_FetchBillers(t *testing.T) {
+	nop := zerolog.Nop()
+	productID := func(filter models.ProductBillerFilter) *int { return filter.ProductID }
+
+	newController := func() (*ProductController, *mocks.MockProductRepository, *mocks.MockProductBillerRepository) {
+		mockUow := new(mocks.MockUnitOfWork)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		controller := NewProductController(
+			usecases.NewProductUseCase(mockProductRepo, mockUow, usecases.DeletionPolicyCascade),
+			usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, mockUow),
+			db.NewCursorSigner("cursor-secret"),
+			&nop,
+		)
+		return controller, mockProductRepo, mockProductBillerRepo
+	}
+
+	fetch := func(controller *ProductController, query string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		e.Validator = validation.New()
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/products/1/billers"+query, nil), rec)
+		ctx.SetParamNames("id")
+		ctx.SetParamValues("1")
+		return rec, controller.FetchBillers(ctx)
+	}
+
+	t.Run("answers 404 when the product is missing", func(t *testing.T) {
+		controller, mockProductRepo, mockProductBillerRepo := newController()
+		mockProductRepo.On("FetchOne", mock.Anything, 1).Return(nil, fmt.Errorf("failed to fetch product: %w", sql.ErrNoRows))
+
+		_, err := fetch(controller, "")
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		mockProductBillerRepo.AssertNotCalled(t, "FetchManyDetailedWithPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("lists the product billers of the product with their billers", func(t *testing.T) {
+		controller, mockProductRepo, mockProductBillerRepo := newController()
+		product := &models.Product{ID: 1, Label: "Pulsa"}
+		mockProductRepo.On("FetchOne", mock.Anything, 1).Return(product, nil)
+		mockProductBillerRepo.On("FetchManyDetailedWithPagination", mock.Anything, ofParent(productID, 1), 2, 1).Return([]*models.ProductBillerDetail{{
+			ProductBiller: models.ProductBiller{ID: 7, ProductID: 1, BillerID: 3, IsActive: true},
+			Product:       product,
+			Biller:        &models.Biller{ID: 3, Label: "Telkomsel"},
+		}}, &db.Pagination{Page: 2, Limit: 1, TotalRows: 2, TotalPages: 2}, nil)
+
+		rec, err := fetch(controller, "?page=2&limit=1")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var page productBillerList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		require.Len(t, page.Data, 1)
+		assert.Equal(t, 7, page.Data[0].ID)
+		require.NotNil(t, page.Data[0].Biller)
+		assert.Equal(t, "Telkomsel", page.Data[0].Biller.Label)
+		assert.Nil(t, page.Data[0].Product, "the product is only embedded with expand=product")
+		assert.Equal(t, db.Pagination{Page: 2, Limit: 1, TotalRows: 2, TotalPages: 2}, page.Pagination)
+	})
+
+	t.Run("embeds the product with expand=product", func(t *testing.T) {
+		controller, mockProductRepo, mockProductBillerRepo := newController()
+		product := &models.Product{ID: 1, Label: "Pulsa"}
+		mockProductRepo.On("FetchOne", mock.Anything, 1).Return(product, nil)
+		mockProductBillerRepo.On("FetchManyDetailedWithPagination", mock.Anything, ofParent(productID, 1), 1, 10).Return([]*models.ProductBillerDetail{{
+			ProductBiller: models.ProductBiller{ID: 7, ProductID: 1, BillerID: 3},
+			Product:       product,
+			Biller:        &models.Biller{ID: 3, Label: "Telkomsel"},
+		}}, &db.Pagination{Page: 1, Limit: 10, TotalRows: 1, TotalPages: 1}, nil)
+
+		rec, err := fetch(controller, "?expand=product")
+		require.NoError(t, err)
+
+		var page productBillerList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		require.Len(t, page.Data, 1)
+		require.NotNil(t, page.Data[0].Product)
+		assert.Equal(t, "Pulsa", page.Data[0].Product.Label)
+		assert.NotNil(t, page.Data[0].Biller)
+	})
+}
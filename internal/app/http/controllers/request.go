@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+// validationFailed is the body of a 400 response to a request with invalid fields.
+type validationFailed struct {
+	Message string            `json:"message"`
+	Errors  validation.Errors `json:"errors"`
+}
+
+// bindRequest binds the body of a request into a new T and validates it.
+func bindRequest[T any](ctx echo.Context) (*T, error) {
+	request := new(T)
+	if err := ctx.Bind(request); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+	}
+
+	if err := validate(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// validate validates i with the validator of the server, answering 400 with every invalid field of i.
+func validate(ctx echo.Context, i interface{}) error {
+	err := validateStruct(ctx, ctx.Request().Context(), i)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validation.Errors
+	if errors.As(err, &fieldErrs) {
+		return echo.NewHTTPError(http.StatusBadRequest, validationFailed{Message: "Validation failed", Errors: fieldErrs})
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+// validateStruct validates i with the validator of the server under reqCtx, which carries the lookups of exists rules.
+func validateStruct(ctx echo.Context, reqCtx context.Context, i interface{}) error {
+	if v, ok := ctx.Echo().Validator.(*validation.Validator); ok {
+		return v.ValidateCtx(reqCtx, i)
+	}
+	return ctx.Validate(i)
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+// newTestEcho creates an Echo with the validator of the server, without lookups unless given.
+func newTestEcho(opts ...validation.Option) *echo.Echo {
+	e := echo.New()
+	e.Validator = validation.New(opts...)
+	return e
+}
+
+func TestBindRequest(t *testing.T) {
+	newContext := func(e *echo.Echo, body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/product-billers", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+	productExists := validation.WithLookup("product", func(ctx context.Context, id int) (bool, error) {
+		return id == 1, nil
+	})
+
+	t.Run("binds a valid request", func(t *testing.T) {
+		request, err := bindRequest[models.CreateProductBillerRequest](newContext(newTestEcho(productExists), `{"product_id": 1, "biller_id": 2, "is_active": true}`))
+		require.NoError(t, err)
+		assert.Equal(t, 1, request.ProductID)
+		assert.Equal(t, 2, request.BillerID)
+		assert.True(t, *request.IsActive)
+
+		// Routing fields left out take their defaults rather than 0.
+		entity := request.ToEntity()
+		assert.Equal(t, models.DefaultProductBillerPriority, entity.Priority)
+		assert.Equal(t, models.DefaultProductBillerWeight, entity.Weight)
+	})
+
+	t.Run("lists every invalid field", func(t *testing.T) {
+		_, err := bindRequest[models.CreateProductBillerRequest](newContext(newTestEcho(productExists), `{"product_id": 3, "biller_id": -2, "weight": -1}`))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.IsType(t, validationFailed{}, httpErr.Message)
+		assert.Equal(t, validation.Errors{
+			{Field: "product_id", Code: validation.CodeNotFound, Message: "must be the ID of an existing product"},
+			{Field: "biller_id", Code: validation.CodeInvalidID, Message: "must be a positive integer"},
+			{Field: "is_active", Code: validation.CodeRequired, Message: "is required"},
+			{Field: "weight", Code: validation.CodeOutOfRange, Message: "must be at least 1"},
+		}, httpErr.Message.(validationFailed).Errors)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		_, err := bindRequest[models.CreateProductRequest](newContext(newTestEcho(), `{"label":`))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	})
+
+	t.Run("failed lookup", func(t *testing.T) {
+		e := newTestEcho(validation.WithLookup("product", func(ctx context.Context, id int) (bool, error) {
+			return false, errors.New("connection refused")
+		}))
+		_, err := bindRequest[models.CreateProductBillerRequest](newContext(e, `{"product_id": 1, "biller_id": 2, "is_active": true}`))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+}
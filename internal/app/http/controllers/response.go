@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// Envelope is the body of every response from API version 2 on: the data of a success, null on errors,
+// metadata about the response and the errors, empty on success.
+type Envelope struct {
+	Data   interface{}     `json:"data"`
+	Meta   Meta            `json:"meta"`
+	Errors []ResponseError `json:"errors"`
+}
+
+// Meta holds the metadata of an Envelope.
+type Meta struct {
+	RequestID string `json:"request_id,omitempty"`
+	// Pagination is the page based or cursor based pagination of a list.
+	Pagination interface{} `json:"pagination,omitempty"`
+	// Message confirms a change that has no data to answer with.
+	Message string `json:"message,omitempty"`
+}
+
+// ResponseError is an error listed in an Envelope, about a field of the request when Field is set.
+type ResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+// envelope wraps data and its metadata into the Envelope of the request.
+func envelope(ctx echo.Context, data interface{}, meta Meta, errs []ResponseError) *Envelope {
+	meta.RequestID = ctx.Response().Header().Get(echo.HeaderXRequestID)
+	if errs == nil {
+		errs = []ResponseError{}
+	}
+	return &Envelope{Data: data, Meta: meta, Errors: errs}
+}
+
+// respond responds with data, as is in version 1 and in an Envelope from version 2 on.
+func respond(ctx echo.Context, status int, data interface{}) error {
+	if apiVersion(ctx).Number < 2 {
+		return ctx.JSON(status, data)
+	}
+	return ctx.JSON(status, envelope(ctx, data, Meta{}, nil))
+}
+
+// respondList responds 200 with a list and its pagination, nil for lists that are not paginated. Version 1
+// answers with the bare list or with {data, pagination}; from version 2 on, the pagination is in the meta
+// of the Envelope.
+func respondList(ctx echo.Context, data interface{}, pagination interface{}) error {
+	if apiVersion(ctx).Number >= 2 {
+		return ctx.JSON(http.StatusOK, envelope(ctx, data, Meta{Pagination: pagination}, nil))
+	}
+	if pagination == nil {
+		return ctx.JSON(http.StatusOK, data)
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"data":       data,
+		"pagination": pagination,
+	})
+}
+
+// respondMessage responds with a message confirming a change, as {message} in version 1 and in the meta of
+// an Envelope without data from version 2 on.
+func respondMessage(ctx echo.Context, status int, message string) error {
+	if apiVersion(ctx).Number < 2 {
+		return ctx.JSON(status, map[string]string{"message": message})
+	}
+	return ctx.JSON(status, envelope(ctx, nil, Meta{Message: message}, nil))
+}
+
+// ErrorHandler answers the errors returned by handlers: from version 2 on with an Envelope listing them,
+// every invalid field of a request included, and as Echo does in version 1.
+func ErrorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed || apiVersion(ctx).Number < 2 {
+		ctx.Echo().DefaultHTTPErrorHandler(err, ctx)
+		return
+	}
+
+	// Like Echo, errors other than HTTP errors answer 500 without revealing their message.
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(httpErr.Code)), " ", "_")
+	var errs []ResponseError
+	switch message := httpErr.Message.(type) {
+	case validationFailed:
+		for _, fieldErr := range message.Errors {
+			errs = append(errs, ResponseError{Code: fieldErr.Code, Message: fieldErr.Message, Field: fieldErr.Field})
+		}
+	case string:
+		errs = append(errs, ResponseError{Code: code, Message: message})
+	default:
+		errs = append(errs, ResponseError{Code: code, Message: http.StatusText(httpErr.Code)})
+	}
+
+	if ctx.Request().Method == http.MethodHead {
+		err = ctx.NoContent(httpErr.Code)
+	} else {
+		err = ctx.JSON(httpErr.Code, envelope(ctx, nil, Meta{}, errs))
+	}
+	if err != nil {
+		ctx.Logger().Error(err)
+	}
+}
+
+// created responds 201 with the created entity, setting the Location header to the entity URL,
+// i.e. the ID appended to the collection path the entity was posted to.
+func created(ctx echo.Context, id int, body interface{}) error {
+	ctx.Response().Header().Set(echo.HeaderLocation, path.Join(ctx.Request().URL.Path, strconv.Itoa(id)))
+	return respond(ctx, http.StatusCreated, body)
+}
+
+// setETag sets the ETag header to the entity version, which clients echo in If-Match to modify the entity.
+func setETag(ctx echo.Context, version int) {
+	ctx.Response().Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// ifMatchVersion reads the entity version a PUT, PATCH or DELETE is based on from the required If-Match header.
+// A missing header answers 428; a tag that is not a strong version tag can never match and answers 412.
+func ifMatchVersion(ctx echo.Context) (int, error) {
+	tag := strings.TrimSpace(ctx.Request().Header.Get("If-Match"))
+	if tag == "" {
+		return 0, echo.NewHTTPError(http.StatusPreconditionRequired, "If-Match header with the entity ETag is required")
+	}
+
+	if len(tag) >= 2 && strings.HasPrefix(tag, `"`) && strings.HasSuffix(tag, `"`) {
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			return version, nil
+		}
+	}
+
+	return 0, echo.NewHTTPError(http.StatusPreconditionFailed, "If-Match does not match the entity ETag")
+}
+
+// accepted responds 202 with the queued job, setting the Location header to the URL to poll it at, in the
+// version of the API the job was queued from.
+func accepted(ctx echo.Context, job *models.Job) error {
+	ctx.Response().Header().Set(echo.HeaderLocation, path.Join(apiVersion(ctx).Prefix, "jobs", strconv.Itoa(job.ID)))
+	return respond(ctx, http.StatusAccepted, job.ToResponse())
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+func TestCreated(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", nil)
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+
+	require.NoError(t, created(ctx, 42, &models.ProductResponse{ID: 42, Label: "Pulsa"}))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/api/v1/products/42", rec.Header().Get(echo.HeaderLocation))
+	assert.Contains(t, rec.Body.String(), `"id":42`)
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	for name, tc := range map[string]struct {
+		header  string
+		version int
+		status  int
+	}{
+		"strong tag":    {header: `"3"`, version: 3},
+		"missing":       {status: http.StatusPreconditionRequired},
+		"weak tag":      {header: `W/"3"`, status: http.StatusPreconditionFailed},
+		"not a version": {header: `"abc"`, status: http.StatusPreconditionFailed},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/products/1", nil)
+			if tc.header != "" {
+				req.Header.Set("If-Match", tc.header)
+			}
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+			version, err := ifMatchVersion(ctx)
+			if tc.status != 0 {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.status, httpErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.version, version)
+		})
+	}
+}
+
+// newVersionContext returns a context for a request served by version.
+func newVersionContext(version APIVersion, method, path string) (echo.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	ctx := newTestEcho().NewContext(httptest.NewRequest(method, path, nil), rec)
+	ctx.Set(apiVersionKey, version)
+	return ctx, rec
+}
+
+func TestRespond(t *testing.T) {
+	page := &db.Pagination{Page: 1, Limit: 10, TotalRows: 1, TotalPages: 1}
+	products := []*models.ProductResponse{{ID: 1, Label: "Pulsa"}}
+
+	for name, tc := range map[string]struct {
+		version APIVersion
+		write   func(ctx echo.Context) error
+		body    string
+	}{
+		"v1 entity": {V1, func(ctx echo.Context) error { return respond(ctx, http.StatusOK, products[0]) }, `"id":1,"label":"Pulsa"`},
+		"v1 list":   {V1, func(ctx echo.Context) error { return respondList(ctx, products, nil) }, `[{"id":1`},
+		"v1 page":   {V1, func(ctx echo.Context) error { return respondList(ctx, products, page) }, `{"data":[{"id":1`},
+		"v1 message": {V1, func(ctx echo.Context) error {
+			return respondMessage(ctx, http.StatusOK, "Product updated successfully")
+		},
+			`{"message":"Product updated successfully"}`},
+		"v2 entity": {V2, func(ctx echo.Context) error { return respond(ctx, http.StatusOK, products[0]) }, `{"data":{"id":1`},
+		"v2 list":   {V2, func(ctx echo.Context) error { return respondList(ctx, products, nil) }, `"meta":{},"errors":[]}`},
+		"v2 page":   {V2, func(ctx echo.Context) error { return respondList(ctx, products, page) }, `"meta":{"pagination":{"order":"","limit":10,"page":1`},
+		"v2 message": {V2, func(ctx echo.Context) error {
+			return respondMessage(ctx, http.StatusOK, "Product updated successfully")
+		},
+			`{"data":null,"meta":{"message":"Product updated successfully"},"errors":[]}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, rec := newVersionContext(tc.version, http.MethodGet, tc.version.Prefix+"/products")
+			require.NoError(t, tc.write(ctx))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.body)
+		})
+	}
+}
+
+func TestAccepted(t *testing.T) {
+	ctx, rec := newVersionContext(V2, http.MethodPost, "/api/v2/product-billers/activation")
+
+	require.NoError(t, accepted(ctx, &models.Job{ID: 7}))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/api/v2/jobs/7", rec.Header().Get(echo.HeaderLocation))
+	assert.Contains(t, rec.Body.String(), `{"data":{"id":7`)
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Run("v1 answers as Echo does", func(t *testing.T) {
+		ctx, rec := newVersionContext(V1, http.MethodGet, "/api/v1/products/1")
+		ErrorHandler(echo.NewHTTPError(http.StatusNotFound, "product not found"), ctx)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"message":"product not found"}`, rec.Body.String())
+	})
+
+	t.Run("v2 lists the error", func(t *testing.T) {
+		ctx, rec := newVersionContext(V2, http.MethodGet, "/api/v2/products/1")
+		ErrorHandler(echo.NewHTTPError(http.StatusNotFound, "product not found"), ctx)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"data":null,"meta":{},"errors":[{"code":"not_found","message":"product not found"}]}`, rec.Body.String())
+	})
+
+	t.Run("v2 lists every invalid field", func(t *testing.T) {
+		ctx, rec := newVersionContext(V2, http.MethodPost, "/api/v2/products")
+		ErrorHandler(echo.NewHTTPError(http.StatusBadRequest, validationFailed{
+			Message: "Validation failed",
+			Errors:  validation.Errors{{Field: "label", Code: "required", Message: "is required"}},
+		}), ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{"data":null,"meta":{},"errors":[{"code":"required","message":"is required","field":"label"}]}`, rec.Body.String())
+	})
+
+	t.Run("v2 hides internal errors", func(t *testing.T) {
+		ctx, rec := newVersionContext(V2, http.MethodGet, "/api/v2/products")
+		ErrorHandler(errors.New("connection refused"), ctx)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+		assert.Contains(t, rec.Body.String(), `"code":"internal_server_error"`)
+	})
+}
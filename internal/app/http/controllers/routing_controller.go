@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/logger"
+)
+
+// RoutingController defines the HTTP layer for biller routing.
+type RoutingController struct {
+	usecases usecases.RoutingUseCase
+	logger   *zerolog.Logger
+}
+
+// NewRoutingController creates a new instance of RoutingController.
+func NewRoutingController(usecases usecases.RoutingUseCase, logger *zerolog.Logger) *RoutingController {
+	return &RoutingController{
+		usecases: usecases,
+		logger:   logger,
+	}
+}
+
+const eventClassRouting = "controller.routing"
+
+// Route handles GET requests to select the biller that should serve a Product.
+func (c *RoutingController) Route(ctx echo.Context) error {
+	reqCtx, logger := logger.NewAppLoggerEcho(ctx, c.logger)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID: must be a positive integer")
+	}
+
+	route, err := c.usecases.Route(reqCtx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, usecases.ErrNoActiveBiller) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		logger.Error(reqCtx, eventClassRouting, "Route", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(ctx, http.StatusOK, route.ToResponse())
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIVersion is a version of the HTTP API, served under its own path prefix. Every version is served by the
+// same controllers; the version only decides the shape of the responses they write.
+type APIVersion struct {
+	Number int
+	Prefix string
+}
+
+var (
+	// V1 answers with bare bodies: entities, arrays, {data, pagination} pages and {message} confirmations.
+	V1 = APIVersion{Number: 1, Prefix: "/api/v1"}
+	// V2 answers with an Envelope, errors included.
+	V2 = APIVersion{Number: 2, Prefix: "/api/v2"}
+)
+
+const apiVersionKey = "apiVersion"
+
+// Middleware marks the requests of the route group it is used by as served by the version.
+func (v APIVersion) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.Set(apiVersionKey, v)
+			return next(ctx)
+		}
+	}
+}
+
+// apiVersion returns the version serving the request, V1 outside the versioned route groups.
+func apiVersion(ctx echo.Context) APIVersion {
+	if version, ok := ctx.Get(apiVersionKey).(APIVersion); ok {
+		return version
+	}
+	return V1
+}
+
+// Deprecate announces on every response of the route group it is used by that the version was deprecated
+// at deprecatedAt (RFC 9745) and is withdrawn at sunsetAt (RFC 8594), unless zero, linking to the same
+// resource in the successor version.
+func Deprecate(version APIVersion, deprecatedAt, sunsetAt time.Time, successor APIVersion) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			// Set before the handler runs, so error responses carry them too.
+			header := ctx.Response().Header()
+			header.Set("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+			if !sunsetAt.IsZero() {
+				header.Set("Sunset", sunsetAt.UTC().Format(http.TimeFormat))
+			}
+			if path, ok := strings.CutPrefix(ctx.Request().URL.Path, version.Prefix); ok {
+				header.Add("Link", `<`+successor.Prefix+path+`>; rel="successor-version"`)
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecate(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2027, 4, 19, 0, 0, 0, 0, time.UTC)
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	api := e.Group(V1.Prefix, V1.Middleware(), Deprecate(V1, deprecatedAt, sunsetAt, V2))
+	api.GET("/products/:id", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "product not found")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil))
+
+	// Errors are announced too, and still answered the version 1 way.
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "@1792368000", rec.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", rec.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2/products/1>; rel="successor-version"`, rec.Header().Get("Link"))
+	require.JSONEq(t, `{"message":"product not found"}`, rec.Body.String())
+}
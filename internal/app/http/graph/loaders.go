@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"context"
+
+	"golang-boilerplate/internal/pkg/dataloader"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// loaders batch the lookups of the nested fields of a query, so that resolving a field over a list takes
+// one query rather than one per item.
+type loaders struct {
+	products                *dataloader.Loader[int, *models.Product]
+	billers                 *dataloader.Loader[int, *models.Biller]
+	productBillersByProduct *dataloader.Loader[int, []*models.ProductBiller]
+	productBillersByBiller  *dataloader.Loader[int, []*models.ProductBiller]
+}
+
+type loadersCtxKey struct{}
+
+func (s *Schema) newLoaders() *loaders {
+	l := &loaders{}
+	*l = loaders{
+		products: dataloader.New(func(ctx context.Context, ids []int) (map[int]*models.Product, error) {
+			products, err := s.products.FetchMany(ctx, models.ProductFilter{IDs: ids})
+			return byKey(products, err, func(p *models.Product) int { return p.ID })
+		}),
+		billers: dataloader.New(func(ctx context.Context, ids []int) (map[int]*models.Biller, error) {
+			billers, err := s.billers.FetchMany(ctx, models.BillerFilter{IDs: ids})
+			return byKey(billers, err, func(b *models.Biller) int { return b.ID })
+		}),
+		productBillersByProduct: dataloader.New(func(ctx context.Context, ids []int) (map[int][]*models.ProductBiller, error) {
+			productBillers, err := s.productBillers.FetchMany(ctx, models.ProductBillerFilter{ProductIDs: ids})
+			l.primeParents(productBillers)
+			return groupByKey(productBillers, err, func(pb *models.ProductBiller) int { return pb.ProductID })
+		}),
+		productBillersByBiller: dataloader.New(func(ctx context.Context, ids []int) (map[int][]*models.ProductBiller, error) {
+			productBillers, err := s.productBillers.FetchMany(ctx, models.ProductBillerFilter{BillerIDs: ids})
+			l.primeParents(productBillers)
+			return groupByKey(productBillers, err, func(pb *models.ProductBiller) int { return pb.BillerID })
+		}),
+	}
+	return l
+}
+
+// primeParents primes the loaders of the products and billers of product billers, so that those of
+// every item of a list are fetched together whichever item resolves them first.
+func (l *loaders) primeParents(productBillers []*models.ProductBiller) {
+	for _, productBiller := range productBillers {
+		l.products.Prime(productBiller.ProductID)
+		l.billers.Prime(productBiller.BillerID)
+	}
+}
+
+// loadersFrom returns the loaders of the query being executed.
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersCtxKey{}).(*loaders)
+}
+
+func byKey[T any](entities []*T, err error, key func(*T) int) (map[int]*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[int]*T, len(entities))
+	for _, entity := range entities {
+		byKey[key(entity)] = entity
+	}
+	return byKey, nil
+}
+
+func groupByKey[T any](entities []*T, err error, key func(*T) int) (map[int][]*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[int][]*T)
+	for _, entity := range entities {
+		groups[key(entity)] = append(groups[key(entity)], entity)
+	}
+	return groups, nil
+}
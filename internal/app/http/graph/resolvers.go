@@ -0,0 +1,365 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ErrInvalidArgument is returned by resolvers for invalid arguments of a query.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// queryResolver resolves the fields of the Query type.
+type queryResolver struct {
+	s *Schema
+}
+
+// listArgs are the arguments of the lists of the Query type, matching the parameters of db.Pagination.
+type listArgs struct {
+	Page  *int32
+	Limit *int32
+	Sort  *string
+}
+
+// parse returns the page and limit of the list, defaulting to the first page of 10 rows, and parses its
+// sort against sortColumns into list.
+func (args listArgs) parse(list *models.ListFilter, sortColumns map[string]string) (int, int, error) {
+	page, limit := 1, 10
+	if args.Page != nil && *args.Page > 0 {
+		page = int(*args.Page)
+	}
+	if args.Limit != nil && *args.Limit > 0 {
+		limit = int(*args.Limit)
+	}
+
+	sort, err := db.ParseSort(stringValue(args.Sort), sortColumns)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid sort: %s", ErrInvalidArgument, err.Error())
+	}
+	list.Sort = sort
+
+	return page, limit, nil
+}
+
+func (q *queryResolver) Product(ctx context.Context, args struct{ ID graphql.ID }) (*productResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := q.s.products.FetchOne(ctx, id)
+	if err != nil {
+		return nil, notFoundAsNull(err)
+	}
+	return newProductResolvers(ctx, []*models.Product{product})[0], nil
+}
+
+func (q *queryResolver) Products(ctx context.Context, args struct {
+	listArgs
+	Label       *string
+	LabelPrefix *string
+}) (*productPageResolver, error) {
+	filter := models.ProductFilter{Label: stringValue(args.Label), LabelPrefix: stringValue(args.LabelPrefix)}
+	page, limit, err := args.parse(&filter.ListFilter, repositories.ProductSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	products, pagination, err := q.s.products.FetchManyWithPagination(ctx, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &productPageResolver{data: newProductResolvers(ctx, products), pagination: pagination}, nil
+}
+
+func (q *queryResolver) Biller(ctx context.Context, args struct{ ID graphql.ID }) (*billerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	biller, err := q.s.billers.FetchOne(ctx, id)
+	if err != nil {
+		return nil, notFoundAsNull(err)
+	}
+	return newBillerResolvers(ctx, []*models.Biller{biller})[0], nil
+}
+
+func (q *queryResolver) Billers(ctx context.Context, args struct {
+	listArgs
+	Label       *string
+	LabelPrefix *string
+}) (*billerPageResolver, error) {
+	filter := models.BillerFilter{Label: stringValue(args.Label), LabelPrefix: stringValue(args.LabelPrefix)}
+	page, limit, err := args.parse(&filter.ListFilter, repositories.BillerSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	billers, pagination, err := q.s.billers.FetchManyWithPagination(ctx, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &billerPageResolver{data: newBillerResolvers(ctx, billers), pagination: pagination}, nil
+}
+
+func (q *queryResolver) ProductBiller(ctx context.Context, args struct{ ID graphql.ID }) (*productBillerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	productBiller, err := q.s.productBillers.FetchOne(ctx, id)
+	if err != nil {
+		return nil, notFoundAsNull(err)
+	}
+	return newProductBillerResolvers(ctx, []*models.ProductBiller{productBiller})[0], nil
+}
+
+func (q *queryResolver) ProductBillers(ctx context.Context, args struct {
+	listArgs
+	ProductID *graphql.ID
+	BillerID  *graphql.ID
+	IsActive  *bool
+}) (*productBillerPageResolver, error) {
+	filter := models.ProductBillerFilter{IsActive: args.IsActive}
+	if args.ProductID != nil {
+		id, err := parseID(*args.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		filter.ProductID = &id
+	}
+	if args.BillerID != nil {
+		id, err := parseID(*args.BillerID)
+		if err != nil {
+			return nil, err
+		}
+		filter.BillerID = &id
+	}
+
+	page, limit, err := args.parse(&filter.ListFilter, repositories.ProductBillerSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	productBillers, pagination, err := q.s.productBillers.FetchManyWithPagination(ctx, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &productBillerPageResolver{data: newProductBillerResolvers(ctx, productBillers), pagination: pagination}, nil
+}
+
+// productResolver resolves the fields of the Product type.
+type productResolver struct {
+	p *models.Product
+}
+
+// newProductResolvers resolves a list of products, priming the loader of their product billers so that
+// resolving them for every product takes a single query.
+func newProductResolvers(ctx context.Context, products []*models.Product) []*productResolver {
+	resolvers := make([]*productResolver, len(products))
+	ids := make([]int, len(products))
+	for i, product := range products {
+		resolvers[i] = &productResolver{p: product}
+		ids[i] = product.ID
+	}
+	loadersFrom(ctx).productBillersByProduct.Prime(ids...)
+	return resolvers
+}
+
+func (r *productResolver) ID() graphql.ID          { return toID(r.p.ID) }
+func (r *productResolver) Label() string           { return r.p.Label }
+func (r *productResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.p.CreatedAt} }
+func (r *productResolver) CreatedBy() string       { return r.p.CreatedBy }
+func (r *productResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.p.UpdatedAt} }
+func (r *productResolver) UpdatedBy() string       { return r.p.UpdatedBy }
+func (r *productResolver) Version() int32          { return int32(r.p.Version) }
+
+func (r *productResolver) ProductBillers(ctx context.Context, args struct{ IsActive *bool }) ([]*productBillerResolver, error) {
+	productBillers, err := loadersFrom(ctx).productBillersByProduct.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newProductBillerResolvers(ctx, filterActive(productBillers, args.IsActive)), nil
+}
+
+// billerResolver resolves the fields of the Biller type.
+type billerResolver struct {
+	b *models.Biller
+}
+
+// newBillerResolvers resolves a list of billers, priming the loader of their product billers.
+func newBillerResolvers(ctx context.Context, billers []*models.Biller) []*billerResolver {
+	resolvers := make([]*billerResolver, len(billers))
+	ids := make([]int, len(billers))
+	for i, biller := range billers {
+		resolvers[i] = &billerResolver{b: biller}
+		ids[i] = biller.ID
+	}
+	loadersFrom(ctx).productBillersByBiller.Prime(ids...)
+	return resolvers
+}
+
+func (r *billerResolver) ID() graphql.ID          { return toID(r.b.ID) }
+func (r *billerResolver) Label() string           { return r.b.Label }
+func (r *billerResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.b.CreatedAt} }
+func (r *billerResolver) CreatedBy() string       { return r.b.CreatedBy }
+func (r *billerResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.b.UpdatedAt} }
+func (r *billerResolver) UpdatedBy() string       { return r.b.UpdatedBy }
+func (r *billerResolver) Version() int32          { return int32(r.b.Version) }
+
+func (r *billerResolver) ProductBillers(ctx context.Context, args struct{ IsActive *bool }) ([]*productBillerResolver, error) {
+	productBillers, err := loadersFrom(ctx).productBillersByBiller.Load(ctx, r.b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newProductBillerResolvers(ctx, filterActive(productBillers, args.IsActive)), nil
+}
+
+// productBillerResolver resolves the fields of the ProductBiller type.
+type productBillerResolver struct {
+	pb *models.ProductBiller
+}
+
+// newProductBillerResolvers resolves a list of product billers, priming the loaders of their products and billers.
+func newProductBillerResolvers(ctx context.Context, productBillers []*models.ProductBiller) []*productBillerResolver {
+	resolvers := make([]*productBillerResolver, len(productBillers))
+	productIDs := make([]int, len(productBillers))
+	billerIDs := make([]int, len(productBillers))
+	for i, productBiller := range productBillers {
+		resolvers[i] = &productBillerResolver{pb: productBiller}
+		productIDs[i] = productBiller.ProductID
+		billerIDs[i] = productBiller.BillerID
+	}
+	loaders := loadersFrom(ctx)
+	loaders.products.Prime(productIDs...)
+	loaders.billers.Prime(billerIDs...)
+	return resolvers
+}
+
+func (r *productBillerResolver) ID() graphql.ID             { return toID(r.pb.ID) }
+func (r *productBillerResolver) ProductID() graphql.ID      { return toID(r.pb.ProductID) }
+func (r *productBillerResolver) BillerID() graphql.ID       { return toID(r.pb.BillerID) }
+func (r *productBillerResolver) IsActive() bool             { return r.pb.IsActive }
+func (r *productBillerResolver) Priority() int32            { return int32(r.pb.Priority) }
+func (r *productBillerResolver) Weight() int32              { return int32(r.pb.Weight) }
+func (r *productBillerResolver) DeactivatedBy() string      { return r.pb.DeactivatedBy }
+func (r *productBillerResolver) DeactivationReason() string { return r.pb.DeactivationReason }
+func (r *productBillerResolver) CreatedAt() graphql.Time    { return graphql.Time{Time: r.pb.CreatedAt} }
+func (r *productBillerResolver) CreatedBy() string          { return r.pb.CreatedBy }
+func (r *productBillerResolver) UpdatedAt() graphql.Time    { return graphql.Time{Time: r.pb.UpdatedAt} }
+func (r *productBillerResolver) UpdatedBy() string          { return r.pb.UpdatedBy }
+func (r *productBillerResolver) Version() int32             { return int32(r.pb.Version) }
+
+func (r *productBillerResolver) DeactivatedAt() *graphql.Time {
+	if r.pb.DeactivatedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.pb.DeactivatedAt}
+}
+
+func (r *productBillerResolver) Product(ctx context.Context) (*productResolver, error) {
+	product, err := loadersFrom(ctx).products.Load(ctx, r.pb.ProductID)
+	if err != nil || product == nil {
+		return nil, err
+	}
+	return newProductResolvers(ctx, []*models.Product{product})[0], nil
+}
+
+func (r *productBillerResolver) Biller(ctx context.Context) (*billerResolver, error) {
+	biller, err := loadersFrom(ctx).billers.Load(ctx, r.pb.BillerID)
+	if err != nil || biller == nil {
+		return nil, err
+	}
+	return newBillerResolvers(ctx, []*models.Biller{biller})[0], nil
+}
+
+type productPageResolver struct {
+	data       []*productResolver
+	pagination *db.Pagination
+}
+
+func (r *productPageResolver) Data() []*productResolver { return r.data }
+func (r *productPageResolver) Pagination() *paginationResolver {
+	return &paginationResolver{r.pagination}
+}
+
+type billerPageResolver struct {
+	data       []*billerResolver
+	pagination *db.Pagination
+}
+
+func (r *billerPageResolver) Data() []*billerResolver { return r.data }
+func (r *billerPageResolver) Pagination() *paginationResolver {
+	return &paginationResolver{r.pagination}
+}
+
+type productBillerPageResolver struct {
+	data       []*productBillerResolver
+	pagination *db.Pagination
+}
+
+func (r *productBillerPageResolver) Data() []*productBillerResolver { return r.data }
+func (r *productBillerPageResolver) Pagination() *paginationResolver {
+	return &paginationResolver{r.pagination}
+}
+
+// paginationResolver resolves the fields of the Pagination type.
+type paginationResolver struct {
+	p *db.Pagination
+}
+
+func (r *paginationResolver) Page() int32       { return int32(r.p.Page) }
+func (r *paginationResolver) Limit() int32      { return int32(r.p.Limit) }
+func (r *paginationResolver) TotalRows() int32  { return int32(r.p.TotalRows) }
+func (r *paginationResolver) TotalPages() int32 { return int32(r.p.TotalPages) }
+
+// filterActive keeps the product billers whose status is isActive, or all of them when it is nil.
+func filterActive(productBillers []*models.ProductBiller, isActive *bool) []*models.ProductBiller {
+	if isActive == nil {
+		return productBillers
+	}
+	filtered := make([]*models.ProductBiller, 0, len(productBillers))
+	for _, productBiller := range productBillers {
+		if productBiller.IsActive == *isActive {
+			filtered = append(filtered, productBiller)
+		}
+	}
+	return filtered
+}
+
+// notFoundAsNull resolves a missing entity as null rather than an error.
+func notFoundAsNull(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+func parseID(id graphql.ID) (int, error) {
+	value, err := strconv.Atoi(string(id))
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("%w: invalid ID %q: must be a positive integer", ErrInvalidArgument, id)
+	}
+	return value, nil
+}
+
+func toID(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"golang-boilerplate/internal/app/http/usecases"
+)
+
+//go:embed schema.graphql
+var schemaDefinition string
+
+// maxDepth bounds the nesting of queries, which could otherwise walk products and billers back and forth.
+const maxDepth = 8
+
+// Schema is the GraphQL schema of the catalog, resolved by the same usecases as the HTTP API.
+type Schema struct {
+	schema         *graphql.Schema
+	products       usecases.ProductUseCase
+	billers        usecases.BillerUseCase
+	productBillers usecases.ProductBillerUseCase
+}
+
+// NewSchema parses the schema and checks it against its resolvers.
+func NewSchema(
+	products usecases.ProductUseCase,
+	billers usecases.BillerUseCase,
+	productBillers usecases.ProductBillerUseCase,
+) (*Schema, error) {
+	s := &Schema{
+		products:       products,
+		billers:        billers,
+		productBillers: productBillers,
+	}
+
+	schema, err := graphql.ParseSchema(schemaDefinition, &queryResolver{s: s}, graphql.MaxDepth(maxDepth))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+	s.schema = schema
+
+	return s, nil
+}
+
+// Exec executes a query, batching the lookups of its nested fields with loaders scoped to the query.
+func (s *Schema) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Response {
+	ctx = context.WithValue(ctx, loadersCtxKey{}, s.newLoaders())
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
@@ -0,0 +1,138 @@
+package graph_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/graph"
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type testSchema struct {
+	schema            *graph.Schema
+	productRepo       *mocks.MockProductRepository
+	billerRepo        *mocks.MockBillerRepository
+	productBillerRepo *mocks.MockProductBillerRepository
+}
+
+func newTestSchema(t *testing.T) *testSchema {
+	productRepo := new(mocks.MockProductRepository)
+	billerRepo := new(mocks.MockBillerRepository)
+	productBillerRepo := new(mocks.MockProductBillerRepository)
+	uow := new(mocks.MockUnitOfWork)
+
+	schema, err := graph.NewSchema(
+		usecases.NewProductUseCase(productRepo, uow, usecases.DeletionPolicyCascade),
+		usecases.NewBillerUseCase(billerRepo, uow, usecases.DeletionPolicyCascade),
+		usecases.NewProductBillerUseCase(productBillerRepo, productRepo, billerRepo, uow),
+	)
+	require.NoError(t, err)
+
+	return &testSchema{
+		schema:            schema,
+		productRepo:       productRepo,
+		billerRepo:        billerRepo,
+		productBillerRepo: productBillerRepo,
+	}
+}
+
+// sameIDs reports whether got holds the IDs of want, in any order.
+func sameIDs(got []int, want ...int) bool {
+	got = append([]int(nil), got...)
+	sort.Ints(got)
+	return assert.ObjectsAreEqual(want, got)
+}
+
+func TestSchema_NestedQueryIsBatched(t *testing.T) {
+	s := newTestSchema(t)
+
+	s.productRepo.On("FetchManyWithPagination", mock.Anything, models.ProductFilter{}, 1, 2).Return(
+		[]*models.Product{{ID: 1, Label: "Pulsa"}, {ID: 2, Label: "Data"}},
+		&db.Pagination{Page: 1, Limit: 2, TotalRows: 3, TotalPages: 2},
+		nil,
+	)
+	s.productBillerRepo.On("FetchMany", mock.Anything, mock.MatchedBy(func(filter models.ProductBillerFilter) bool {
+		return sameIDs(filter.ProductIDs, 1, 2)
+	})).Return([]*models.ProductBiller{
+		{ID: 10, ProductID: 1, BillerID: 5, IsActive: true},
+		{ID: 11, ProductID: 1, BillerID: 6, IsActive: false},
+		{ID: 12, ProductID: 2, BillerID: 6, IsActive: true},
+	}, nil).Once()
+	s.billerRepo.On("FetchMany", mock.Anything, mock.MatchedBy(func(filter models.BillerFilter) bool {
+		return sameIDs(filter.IDs, 5, 6)
+	})).Return([]*models.Biller{{ID: 5, Label: "Alpha"}, {ID: 6, Label: "Beta"}}, nil).Once()
+
+	response := s.schema.Exec(context.Background(), `{
+		products(limit: 2) {
+			data {
+				id
+				label
+				productBillers(isActive: true) { id isActive biller { label } }
+			}
+			pagination { totalRows totalPages }
+		}
+	}`, "", nil)
+	require.Empty(t, response.Errors)
+
+	var data struct {
+		Products struct {
+			Data []struct {
+				ID             string
+				Label          string
+				ProductBillers []struct {
+					ID       string
+					IsActive bool
+					Biller   struct{ Label string }
+				}
+			}
+			Pagination struct{ TotalRows, TotalPages int }
+		}
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &data))
+
+	require.Len(t, data.Products.Data, 2)
+	assert.Equal(t, 3, data.Products.Pagination.TotalRows)
+	require.Len(t, data.Products.Data[0].ProductBillers, 1)
+	assert.Equal(t, "10", data.Products.Data[0].ProductBillers[0].ID)
+	assert.Equal(t, "Alpha", data.Products.Data[0].ProductBillers[0].Biller.Label)
+	require.Len(t, data.Products.Data[1].ProductBillers, 1)
+	assert.Equal(t, "Beta", data.Products.Data[1].ProductBillers[0].Biller.Label)
+
+	// One query per level of nesting, however many products and billers are listed.
+	s.productBillerRepo.AssertNumberOfCalls(t, "FetchMany", 1)
+	s.billerRepo.AssertNumberOfCalls(t, "FetchMany", 1)
+}
+
+func TestSchema_Product(t *testing.T) {
+	s := newTestSchema(t)
+	s.productRepo.On("FetchOne", mock.Anything, 2).Return(nil, sql.ErrNoRows)
+
+	t.Run("resolves a missing product as null", func(t *testing.T) {
+		response := s.schema.Exec(context.Background(), `{ product(id: 2) { id } }`, "", nil)
+		require.Empty(t, response.Errors)
+		assert.JSONEq(t, `{"product": null}`, string(response.Data))
+	})
+
+	t.Run("rejects an invalid ID", func(t *testing.T) {
+		response := s.schema.Exec(context.Background(), `query($id: ID!) { product(id: $id) { id } }`, "",
+			map[string]interface{}{"id": "abc"})
+		require.Len(t, response.Errors, 1)
+		assert.ErrorIs(t, response.Errors[0].ResolverError, graph.ErrInvalidArgument)
+	})
+
+	t.Run("rejects an unknown sort", func(t *testing.T) {
+		response := s.schema.Exec(context.Background(), `{ products(sort: "unknown") { data { id } } }`, "", nil)
+		require.Len(t, response.Errors, 1)
+		assert.ErrorIs(t, response.Errors[0].ResolverError, graph.ErrInvalidArgument)
+	})
+}
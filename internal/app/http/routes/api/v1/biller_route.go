@@ -1,17 +1,89 @@
 package v1
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 
 	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
 )
 
 func RegisterBillerRoute(e *echo.Group, billerController *controllers.BillerController) {
-	billerGroup := e.Group("/product-billers")
+	billerGroup := e.Group("/billers")
 	billerGroup.POST("", billerController.Create)
+	billerGroup.GET("/export", billerController.Export)
 	billerGroup.PUT("/:id", billerController.Update)
+	billerGroup.PATCH("/:id", billerController.Patch)
 	billerGroup.DELETE("/:id", billerController.Delete)
+	billerGroup.POST("/:id/restore", billerController.Restore)
 	billerGroup.GET("/:id", billerController.FetchOne)
+	billerGroup.GET("/:id/products", billerController.FetchProducts)
 	billerGroup.GET("/all", billerController.FetchMany)
 	billerGroup.GET("", billerController.FetchManyWithPagination)
 }
+
+func billerRouteDocs() []openapi.Route {
+	const tag = "Billers"
+	return []openapi.Route{
+		{
+			Method: http.MethodPost, Path: "/billers", Tag: tag, Summary: "Create a biller",
+			Body:      models.CreateBillerRequest{},
+			Responses: responses(http.StatusCreated, models.BillerResponse{}, http.StatusBadRequest, http.StatusConflict),
+		},
+		{
+			Method: http.MethodGet, Path: "/billers/export", Tag: tag, Summary: "Download the billers matching the filters",
+			Query:     models.BillerFilter{},
+			Params:    []openapi.Parameter{sortParam(repositories.BillerSortColumns), exportFormatParam},
+			Responses: responses(http.StatusOK, exportedFile(), http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodPut, Path: "/billers/:id", Tag: tag, Summary: "Replace a biller",
+			Params:    []openapi.Parameter{ifMatch},
+			Body:      models.UpdateBillerRequest{},
+			Responses: responses(http.StatusOK, message{}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodPatch, Path: "/billers/:id", Tag: tag, Summary: "Update some fields of a biller with a JSON Merge Patch",
+			Params:    []openapi.Parameter{ifMatch},
+			Body:      openapi.Content{"application/merge-patch+json": models.PatchBillerRequest{}},
+			Responses: responses(http.StatusOK, message{}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodDelete, Path: "/billers/:id", Tag: tag, Summary: "Delete a biller, applying the deletion policy to its product billers",
+			Params:    []openapi.Parameter{ifMatch},
+			Responses: responses(http.StatusOK, message{}, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodPost, Path: "/billers/:id/restore", Tag: tag, Summary: "Restore a deleted biller",
+			Params:    []openapi.Parameter{queryParam("cascade", "boolean", "Whether to restore the product billers deleted along with the biller")},
+			Responses: responses(http.StatusOK, models.BillerResponse{}, http.StatusNotFound, http.StatusConflict),
+		},
+		{
+			Method: http.MethodGet, Path: "/billers/:id", Tag: tag, Summary: "Get a biller",
+			Params:    []openapi.Parameter{queryParam("include_deleted", "boolean", "Whether to get a deleted biller too")},
+			Responses: responses(http.StatusOK, models.BillerResponse{}, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodGet, Path: "/billers/:id/products", Tag: tag,
+			Summary:   "List the products of a biller page by page, as its product billers embedding their product",
+			Query:     models.ProductBillerFilter{},
+			Params:    append(listParams(repositories.ProductBillerSortColumns), expandParam),
+			Responses: responses(http.StatusOK, pageOf[models.ProductBillerResponse]{}, http.StatusBadRequest, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodGet, Path: "/billers/all", Tag: tag, Summary: "List all billers",
+			Query:     models.BillerFilter{},
+			Params:    []openapi.Parameter{sortParam(repositories.BillerSortColumns)},
+			Responses: responses(http.StatusOK, []models.BillerResponse{}, http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodGet, Path: "/billers", Tag: tag, Summary: "List billers page by page",
+			Query:     models.BillerFilter{},
+			Params:    listParams(repositories.BillerSortColumns),
+			Responses: responses(http.StatusOK, pageOf[models.BillerResponse]{}, http.StatusBadRequest),
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/export"
+	"golang-boilerplate/internal/pkg/openapi"
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+// Docs documents the routes of API version 1, which the OpenAPI documents are generated from. Version 2
+// serves the same routes, answering the documented bodies in an Envelope, see EnvelopeResponse.
+// Every route registered in this package must be documented here.
+func Docs() []openapi.Route {
+	var docs []openapi.Route
+	for _, resource := range [][]openapi.Route{
+		productRouteDocs(),
+		billerRouteDocs(),
+		productBillerRouteDocs(),
+		productBillerStatRouteDocs(),
+		routingRouteDocs(),
+		notificationRouteDocs(),
+		jobRouteDocs(),
+	} {
+		docs = append(docs, resource...)
+	}
+	return docs
+}
+
+// ErrorBody is the body of error responses in version 1.
+type ErrorBody struct {
+	Message string `json:"message"`
+}
+
+// message is the body of responses confirming a change.
+type message struct {
+	Message string `json:"message"`
+}
+
+// validationFailed is the body of a 400 response to a request with invalid fields.
+type validationFailed struct {
+	Message string            `json:"message"`
+	Errors  validation.Errors `json:"errors"`
+}
+
+// badRequest is the body of a 400 response, which lists the invalid fields when the body failed validation.
+type badRequest struct{}
+
+func (badRequest) OpenAPISchema(g *openapi.Generator) *openapi.Schema {
+	return &openapi.Schema{OneOf: []*openapi.Schema{g.Schema(validationFailed{}), g.Schema(ErrorBody{})}}
+}
+
+// pageOf is the body of a paginated list of T.
+type pageOf[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination pagination `json:"pagination"`
+}
+
+// pagination is the page based or, with the cursor parameter, the cursor based pagination of a list.
+type pagination struct{}
+
+func (pagination) OpenAPISchema(g *openapi.Generator) *openapi.Schema {
+	return &openapi.Schema{OneOf: []*openapi.Schema{g.Schema(db.Pagination{}), g.Schema(db.CursorPagination{})}}
+}
+
+// data returns the schema of the list of a page, which version 2 answers as the data of an Envelope.
+func (pageOf[T]) data(g *openapi.Generator) *openapi.Schema {
+	return g.Schema([]T{})
+}
+
+// Envelope documents controllers.Envelope, the body of every response from version 2 on.
+type Envelope struct {
+	// Data is the data of a success, null on errors.
+	Data   interface{}                 `json:"data"`
+	Meta   envelopeMeta                `json:"meta"`
+	Errors []controllers.ResponseError `json:"errors"`
+}
+
+// envelopeMeta documents controllers.Meta.
+type envelopeMeta struct {
+	RequestID  string     `json:"request_id,omitempty"`
+	Pagination pagination `json:"pagination,omitempty"`
+	Message    string     `json:"message,omitempty"`
+}
+
+// EnvelopeResponse returns the schema of the version 2 response to a route documented as answering body:
+// an Envelope holding body as data. Pages hold their list as data and their pagination in the meta, while
+// confirmations and errors answer an Envelope without data.
+func EnvelopeResponse(g *openapi.Generator, body interface{}) *openapi.Schema {
+	envelope := g.Schema(Envelope{})
+	var data *openapi.Schema
+	switch body := body.(type) {
+	case message, ErrorBody, badRequest:
+		return envelope
+	case interface {
+		data(g *openapi.Generator) *openapi.Schema
+	}:
+		data = body.data(g)
+	default:
+		data = g.Schema(body)
+	}
+	return &openapi.Schema{AllOf: []*openapi.Schema{
+		envelope,
+		{Type: "object", Properties: map[string]*openapi.Schema{"data": data}},
+	}}
+}
+
+// file is an uploaded file.
+type file struct{}
+
+func (file) OpenAPISchema(*openapi.Generator) *openapi.Schema {
+	return &openapi.Schema{Type: "string", Format: "binary"}
+}
+
+// responses documents the body of the success status, along with the error statuses the route answers.
+func responses(status int, body interface{}, errorStatuses ...int) map[int]interface{} {
+	documented := map[int]interface{}{status: body}
+	for _, errorStatus := range errorStatuses {
+		if errorStatus == http.StatusBadRequest {
+			documented[errorStatus] = badRequest{}
+		} else {
+			documented[errorStatus] = ErrorBody{}
+		}
+	}
+	return documented
+}
+
+func queryParam(name, schemaType, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: schemaType}}
+}
+
+// ifMatch is the header versioned changes are conditional on.
+var ifMatch = openapi.Parameter{
+	Name:        "If-Match",
+	In:          "header",
+	Description: "ETag of the version of the entity the change is based on",
+	Required:    true,
+	Schema:      &openapi.Schema{Type: "string"},
+}
+
+// expandParam documents the related resources product billers can embed.
+var exportFormatParam = queryParam("format", "string", "File format: csv (default), json or xlsx")
+
+// exportedFile documents a download in any of the export formats.
+func exportedFile() openapi.Content {
+	content := openapi.Content{}
+	for _, format := range export.Formats {
+		content[format.ContentType()] = nil
+	}
+	return content
+}
+
+var expandParam = queryParam("expand", "string", "Comma separated resources to embed in each product biller: product, biller")
+
+// sortParam documents the sort parameter of a list, ordered by the given columns.
+func sortParam(columns map[string]string) openapi.Parameter {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return queryParam("sort", "string", "Comma separated columns to sort by, descending when prefixed with -: "+strings.Join(names, ", "))
+}
+
+// listParams documents the parameters of a paginated list, ordered by the given columns.
+func listParams(columns map[string]string) []openapi.Parameter {
+	return []openapi.Parameter{
+		sortParam(columns),
+		queryParam("page", "integer", "Page number, starting at 1"),
+		queryParam("limit", "integer", "Rows per page, 10 by default"),
+		queryParam("cursor", "string", "Cursor of the page, empty for the first one; switches to cursor pagination"),
+		queryParam("count", "boolean", "Whether to count the total rows of a cursor paginated list"),
+	}
+}
@@ -0,0 +1,140 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/config"
+	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/app/http/routes"
+	v1 "golang-boilerplate/internal/app/http/routes/api/v1"
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+// registerAll registers every route as the server does.
+func registerAll(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	cfg := &config.Config{Service: config.Service{
+		RoutingStrategy:       "weighted",
+		ProductDeletionPolicy: "cascade",
+		BillerDeletionPolicy:  "cascade",
+	}}
+	log := zerolog.Nop()
+	e := echo.New()
+	// Roles are only consulted by requests to authorized routes.
+	routes.RegisterRoutes(e, routes.NewUseCases(sqlx.NewDb(sqlDB, "mysql"), &log, cfg), nil, &log, cfg)
+	return e
+}
+
+// serve answers a GET request to path.
+func serve(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func TestDocs(t *testing.T) {
+	e := registerAll(t)
+
+	for _, version := range []controllers.APIVersion{controllers.V1, controllers.V2} {
+		t.Run(version.Prefix, func(t *testing.T) {
+			rec := serve(e, "/api/docs/v"+strconv.Itoa(version.Number)+"/openapi.json")
+			require.Equal(t, http.StatusOK, rec.Code)
+			var doc openapi.Document
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+			testDocument(t, e, version, &doc)
+		})
+	}
+
+	t.Run("redirects to the latest version", func(t *testing.T) {
+		rec := serve(e, "/api/docs")
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/api/docs/v2", rec.Header().Get(echo.HeaderLocation))
+	})
+}
+
+func testDocument(t *testing.T, e *echo.Echo, version controllers.APIVersion, doc *openapi.Document) {
+	t.Run("every route is in the specification", func(t *testing.T) {
+		for _, route := range e.Routes() {
+			// Groups with middleware register catch-all not found routes, which are not API routes.
+			if !strings.HasPrefix(route.Path, version.Prefix+"/") || route.Method == echo.RouteNotFound {
+				continue
+			}
+			path := pathParam.ReplaceAllString(route.Path, "{$1}")
+			item, ok := doc.Paths[path]
+			if assert.Truef(t, ok, "%s %s is missing from the specification", route.Method, route.Path) {
+				assert.Containsf(t, *item, strings.ToLower(route.Method), "%s %s is missing from the specification", route.Method, route.Path)
+			}
+		}
+	})
+
+	t.Run("every documented route is registered", func(t *testing.T) {
+		registered := map[string]bool{}
+		for _, route := range e.Routes() {
+			registered[route.Method+" "+route.Path] = true
+		}
+		for _, route := range v1.Docs() {
+			assert.Truef(t, registered[route.Method+" "+version.Prefix+route.Path], "%s %s is documented but not registered", route.Method, route.Path)
+		}
+	})
+
+	t.Run("every reference resolves", func(t *testing.T) {
+		encoded, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		for _, ref := range regexp.MustCompile(`"\$ref":"#/components/schemas/(\w+)"`).FindAllStringSubmatch(string(encoded), -1) {
+			assert.Containsf(t, doc.Components.Schemas, ref[1], "%s is referenced but not defined", ref[1])
+		}
+	})
+
+	t.Run("operations are deprecated with version 1", func(t *testing.T) {
+		for path, item := range doc.Paths {
+			for method, op := range *item {
+				assert.Equalf(t, version == controllers.V1, op.Deprecated, "deprecation of %s %s", method, path)
+			}
+		}
+	})
+
+	t.Run("JSON responses are envelopes from version 2 on", func(t *testing.T) {
+		envelope := "#/components/schemas/Envelope"
+		for path, item := range doc.Paths {
+			for method, op := range *item {
+				for status, response := range op.Responses {
+					media, ok := response.Content[echo.MIMEApplicationJSON]
+					if !ok {
+						continue
+					}
+					schema := media.Schema
+					if len(schema.AllOf) > 0 {
+						schema = schema.AllOf[0]
+					}
+					if version == controllers.V1 {
+						assert.NotEqualf(t, envelope, schema.Ref, "%s %s answers an envelope with %s", method, path, status)
+					} else {
+						assert.Equalf(t, envelope, schema.Ref, "%s %s does not answer an envelope with %s", method, path, status)
+					}
+				}
+			}
+		}
+	})
+}
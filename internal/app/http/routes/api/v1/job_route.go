@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+func RegisterJobRoute(e *echo.Group, jobController *controllers.JobController) {
+	jobGroup := e.Group("/jobs")
+	jobGroup.GET("/:id", jobController.FetchOne)
+	jobGroup.POST("/:id/cancel", jobController.Cancel)
+}
+
+func jobRouteDocs() []openapi.Route {
+	const tag = "Jobs"
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/jobs/:id", Tag: tag, Summary: "Get the status, progress and result of a job",
+			Responses: responses(http.StatusOK, models.JobResponse{}, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodPost, Path: "/jobs/:id/cancel", Tag: tag, Summary: "Cancel a pending or running job",
+			Responses: responses(http.StatusAccepted, models.JobResponse{}, http.StatusNotFound, http.StatusConflict),
+		},
+	}
+}
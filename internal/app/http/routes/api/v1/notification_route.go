@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+func RegisterNotificationRoute(e *echo.Group, notificationController *controllers.NotificationController) {
+	notificationGroup := e.Group("/notifications")
+	notificationGroup.GET("", notificationController.FetchManyWithPagination)
+	notificationGroup.GET("/:id", notificationController.FetchOne)
+	notificationGroup.POST("/:id/resend", notificationController.Resend)
+}
+
+func notificationRouteDocs() []openapi.Route {
+	const tag = "Notifications"
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/notifications", Tag: tag, Summary: "List notifications page by page",
+			Query:     models.NotificationFilter{},
+			Params:    listParams(repositories.NotificationSortColumns),
+			Responses: responses(http.StatusOK, pageOf[models.QueuedNotificationResponse]{}, http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodGet, Path: "/notifications/:id", Tag: tag, Summary: "Get a notification",
+			Responses: responses(http.StatusOK, models.QueuedNotificationResponse{}, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodPost, Path: "/notifications/:id/resend", Tag: tag, Summary: "Queue a failed notification to be sent again",
+			Responses: responses(http.StatusAccepted, message{}, http.StatusNotFound, http.StatusConflict),
+		},
+	}
+}
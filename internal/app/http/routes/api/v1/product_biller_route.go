@@ -1,17 +1,111 @@
 package v1
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 
 	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
 )
 
 func RegisterProductBillerRoute(e *echo.Group, productBillerController *controllers.ProductBillerController) {
 	productBillerGroup := e.Group("/product-billers")
 	productBillerGroup.POST("", productBillerController.Create)
+	productBillerGroup.POST("/bulk", productBillerController.Import)
+	productBillerGroup.GET("/export", productBillerController.Export)
+	productBillerGroup.POST("/activation", productBillerController.Activate)
 	productBillerGroup.PUT("/:id", productBillerController.Update)
+	productBillerGroup.PATCH("/:id", productBillerController.Patch)
 	productBillerGroup.DELETE("/:id", productBillerController.Delete)
+	productBillerGroup.POST("/:id/restore", productBillerController.Restore)
 	productBillerGroup.GET("/:id", productBillerController.FetchOne)
 	productBillerGroup.GET("/all", productBillerController.FetchMany)
 	productBillerGroup.GET("", productBillerController.FetchManyWithPagination)
 }
+
+func productBillerRouteDocs() []openapi.Route {
+	const tag = "Product Billers"
+	return []openapi.Route{
+		{
+			Method: http.MethodPost, Path: "/product-billers", Tag: tag, Summary: "Map a product to a biller",
+			Body:      models.CreateProductBillerRequest{},
+			Responses: responses(http.StatusCreated, models.ProductBillerResponse{}, http.StatusBadRequest, http.StatusConflict),
+		},
+		{
+			Method: http.MethodPost, Path: "/product-billers/bulk", Tag: tag,
+			Summary: "Import product billers from a JSON array, a CSV file or an uploaded file, or queue the import as a job",
+			Params:  []openapi.Parameter{queryParam("async", "boolean", "Whether to queue the import as a job")},
+			Body: openapi.Content{
+				echo.MIMEApplicationJSON: []models.CreateProductBillerRequest{},
+				"text/csv":               nil,
+				echo.MIMEMultipartForm:   importUpload{},
+			},
+			Responses: func() map[int]interface{} {
+				documented := responses(http.StatusOK, models.ProductBillerImportReport{}, http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+				documented[http.StatusAccepted] = models.JobResponse{}
+				return documented
+			}(),
+		},
+		{
+			Method: http.MethodGet, Path: "/product-billers/export", Tag: tag, Summary: "Download the product billers matching the filters",
+			Query: models.ProductBillerFilter{},
+			Params: []openapi.Parameter{
+				sortParam(repositories.ProductBillerSortColumns),
+				exportFormatParam,
+			},
+			Responses: responses(http.StatusOK, exportedFile(), http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodPost, Path: "/product-billers/activation", Tag: tag,
+			Summary:   "Queue a job activating or deactivating the product billers of a product or a biller",
+			Body:      models.ProductBillerActivationRequest{},
+			Responses: responses(http.StatusAccepted, models.JobResponse{}, http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodPut, Path: "/product-billers/:id", Tag: tag, Summary: "Replace a product biller",
+			Params:    []openapi.Parameter{ifMatch},
+			Body:      models.UpdateProductBillerRequest{},
+			Responses: responses(http.StatusOK, message{}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodPatch, Path: "/product-billers/:id", Tag: tag, Summary: "Update some fields of a product biller with a JSON Merge Patch",
+			Params:    []openapi.Parameter{ifMatch},
+			Body:      openapi.Content{"application/merge-patch+json": models.PatchProductBillerRequest{}},
+			Responses: responses(http.StatusOK, message{}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodDelete, Path: "/product-billers/:id", Tag: tag, Summary: "Delete a product biller",
+			Params:    []openapi.Parameter{ifMatch},
+			Responses: responses(http.StatusOK, message{}, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodPost, Path: "/product-billers/:id/restore", Tag: tag, Summary: "Restore a deleted product biller",
+			Responses: responses(http.StatusOK, models.ProductBillerResponse{}, http.StatusNotFound, http.StatusConflict),
+		},
+		{
+			Method: http.MethodGet, Path: "/product-billers/:id", Tag: tag, Summary: "Get a product biller",
+			Params:    []openapi.Parameter{queryParam("include_deleted", "boolean", "Whether to get a deleted product biller too")},
+			Responses: responses(http.StatusOK, models.ProductBillerResponse{}, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodGet, Path: "/product-billers/all", Tag: tag, Summary: "List all product billers",
+			Query:     models.ProductBillerFilter{},
+			Params:    []openapi.Parameter{sortParam(repositories.ProductBillerSortColumns), expandParam},
+			Responses: responses(http.StatusOK, []models.ProductBillerResponse{}, http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodGet, Path: "/product-billers", Tag: tag, Summary: "List product billers page by page",
+			Query:     models.ProductBillerFilter{},
+			Params:    append(listParams(repositories.ProductBillerSortColumns), expandParam),
+			Responses: responses(http.StatusOK, pageOf[models.ProductBillerResponse]{}, http.StatusBadRequest),
+		},
+	}
+}
+
+// importUpload is the multipart form of an import uploaded as a file, read as JSON when named *.json and as CSV otherwise.
+type importUpload struct {
+	File file `json:"file" validate:"required"`
+}
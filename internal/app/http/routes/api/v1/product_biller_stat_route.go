@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+func RegisterProductBillerStatRoute(e *echo.Group, productBillerStatController *controllers.ProductBillerStatController) {
+	e.GET("/product-billers/:id/stats", productBillerStatController.FetchMany)
+}
+
+func productBillerStatRouteDocs() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/product-billers/:id/stats", Tag: "Product Billers",
+			Summary: "Get time-bucketed transaction statistics of a product biller",
+			Params: []openapi.Parameter{
+				queryParam("from", "string", "Start of the range as an RFC 3339 timestamp, 24 hours before to by default"),
+				queryParam("to", "string", "End of the range as an RFC 3339 timestamp, now by default"),
+				queryParam("bucket", "string", "Bucket size: minute or hour (default)"),
+			},
+			Responses: responses(http.StatusOK, models.ProductBillerStatsResponse{}, http.StatusBadRequest, http.StatusNotFound),
+		},
+	}
+}
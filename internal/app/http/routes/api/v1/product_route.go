@@ -1,17 +1,89 @@
 package v1
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 
 	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
 )
 
 func RegisterProductRoute(e *echo.Group, productController *controllers.ProductController) {
-	productGroup := e.Group("/product-billers")
+	productGroup := e.Group("/products")
 	productGroup.POST("", productController.Create)
+	productGroup.GET("/export", productController.Export)
 	productGroup.PUT("/:id", productController.Update)
+	productGroup.PATCH("/:id", productController.Patch)
 	productGroup.DELETE("/:id", productController.Delete)
+	productGroup.POST("/:id/restore", productController.Restore)
 	productGroup.GET("/:id", productController.FetchOne)
+	productGroup.GET("/:id/billers", productController.FetchBillers)
 	productGroup.GET("/all", productController.FetchMany)
 	productGroup.GET("", productController.FetchManyWithPagination)
 }
+
+func productRouteDocs() []openapi.Route {
+	const tag = "Products"
+	return []openapi.Route{
+		{
+			Method: http.MethodPost, Path: "/products", Tag: tag, Summary: "Create a product",
+			Body:      models.CreateProductRequest{},
+			Responses: responses(http.StatusCreated, models.ProductResponse{}, http.StatusBadRequest, http.StatusConflict),
+		},
+		{
+			Method: http.MethodGet, Path: "/products/export", Tag: tag, Summary: "Download the products matching the filters",
+			Query:     models.ProductFilter{},
+			Params:    []openapi.Parameter{sortParam(repositories.ProductSortColumns), exportFormatParam},
+			Responses: responses(http.StatusOK, exportedFile(), http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodPut, Path: "/products/:id", Tag: tag, Summary: "Replace a product",
+			Params:    []openapi.Parameter{ifMatch},
+			Body:      models.UpdateProductRequest{},
+			Responses: responses(http.StatusOK, message{}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodPatch, Path: "/products/:id", Tag: tag, Summary: "Update some fields of a product with a JSON Merge Patch",
+			Params:    []openapi.Parameter{ifMatch},
+			Body:      openapi.Content{"application/merge-patch+json": models.PatchProductRequest{}},
+			Responses: responses(http.StatusOK, message{}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodDelete, Path: "/products/:id", Tag: tag, Summary: "Delete a product, applying the deletion policy to its product billers",
+			Params:    []openapi.Parameter{ifMatch},
+			Responses: responses(http.StatusOK, message{}, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired),
+		},
+		{
+			Method: http.MethodPost, Path: "/products/:id/restore", Tag: tag, Summary: "Restore a deleted product",
+			Params:    []openapi.Parameter{queryParam("cascade", "boolean", "Whether to restore the product billers deleted along with the product")},
+			Responses: responses(http.StatusOK, models.ProductResponse{}, http.StatusNotFound, http.StatusConflict),
+		},
+		{
+			Method: http.MethodGet, Path: "/products/:id", Tag: tag, Summary: "Get a product",
+			Params:    []openapi.Parameter{queryParam("include_deleted", "boolean", "Whether to get a deleted product too")},
+			Responses: responses(http.StatusOK, models.ProductResponse{}, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodGet, Path: "/products/:id/billers", Tag: tag,
+			Summary:   "List the billers of a product page by page, as its product billers embedding their biller",
+			Query:     models.ProductBillerFilter{},
+			Params:    append(listParams(repositories.ProductBillerSortColumns), expandParam),
+			Responses: responses(http.StatusOK, pageOf[models.ProductBillerResponse]{}, http.StatusBadRequest, http.StatusNotFound),
+		},
+		{
+			Method: http.MethodGet, Path: "/products/all", Tag: tag, Summary: "List all products",
+			Query:     models.ProductFilter{},
+			Params:    []openapi.Parameter{sortParam(repositories.ProductSortColumns)},
+			Responses: responses(http.StatusOK, []models.ProductResponse{}, http.StatusBadRequest),
+		},
+		{
+			Method: http.MethodGet, Path: "/products", Tag: tag, Summary: "List products page by page",
+			Query:     models.ProductFilter{},
+			Params:    listParams(repositories.ProductSortColumns),
+			Responses: responses(http.StatusOK, pageOf[models.ProductResponse]{}, http.StatusBadRequest),
+		},
+	}
+}
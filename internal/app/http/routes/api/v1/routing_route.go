@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+func RegisterRoutingRoute(e *echo.Group, routingController *controllers.RoutingController) {
+	e.GET("/products/:id/route", routingController.Route)
+}
+
+func routingRouteDocs() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/products/:id/route", Tag: "Routing",
+			Summary:   "Select the biller to route a transaction of a product to",
+			Responses: responses(http.StatusOK, models.ProductBillerRouteResponse{}, http.StatusNotFound),
+		},
+	}
+}
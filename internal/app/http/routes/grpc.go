@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/config"
+	"golang-boilerplate/internal/app/http/rpc"
+)
+
+// NewGRPCServer sets up the gRPC API, served by the same usecases as the HTTP routes.
+func NewGRPCServer(uc *UseCases, log *zerolog.Logger, config *config.Config) *rpc.Server {
+	catalog := rpc.NewCatalogServer(uc.Product, uc.Biller, uc.ProductBiller, uc.Routing, log)
+	return rpc.NewServer(catalog, config.Service.JwtSecret)
+}
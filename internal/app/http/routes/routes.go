@@ -1,25 +1,34 @@
 package routes
 
 import (
-	"github.com/jmoiron/sqlx"
+	"fmt"
+	"net/http"
+	"strconv"
+
 	"github.com/labstack/echo-contrib/echoprometheus"
+	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog"
 
 	"golang-boilerplate/internal/app/http/config"
 	"golang-boilerplate/internal/app/http/controllers"
+	"golang-boilerplate/internal/app/http/graph"
 	v1 "golang-boilerplate/internal/app/http/routes/api/v1"
-	"golang-boilerplate/internal/app/http/usecases"
-	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/auth"
+	dbconn "golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/openapi"
+	"golang-boilerplate/internal/pkg/rbac"
+	"golang-boilerplate/internal/pkg/validation"
 )
 
-// RegisterRoutes sets up all HTTP routes, middleware, and usecases.
-func RegisterRoutes(e *echo.Echo, db *sqlx.DB, log *zerolog.Logger, config *config.Config) {
+// RegisterRoutes sets up all HTTP routes and middleware, served by the given usecases.
+func RegisterRoutes(e *echo.Echo, uc *UseCases, roles rbac.RolesManager, log *zerolog.Logger, config *config.Config) {
 	// General Middleware Configuration
 	e.HideBanner = true
 	e.Pre(middleware.RemoveTrailingSlash())
+	e.HTTPErrorHandler = controllers.ErrorHandler
 	e.Use(middleware.Secure())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowHeaders: []string{
@@ -27,6 +36,14 @@ func RegisterRoutes(e *echo.Echo, db *sqlx.DB, log *zerolog.Logger, config *conf
 			echo.HeaderContentType,
 			echo.HeaderAccept,
 			echo.HeaderAuthorization,
+			"If-Match",
+		},
+		ExposeHeaders: []string{
+			echo.HeaderLocation,
+			"ETag",
+			"Deprecation",
+			"Sunset",
+			"Link",
 		},
 	}))
 	e.Use(middleware.RequestID())
@@ -43,27 +60,77 @@ func RegisterRoutes(e *echo.Echo, db *sqlx.DB, log *zerolog.Logger, config *conf
 		LogValuesFunc: requestLogger.LogRequest, // Custom log function
 	}))
 
-	// Initialize Unit of Work
-	uow := repositories.NewUnitOfWork(db)
-
-	// Initialize Repositories
-	productRepo := repositories.NewProductRepository(db)
-	billerRepo := repositories.NewBillerRepository(db)
-	productBillerRepo := repositories.NewProductBillerRepository(db)
+	// Initialize Request Validation, checking that referenced products and billers exist
+	e.Validator = validation.New(
+		validation.WithLookup("product", validation.FetchLookup(uc.Product.FetchOne)),
+		validation.WithLookup("biller", validation.FetchLookup(uc.Biller.FetchOne)),
+	)
 
-	// Initialize UseCases
-	productUseCase := usecases.NewProductUseCase(productRepo, uow)
-	billerUseCase := usecases.NewBillerUseCase(billerRepo, uow)
-	productBillerUseCase := usecases.NewProductBillerUseCase(productBillerRepo, productRepo, billerRepo)
+	// Initialize Pagination Cursor Signing
+	cursorSigner := dbconn.NewCursorSigner(config.Service.CursorSecret)
 
 	// Initialize Controllers
-	productCtrl := controllers.NewProductController(productUseCase, log)
-	billerCtrl := controllers.NewBillerController(billerUseCase, log)
-	productBillerCtrl := controllers.NewProductBillerController(productBillerUseCase, log)
+	productCtrl := controllers.NewProductController(uc.Product, uc.ProductBiller, cursorSigner, log)
+	billerCtrl := controllers.NewBillerController(uc.Biller, uc.ProductBiller, cursorSigner, log)
+	productBillerCtrl := controllers.NewProductBillerController(uc.ProductBiller, uc.Job, cursorSigner, log)
+	productBillerStatCtrl := controllers.NewProductBillerStatController(uc.ProductBillerStat, log)
+	routingCtrl := controllers.NewRoutingController(uc.Routing, log)
+	notificationCtrl := controllers.NewNotificationController(uc.Notification, cursorSigner, log)
+	jobCtrl := controllers.NewJobController(uc.Job, log)
+
+	// Register API Version 1 Routes, deprecated in favor of version 2, and API Version 2 Routes, the same
+	// routes answering with envelopes
+	apiV1 := e.Group(controllers.V1.Prefix, controllers.V1.Middleware(), controllers.Deprecate(
+		controllers.V1, config.Service.V1DeprecatedAt, config.Service.V1SunsetAt, controllers.V2,
+	))
+	apiV2 := e.Group(controllers.V2.Prefix, controllers.V2.Middleware())
+	for _, api := range []*echo.Group{apiV1, apiV2} {
+		v1.RegisterProductRoute(api, productCtrl)
+		v1.RegisterBillerRoute(api, billerCtrl)
+		v1.RegisterProductBillerRoute(api, productBillerCtrl)
+		v1.RegisterProductBillerStatRoute(api, productBillerStatCtrl)
+		v1.RegisterRoutingRoute(api, routingCtrl)
+		v1.RegisterNotificationRoute(api, notificationCtrl)
+		v1.RegisterJobRoute(api, jobCtrl)
+	}
+
+	// Register API Documentation, a document per version generated from the routes registered above:
+	// version 1 deprecated, version 2 answering envelopes
+	for _, api := range []struct {
+		version controllers.APIVersion
+		builder openapi.Builder
+	}{
+		{controllers.V1, openapi.Builder{Error: v1.ErrorBody{}, Deprecated: true}},
+		{controllers.V2, openapi.Builder{Error: v1.Envelope{}, Response: v1.EnvelopeResponse}},
+	} {
+		docsPath := "/api/docs/v" + strconv.Itoa(api.version.Number)
+		api.builder.Info = openapi.Info{
+			Title:   fmt.Sprintf("%s API v%d", config.App.Name, api.version.Number),
+			Version: config.App.Version,
+		}
+		api.builder.Prefix = api.version.Prefix
+		docsCtrl, err := controllers.NewDocsController(api.builder.Build(e.Routes(), v1.Docs()), docsPath+"/openapi.json", "/api/docs/assets")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize API documentation")
+		}
+		e.GET(docsPath, docsCtrl.UI)
+		e.GET(docsPath+"/openapi.json", docsCtrl.Spec)
+	}
+	e.GET("/api/docs", func(ctx echo.Context) error {
+		return ctx.Redirect(http.StatusFound, "/api/docs/v"+strconv.Itoa(controllers.V2.Number))
+	})
+	e.StaticFS("/api/docs/assets", openapi.SwaggerUIAssets())
 
-	// Register API Version 1 Routes
-	apiV1 := e.Group("/api/v1")
-	v1.RegisterProductRoute(apiV1, productCtrl)
-	v1.RegisterBillerRoute(apiV1, billerCtrl)
-	v1.RegisterProductBillerRoute(apiV1, productBillerCtrl)
+	// Register GraphQL, authenticated by JWT and authorized by role
+	schema, err := graph.NewSchema(uc.Product, uc.Biller, uc.ProductBiller)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize GraphQL schema")
+	}
+	graphQLCtrl := controllers.NewGraphQLController(schema, log)
+	graphQLAuth := []echo.MiddlewareFunc{
+		echojwt.WithConfig(auth.InitJwtAuth(config.Service.JwtSecret)),
+		auth.Middleware(roles, "graphql", "query"),
+	}
+	e.GET("/graphql", graphQLCtrl.Query, graphQLAuth...)
+	e.POST("/graphql", graphQLCtrl.Query, graphQLAuth...)
 }
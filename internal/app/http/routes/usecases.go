@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+
+	"golang-boilerplate/internal/app/http/config"
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/routing"
+)
+
+// UseCases holds the usecases of the HTTP service, shared by its HTTP and gRPC APIs.
+type UseCases struct {
+	Product           usecases.ProductUseCase
+	Biller            usecases.BillerUseCase
+	ProductBiller     usecases.ProductBillerUseCase
+	ProductBillerStat usecases.ProductBillerStatUseCase
+	Notification      usecases.NotificationUseCase
+	Job               usecases.JobUseCase
+	Routing           usecases.RoutingUseCase
+}
+
+// NewUseCases sets up the repositories and usecases of the HTTP service.
+func NewUseCases(db *sqlx.DB, log *zerolog.Logger, config *config.Config) *UseCases {
+	// Initialize Unit of Work
+	uow := repositories.NewUnitOfWork(db)
+
+	// Initialize Repositories
+	productRepo := repositories.NewProductRepository(db)
+	billerRepo := repositories.NewBillerRepository(db)
+	productBillerRepo := repositories.NewProductBillerRepository(db)
+	productBillerStatRepo := repositories.NewProductBillerStatRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	jobRepo := repositories.NewJobRepository(db)
+
+	// Initialize Deletion Policies
+	productDeletionPolicy, err := usecases.ParseDeletionPolicy(config.Service.ProductDeletionPolicy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize product deletion policy")
+	}
+	billerDeletionPolicy, err := usecases.ParseDeletionPolicy(config.Service.BillerDeletionPolicy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize biller deletion policy")
+	}
+
+	// Initialize Biller Routing
+	routingStrategy, err := routing.NewStrategy(config.Service.RoutingStrategy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize routing strategy")
+	}
+
+	// Initialize UseCases
+	return &UseCases{
+		Product:           usecases.NewProductUseCase(productRepo, uow, productDeletionPolicy),
+		Biller:            usecases.NewBillerUseCase(billerRepo, uow, billerDeletionPolicy),
+		ProductBiller:     usecases.NewProductBillerUseCase(productBillerRepo, productRepo, billerRepo, uow),
+		ProductBillerStat: usecases.NewProductBillerStatUseCase(productBillerStatRepo, productBillerRepo),
+		Notification:      usecases.NewNotificationUseCase(notificationRepo),
+		Job:               usecases.NewJobUseCase(jobRepo),
+		Routing:           usecases.NewRoutingUseCase(productBillerRepo, productRepo, billerRepo, routingStrategy),
+	}
+}
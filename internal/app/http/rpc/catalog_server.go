@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/models"
+	catalogv1 "golang-boilerplate/internal/pkg/pb/catalog/v1"
+)
+
+// CatalogServer defines the gRPC layer for catalog reads, served by the same usecases as the HTTP API.
+type CatalogServer struct {
+	catalogv1.UnimplementedCatalogServiceServer
+
+	products       usecases.ProductUseCase
+	billers        usecases.BillerUseCase
+	productBillers usecases.ProductBillerUseCase
+	routing        usecases.RoutingUseCase
+	logger         *zerolog.Logger
+}
+
+// NewCatalogServer creates a new instance of CatalogServer.
+func NewCatalogServer(
+	products usecases.ProductUseCase,
+	billers usecases.BillerUseCase,
+	productBillers usecases.ProductBillerUseCase,
+	routing usecases.RoutingUseCase,
+	logger *zerolog.Logger,
+) *CatalogServer {
+	return &CatalogServer{
+		products:       products,
+		billers:        billers,
+		productBillers: productBillers,
+		routing:        routing,
+		logger:         logger,
+	}
+}
+
+const eventClassCatalog = "rpc.catalog"
+
+// GetProduct retrieves a single Product.
+func (s *CatalogServer) GetProduct(ctx context.Context, req *catalogv1.GetProductRequest) (*catalogv1.Product, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.products.FetchOne(reqCtx, id)
+	if err != nil {
+		return nil, s.fail(reqCtx, logger, "GetProduct", err)
+	}
+
+	return productToProto(product), nil
+}
+
+// ListProducts retrieves a page of Products based on filters.
+func (s *CatalogServer) ListProducts(ctx context.Context, req *catalogv1.ListProductsRequest) (*catalogv1.ListProductsResponse, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	filter := models.ProductFilter{Label: req.GetLabel(), LabelPrefix: req.GetLabelPrefix()}
+	page, limit, err := parsePage(req.GetPage(), &filter.ListFilter, repositories.ProductSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	products, pagination, err := s.products.FetchManyWithPagination(reqCtx, filter, page, limit)
+	if err != nil {
+		return nil, s.fail(reqCtx, logger, "ListProducts", err)
+	}
+
+	response := &catalogv1.ListProductsResponse{Pagination: paginationToProto(pagination)}
+	for _, product := range products {
+		response.Products = append(response.Products, productToProto(product))
+	}
+	return response, nil
+}
+
+// GetBiller retrieves a single Biller.
+func (s *CatalogServer) GetBiller(ctx context.Context, req *catalogv1.GetBillerRequest) (*catalogv1.Biller, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	biller, err := s.billers.FetchOne(reqCtx, id)
+	if err != nil {
+		return nil, s.fail(reqCtx, logger, "GetBiller", err)
+	}
+
+	return billerToProto(biller), nil
+}
+
+// ListBillers retrieves a page of Billers based on filters.
+func (s *CatalogServer) ListBillers(ctx context.Context, req *catalogv1.ListBillersRequest) (*catalogv1.ListBillersResponse, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	filter := models.BillerFilter{Label: req.GetLabel(), LabelPrefix: req.GetLabelPrefix()}
+	page, limit, err := parsePage(req.GetPage(), &filter.ListFilter, repositories.BillerSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	billers, pagination, err := s.billers.FetchManyWithPagination(reqCtx, filter, page, limit)
+	if err != nil {
+		return nil, s.fail(reqCtx, logger, "ListBillers", err)
+	}
+
+	response := &catalogv1.ListBillersResponse{Pagination: paginationToProto(pagination)}
+	for _, biller := range billers {
+		response.Billers = append(response.Billers, billerToProto(biller))
+	}
+	return response, nil
+}
+
+// GetProductBiller retrieves a single ProductBiller.
+func (s *CatalogServer) GetProductBiller(ctx context.Context, req *catalogv1.GetProductBillerRequest) (*catalogv1.ProductBiller, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	productBiller, err := s.productBillers.FetchOne(reqCtx, id)
+	if err != nil {
+		return nil, s.fail(reqCtx, logger, "GetProductBiller", err)
+	}
+
+	return productBillerToProto(productBiller), nil
+}
+
+// ListProductBillers retrieves a page of ProductBillers based on filters, such as the active mappings of a product.
+func (s *CatalogServer) ListProductBillers(ctx context.Context, req *catalogv1.ListProductBillersRequest) (*catalogv1.ListProductBillersResponse, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	var filter models.ProductBillerFilter
+	if req.ProductId != nil {
+		id, err := parseID("product_id", req.GetProductId())
+		if err != nil {
+			return nil, err
+		}
+		filter.ProductID = &id
+	}
+	if req.BillerId != nil {
+		id, err := parseID("biller_id", req.GetBillerId())
+		if err != nil {
+			return nil, err
+		}
+		filter.BillerID = &id
+	}
+	filter.IsActive = req.IsActive
+
+	page, limit, err := parsePage(req.GetPage(), &filter.ListFilter, repositories.ProductBillerSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	productBillers, pagination, err := s.productBillers.FetchManyWithPagination(reqCtx, filter, page, limit)
+	if err != nil {
+		return nil, s.fail(reqCtx, logger, "ListProductBillers", err)
+	}
+
+	response := &catalogv1.ListProductBillersResponse{Pagination: paginationToProto(pagination)}
+	for _, productBiller := range productBillers {
+		response.ProductBillers = append(response.ProductBillers, productBillerToProto(productBiller))
+	}
+	return response, nil
+}
+
+// RouteProduct selects the biller that should serve a Product.
+func (s *CatalogServer) RouteProduct(ctx context.Context, req *catalogv1.RouteProductRequest) (*catalogv1.ProductBillerRoute, error) {
+	reqCtx, logger := logger.NewAppLogger(ctx, s.logger)
+
+	id, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	route, err := s.routing.Route(reqCtx, id)
+	if err != nil {
+		if errors.Is(err, usecases.ErrNoActiveBiller) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, s.fail(reqCtx, logger, "RouteProduct", err)
+	}
+
+	return routeToProto(route), nil
+}
+
+// fail maps the error of a usecase to the status of the call, logging unexpected errors.
+func (s *CatalogServer) fail(ctx context.Context, logger *logger.AppLogger, event string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	logger.Error(ctx, eventClassCatalog, event, err.Error())
+	return status.Error(codes.Internal, err.Error())
+}
+
+// parseID checks that an ID field of a request is a positive integer.
+func parseID(field string, id int64) (int, error) {
+	if id < 1 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s: must be a positive integer", field)
+	}
+	return int(id), nil
+}
+
+// parsePage reads the page and limit of a list request, defaulting to the first page of 10 rows, and parses
+// its sort against sortColumns into list.
+func parsePage(req *catalogv1.PageRequest, list *models.ListFilter, sortColumns map[string]string) (int, int, error) {
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	limit := int(req.GetLimit())
+	if limit < 1 {
+		limit = 10
+	}
+
+	sort, err := db.ParseSort(req.GetSort(), sortColumns)
+	if err != nil {
+		return 0, 0, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid sort: %s", err.Error()))
+	}
+	list.Sort = sort
+
+	return page, limit, nil
+}
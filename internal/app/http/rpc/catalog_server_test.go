@@ -0,0 +1,174 @@
+package rpc_test
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"golang-boilerplate/internal/app/http/rpc"
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/auth"
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+	catalogv1 "golang-boilerplate/internal/pkg/pb/catalog/v1"
+	"golang-boilerplate/internal/pkg/routing"
+)
+
+const testSecret = "secret"
+
+type testServer struct {
+	client            catalogv1.CatalogServiceClient
+	health            healthpb.HealthClient
+	productRepo       *mocks.MockProductRepository
+	billerRepo        *mocks.MockBillerRepository
+	productBillerRepo *mocks.MockProductBillerRepository
+}
+
+// newTestServer serves the catalog over an in-memory connection, backed by usecases over mocked repositories.
+func newTestServer(t *testing.T) *testServer {
+	productRepo := new(mocks.MockProductRepository)
+	billerRepo := new(mocks.MockBillerRepository)
+	productBillerRepo := new(mocks.MockProductBillerRepository)
+	uow := new(mocks.MockUnitOfWork)
+	logger := zerolog.Nop()
+
+	catalog := rpc.NewCatalogServer(
+		usecases.NewProductUseCase(productRepo, uow, usecases.DeletionPolicyCascade),
+		usecases.NewBillerUseCase(billerRepo, uow, usecases.DeletionPolicyCascade),
+		usecases.NewProductBillerUseCase(productBillerRepo, productRepo, billerRepo, uow),
+		usecases.NewRoutingUseCase(productBillerRepo, productRepo, billerRepo, routing.NewRoundRobinStrategy()),
+		&logger,
+	)
+	server := rpc.NewServer(catalog, testSecret)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.GracefulStop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &testServer{
+		client:            catalogv1.NewCatalogServiceClient(conn),
+		health:            healthpb.NewHealthClient(conn),
+		productRepo:       productRepo,
+		billerRepo:        billerRepo,
+		productBillerRepo: productBillerRepo,
+	}
+}
+
+// authorized returns a context carrying a token signed with secret in its authorization metadata.
+func authorized(t *testing.T, secret string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.JwtCustomClaims{ID: 1, Username: "payments"}).
+		SignedString([]byte(secret))
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestCatalogServer_Auth(t *testing.T) {
+	s := newTestServer(t)
+	s.productRepo.On("FetchOne", mock.Anything, 1).Return(&models.Product{ID: 1, Label: "Pulsa"}, nil)
+
+	_, err := s.client.GetProduct(context.Background(), &catalogv1.GetProductRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = s.client.GetProduct(authorized(t, "wrong"), &catalogv1.GetProductRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	product, err := s.client.GetProduct(authorized(t, testSecret), &catalogv1.GetProductRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Pulsa", product.GetLabel())
+}
+
+func TestCatalogServer_Health(t *testing.T) {
+	s := newTestServer(t)
+
+	// The health service is served without a token.
+	for _, service := range []string{"", catalogv1.CatalogService_ServiceDesc.ServiceName} {
+		response, err := s.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+	}
+}
+
+func TestCatalogServer_GetProduct(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t, testSecret)
+	s.productRepo.On("FetchOne", mock.Anything, 2).Return(nil, sql.ErrNoRows)
+
+	_, err := s.client.GetProduct(ctx, &catalogv1.GetProductRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.client.GetProduct(ctx, &catalogv1.GetProductRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCatalogServer_ListProductBillers(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t, testSecret)
+
+	productID, isActive := 1, true
+	sort, err := db.ParseSort("-priority", repositories.ProductBillerSortColumns)
+	require.NoError(t, err)
+	filter := models.ProductBillerFilter{ProductID: &productID, IsActive: &isActive, ListFilter: models.ListFilter{Sort: sort}}
+	s.productBillerRepo.On("FetchManyWithPagination", mock.Anything, filter, 2, 5).Return(
+		[]*models.ProductBiller{{ID: 10, ProductID: 1, BillerID: 3, IsActive: true, Priority: 1}},
+		&db.Pagination{Page: 2, Limit: 5, TotalRows: 6, TotalPages: 2},
+		nil,
+	)
+
+	response, err := s.client.ListProductBillers(ctx, &catalogv1.ListProductBillersRequest{
+		Page:      &catalogv1.PageRequest{Page: 2, Limit: 5, Sort: "-priority"},
+		ProductId: proto.Int64(1),
+		IsActive:  proto.Bool(true),
+	})
+	require.NoError(t, err)
+	require.Len(t, response.GetProductBillers(), 1)
+	assert.Equal(t, int64(3), response.GetProductBillers()[0].GetBillerId())
+	assert.Nil(t, response.GetProductBillers()[0].GetDeactivatedAt())
+	assert.Equal(t, int32(6), response.GetPagination().GetTotalRows())
+
+	_, err = s.client.ListProductBillers(ctx, &catalogv1.ListProductBillersRequest{
+		Page: &catalogv1.PageRequest{Sort: "unknown"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCatalogServer_RouteProduct(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t, testSecret)
+
+	productID, isActive := 1, true
+	s.productRepo.On("FetchOne", mock.Anything, 1).Return(&models.Product{ID: 1}, nil)
+	s.productBillerRepo.On("FetchMany", mock.Anything, models.ProductBillerFilter{ProductID: &productID, IsActive: &isActive}).Return(
+		[]*models.ProductBiller{{ID: 10, ProductID: 1, BillerID: 3, IsActive: true, Priority: 1, Weight: 1}}, nil,
+	)
+	s.billerRepo.On("FetchMany", mock.Anything, models.BillerFilter{IDs: []int{3}}).Return([]*models.Biller{{ID: 3, Label: "Biller"}}, nil)
+
+	route, err := s.client.RouteProduct(ctx, &catalogv1.RouteProductRequest{ProductId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), route.GetProductBiller().GetId())
+	assert.Equal(t, "Biller", route.GetBiller().GetLabel())
+	assert.Empty(t, route.GetFallbacks())
+}
@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+	catalogv1 "golang-boilerplate/internal/pkg/pb/catalog/v1"
+)
+
+func productToProto(p *models.Product) *catalogv1.Product {
+	return &catalogv1.Product{
+		Id:        int64(p.ID),
+		Label:     p.Label,
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		CreatedBy: p.CreatedBy,
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+		UpdatedBy: p.UpdatedBy,
+		Version:   int64(p.Version),
+	}
+}
+
+func billerToProto(b *models.Biller) *catalogv1.Biller {
+	return &catalogv1.Biller{
+		Id:        int64(b.ID),
+		Label:     b.Label,
+		CreatedAt: timestamppb.New(b.CreatedAt),
+		CreatedBy: b.CreatedBy,
+		UpdatedAt: timestamppb.New(b.UpdatedAt),
+		UpdatedBy: b.UpdatedBy,
+		Version:   int64(b.Version),
+	}
+}
+
+func productBillerToProto(pb *models.ProductBiller) *catalogv1.ProductBiller {
+	return &catalogv1.ProductBiller{
+		Id:                 int64(pb.ID),
+		ProductId:          int64(pb.ProductID),
+		BillerId:           int64(pb.BillerID),
+		IsActive:           pb.IsActive,
+		Priority:           int32(pb.Priority),
+		Weight:             int32(pb.Weight),
+		DeactivatedAt:      optionalTimestamp(pb.DeactivatedAt),
+		DeactivatedBy:      pb.DeactivatedBy,
+		DeactivationReason: pb.DeactivationReason,
+		CreatedAt:          timestamppb.New(pb.CreatedAt),
+		CreatedBy:          pb.CreatedBy,
+		UpdatedAt:          timestamppb.New(pb.UpdatedAt),
+		UpdatedBy:          pb.UpdatedBy,
+		Version:            int64(pb.Version),
+	}
+}
+
+func routeToProto(r *models.ProductBillerRoute) *catalogv1.ProductBillerRoute {
+	route := &catalogv1.ProductBillerRoute{
+		ProductId:     int64(r.ProductID),
+		Strategy:      r.Strategy,
+		ProductBiller: productBillerToProto(r.Selected),
+		Biller:        billerToProto(r.Biller),
+	}
+	for _, fallback := range r.Fallbacks {
+		route.Fallbacks = append(route.Fallbacks, productBillerToProto(fallback))
+	}
+	return route
+}
+
+func paginationToProto(p *db.Pagination) *catalogv1.Pagination {
+	return &catalogv1.Pagination{
+		Page:       int32(p.Page),
+		Limit:      int32(p.Limit),
+		TotalRows:  int32(p.TotalRows),
+		TotalPages: int32(p.TotalPages),
+	}
+}
+
+// optionalTimestamp leaves a nil time unset rather than converting it to the epoch.
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"golang-boilerplate/internal/pkg/auth"
+	catalogv1 "golang-boilerplate/internal/pkg/pb/catalog/v1"
+)
+
+// Server is the gRPC server of the HTTP service, along with the health service reporting its status.
+type Server struct {
+	*grpc.Server
+	Health *health.Server
+}
+
+// NewServer creates a gRPC server serving catalog, authenticating calls by the JWTs signed with jwtSecret.
+// The standard health service is served without authentication, so that probes need no token.
+func NewServer(catalog *CatalogServer, jwtSecret string) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(jwtSecret, healthpb.Health_ServiceDesc.ServiceName)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(jwtSecret, healthpb.Health_ServiceDesc.ServiceName)),
+	)
+	catalogv1.RegisterCatalogServiceServer(server, catalog)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(catalogv1.CatalogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	return &Server{Server: server, Health: healthServer}
+}
+
+// GracefulStop reports every service as not serving, then stops the server once pending calls complete.
+func (s *Server) GracefulStop() {
+	s.Health.Shutdown()
+	s.Server.GracefulStop()
+}
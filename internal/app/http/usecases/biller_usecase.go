@@ -2,9 +2,13 @@ package usecases
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+
+	"golang-boilerplate/internal/pkg/catalog"
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/models"
@@ -12,72 +16,156 @@ import (
 
 // BillerUseCase defines the interface for the usecase layer of Biller entities.
 type BillerUseCase interface {
-	Create(ctx context.Context, biller *models.Biller) error
-	Update(ctx context.Context, id int, biller *models.Biller) error
-	Delete(ctx context.Context, id int) error
+	Create(ctx context.Context, biller *models.Biller) (*models.Biller, error)
+	Update(ctx context.Context, id, version int, biller *models.Biller) error
+	Patch(ctx context.Context, id, version int, patch *models.BillerPatch) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int, cascade bool) (*models.Biller, error)
 	FetchOne(ctx context.Context, id int) (*models.Biller, error)
-	FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Biller, error)
-	FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Biller, *db.Pagination, error)
+	FetchMany(ctx context.Context, filter models.BillerFilter) ([]*models.Biller, error)
+	Export(ctx context.Context, filter models.BillerFilter, fn func(biller *models.Biller) error) error
+	FetchManyWithPagination(ctx context.Context, filter models.BillerFilter, page, limit int) ([]*models.Biller, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.BillerFilter, pagination *db.CursorPagination) ([]*models.Biller, error)
 }
 
 // billerUseCase implements BillerUseCase.
 type billerUseCase struct {
-	repo repositories.BillerRepository
-	uow  repositories.UnitOfWork
+	repo   repositories.BillerRepository
+	uow    repositories.UnitOfWork
+	policy DeletionPolicy
 }
 
-// NewBillerUseCase creates a new instance of BillerUseCase.
-func NewBillerUseCase(repo repositories.BillerRepository, uow repositories.UnitOfWork) BillerUseCase {
+// NewBillerUseCase creates a new instance of BillerUseCase, which applies policy to the product billers
+// of deleted billers.
+func NewBillerUseCase(repo repositories.BillerRepository, uow repositories.UnitOfWork, policy DeletionPolicy) BillerUseCase {
 	return &billerUseCase{
-		repo: repo,
-		uow:  uow,
+		repo:   repo,
+		uow:    uow,
+		policy: policy,
 	}
 }
 
-func (uc *billerUseCase) Create(ctx context.Context, biller *models.Biller) error {
+func (uc *billerUseCase) Create(ctx context.Context, biller *models.Biller) (*models.Biller, error) {
 	if biller == nil {
-		return errors.New("biller is nil")
+		return nil, errors.New("biller is nil")
 	}
 
 	return uc.repo.Create(ctx, biller)
 }
 
-func (uc *billerUseCase) Update(ctx context.Context, id int, biller *models.Biller) error {
+// Update returns ErrVersionConflict when the biller no longer has the given version.
+func (uc *billerUseCase) Update(ctx context.Context, id, version int, biller *models.Biller) error {
 	if biller == nil {
 		return errors.New("biller is nil")
 	}
 
-	return uc.repo.Update(ctx, id, biller)
+	return catalog.VersionConflict(ctx, uc.repo.FetchOne, id, uc.repo.Update(ctx, id, version, biller))
 }
 
-func (uc *billerUseCase) Delete(ctx context.Context, id int) error {
+// Patch returns ErrVersionConflict when the biller no longer has the given version.
+func (uc *billerUseCase) Patch(ctx context.Context, id, version int, patch *models.BillerPatch) error {
+	if patch == nil {
+		return errors.New("biller patch is nil")
+	}
+
+	return catalog.VersionConflict(ctx, uc.repo.FetchOne, id, uc.repo.Patch(ctx, id, version, patch))
+}
+
+// Delete deletes the biller and applies the deletion policy to its product billers. It returns
+// ErrVersionConflict when the biller no longer has the given version, and ErrDeleteRestricted when the
+// restrict policy refuses the deletion.
+func (uc *billerUseCase) Delete(ctx context.Context, id, version int) error {
+	// Deleted product billers share the batch of the biller, so they can be restored along with it.
+	batch := uuid.NewString()
 	err := uc.uow.Execute(ctx, func(uow repositories.UnitOfWork) error {
-		if err := uow.ProductBillerRepo().DeleteByBillerID(ctx, id); err != nil {
-			return fmt.Errorf("failed to delete product billers for biller ID %d: %w", id, err)
+		// The biller goes first so a version conflict is detected before touching its product billers.
+		if err := uow.BillerRepo().Delete(ctx, id, version, batch); err != nil {
+			return fmt.Errorf("failed to delete biller with ID %d: %w", id, err)
 		}
 
-		if err := uow.BillerRepo().Delete(ctx, id); err != nil {
-			return fmt.Errorf("failed to delete biller with ID %d: %w", id, err)
+		productBillerRepo := uow.ProductBillerRepo()
+		err := applyDeletionPolicy(ctx, uc.policy, productBillerRepo, models.ProductBillerFilter{BillerID: &id},
+			func() error {
+				return productBillerRepo.DeleteByBillerID(ctx, id, batch)
+			},
+			func() error {
+				return productBillerRepo.DeactivateByBillerID(ctx, id, deletionActor, fmt.Sprintf("biller %d deleted", id))
+			},
+		)
+		if errors.Is(err, ErrDeleteRestricted) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply deletion policy to product billers for biller ID %d: %w", id, err)
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("transaction failed while deleting biller with ID %d: %w", id, err)
+		return fmt.Errorf("transaction failed while deleting biller with ID %d: %w", id, catalog.VersionConflict(ctx, uc.repo.FetchOne, id, err))
 	}
 
 	return nil
 }
 
+// Restore restores the deleted biller and, with cascade, the product billers deleted along with it,
+// except those whose product is deleted. It returns ErrNotDeleted when the biller is not deleted.
+func (uc *billerUseCase) Restore(ctx context.Context, id int, cascade bool) (*models.Biller, error) {
+	var restored *models.Biller
+	err := uc.uow.Execute(ctx, func(uow repositories.UnitOfWork) error {
+		billers, err := uow.BillerRepo().FetchMany(ctx, models.BillerFilter{ID: &id, IncludeDeleted: true})
+		if err != nil {
+			return fmt.Errorf("failed to fetch biller with ID %d: %w", id, err)
+		}
+		if len(billers) == 0 {
+			return fmt.Errorf("failed to fetch biller with ID %d: %w", id, sql.ErrNoRows)
+		}
+		if billers[0].DeletedAt == nil {
+			return ErrNotDeleted
+		}
+
+		if err := uow.BillerRepo().Restore(ctx, id); err != nil {
+			// Restored concurrently in the meantime.
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotDeleted
+			}
+			return fmt.Errorf("failed to restore biller with ID %d: %w", id, err)
+		}
+
+		if cascade && billers[0].DeletedBatch != nil {
+			if _, err := uow.ProductBillerRepo().RestoreBatch(ctx, *billers[0].DeletedBatch); err != nil {
+				return fmt.Errorf("failed to restore product billers for biller ID %d: %w", id, err)
+			}
+		}
+
+		restored, err = uow.BillerRepo().FetchOne(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
 func (uc *billerUseCase) FetchOne(ctx context.Context, id int) (*models.Biller, error) {
 	return uc.repo.FetchOne(ctx, id)
 }
 
-func (uc *billerUseCase) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Biller, error) {
+func (uc *billerUseCase) FetchMany(ctx context.Context, filter models.BillerFilter) ([]*models.Biller, error) {
 	return uc.repo.FetchMany(ctx, filter)
 }
 
-func (uc *billerUseCase) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Biller, *db.Pagination, error) {
+// Export passes the billers matching the filter to fn one at a time, so they can be streamed out.
+func (uc *billerUseCase) Export(ctx context.Context, filter models.BillerFilter, fn func(biller *models.Biller) error) error {
+	return uc.repo.FetchEach(ctx, filter, fn)
+}
+
+func (uc *billerUseCase) FetchManyWithPagination(ctx context.Context, filter models.BillerFilter, page, limit int) ([]*models.Biller, *db.Pagination, error) {
 	return uc.repo.FetchManyWithPagination(ctx, filter, page, limit)
 }
+
+func (uc *billerUseCase) FetchManyWithCursor(ctx context.Context, filter models.BillerFilter, pagination *db.CursorPagination) ([]*models.Biller, error) {
+	return uc.repo.FetchManyWithCursor(ctx, filter, pagination)
+}
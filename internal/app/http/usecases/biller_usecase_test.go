@@ -0,0 +1,71 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestBillerUseCase_Delete(t *testing.T) {
+	ctx := context.Background()
+	id := 2
+	active := true
+	activeFilter := models.ProductBillerFilter{BillerID: &id, IsActive: &active}
+
+	newUseCase := func(policy usecases.DeletionPolicy) (usecases.BillerUseCase, *mocks.MockBillerRepository, *mocks.MockProductBillerRepository) {
+		mockUow := new(mocks.MockUnitOfWork)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+
+		mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+		mockUow.On("BillerRepo").Return(mockBillerRepo)
+		mockUow.On("ProductBillerRepo").Return(mockProductBillerRepo)
+
+		return usecases.NewBillerUseCase(mockBillerRepo, mockUow, policy), mockBillerRepo, mockProductBillerRepo
+	}
+
+	t.Run("cascade", func(t *testing.T) {
+		useCase, mockBillerRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyCascade)
+		mockBillerRepo.On("Delete", ctx, 2, 1, mock.AnythingOfType("string")).Return(nil)
+		mockProductBillerRepo.On("DeleteByBillerID", ctx, 2, mock.AnythingOfType("string")).Return(nil)
+
+		require.NoError(t, useCase.Delete(ctx, 2, 1))
+		mockProductBillerRepo.AssertExpectations(t)
+	})
+
+	t.Run("restrict", func(t *testing.T) {
+		useCase, mockBillerRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyRestrict)
+		mockBillerRepo.On("Delete", ctx, 2, 1, mock.AnythingOfType("string")).Return(nil)
+		mockProductBillerRepo.On("FetchMany", ctx, activeFilter).Return([]*models.ProductBiller{{ID: 7, BillerID: 2, IsActive: true}}, nil)
+
+		err := useCase.Delete(ctx, 2, 1)
+		assert.ErrorIs(t, err, usecases.ErrDeleteRestricted)
+		mockProductBillerRepo.AssertNotCalled(t, "DeleteByBillerID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		useCase, mockBillerRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyDetach)
+		mockBillerRepo.On("Delete", ctx, 2, 1, mock.AnythingOfType("string")).Return(nil)
+		mockProductBillerRepo.On("DeactivateByBillerID", ctx, 2, "system", "biller 2 deleted").Return(nil)
+
+		require.NoError(t, useCase.Delete(ctx, 2, 1))
+		mockProductBillerRepo.AssertExpectations(t)
+		mockProductBillerRepo.AssertNotCalled(t, "DeleteByBillerID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestParseDeletionPolicy(t *testing.T) {
+	policy, err := usecases.ParseDeletionPolicy("detach")
+	require.NoError(t, err)
+	assert.Equal(t, usecases.DeletionPolicyDetach, policy)
+
+	_, err = usecases.ParseDeletionPolicy("nullify")
+	assert.Error(t, err)
+}
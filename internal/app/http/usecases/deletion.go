@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// DeletionPolicy decides what happens to the product billers of a product or biller being deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyCascade deletes the product billers along with the product or biller.
+	DeletionPolicyCascade DeletionPolicy = "cascade"
+	// DeletionPolicyRestrict refuses the deletion while active product billers exist.
+	DeletionPolicyRestrict DeletionPolicy = "restrict"
+	// DeletionPolicyDetach keeps the product billers but deactivates them.
+	DeletionPolicyDetach DeletionPolicy = "detach"
+)
+
+// deletionActor is recorded as the deactivator of product billers detached by a deletion.
+const deletionActor = "system"
+
+// ErrDeleteRestricted is returned when the restrict policy refuses a deletion.
+var ErrDeleteRestricted = errors.New("entity still has active product billers")
+
+// ParseDeletionPolicy returns the DeletionPolicy with the given name.
+func ParseDeletionPolicy(name string) (DeletionPolicy, error) {
+	switch policy := DeletionPolicy(name); policy {
+	case DeletionPolicyCascade, DeletionPolicyRestrict, DeletionPolicyDetach:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown deletion policy: %q", name)
+	}
+}
+
+// applyDeletionPolicy applies policy to the product billers selected by filter, which deleteAll deletes and
+// deactivateAll deactivates. It runs inside the unit of work of the deletion, after the product or biller itself
+// is deleted, so a version conflict is reported first and a refusal rolls the deletion back.
+func applyDeletionPolicy(ctx context.Context, policy DeletionPolicy, repo repositories.ProductBillerRepository,
+	filter models.ProductBillerFilter, deleteAll, deactivateAll func() error) error {
+	switch policy {
+	case DeletionPolicyRestrict:
+		active := true
+		filter.IsActive = &active
+		productBillers, err := repo.FetchMany(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if len(productBillers) > 0 {
+			return ErrDeleteRestricted
+		}
+		// Inactive product billers do not hold the deletion back and go along with it.
+		return deleteAll()
+	case DeletionPolicyDetach:
+		return deactivateAll()
+	default:
+		return deleteAll()
+	}
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ErrJobFinished is returned when canceling a job that already reached a final status.
+var ErrJobFinished = errors.New("job already finished")
+
+// JobUseCase defines the interface for queuing and following background jobs run by the worker.
+type JobUseCase interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}, createdBy string) (*models.Job, error)
+	FetchOne(ctx context.Context, id int) (*models.Job, error)
+	Cancel(ctx context.Context, id int) (*models.Job, error)
+}
+
+// jobUseCase implements JobUseCase.
+type jobUseCase struct {
+	repo repositories.JobRepository
+}
+
+// NewJobUseCase creates a new instance of JobUseCase.
+func NewJobUseCase(repo repositories.JobRepository) JobUseCase {
+	return &jobUseCase{
+		repo: repo,
+	}
+}
+
+// Enqueue queues a job of the given type, whose payload is stored as JSON for the worker to pick up.
+func (uc *jobUseCase) Enqueue(ctx context.Context, jobType string, payload interface{}, createdBy string) (*models.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	return uc.repo.Create(ctx, &models.Job{
+		Type:      jobType,
+		Payload:   data,
+		CreatedBy: createdBy,
+	})
+}
+
+func (uc *jobUseCase) FetchOne(ctx context.Context, id int) (*models.Job, error) {
+	return uc.repo.FetchOne(ctx, id)
+}
+
+// Cancel cancels a pending job, or asks the worker to stop a running one, and returns the job as it now
+// stands. It returns ErrJobFinished when the job already reached a final status.
+func (uc *jobUseCase) Cancel(ctx context.Context, id int) (*models.Job, error) {
+	cancelErr := uc.repo.Cancel(ctx, id)
+	if cancelErr != nil && !errors.Is(cancelErr, sql.ErrNoRows) {
+		return nil, cancelErr
+	}
+
+	job, err := uc.repo.FetchOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing was updated either because the job is over, or because it is already being canceled.
+	if cancelErr != nil && job.Finished() {
+		return nil, ErrJobFinished
+	}
+
+	return job, nil
+}
@@ -0,0 +1,56 @@
+package usecases_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestJobUseCase_Cancel(t *testing.T) {
+	ctx := context.Background()
+	notCanceled := fmt.Errorf("failed to cancel job: %w", sql.ErrNoRows)
+
+	t.Run("running job", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		repo.On("Cancel", ctx, 1).Return(nil)
+		repo.On("FetchOne", ctx, 1).Return(&models.Job{ID: 1, Status: models.JobStatusRunning, CancelRequested: true}, nil)
+
+		job, err := usecases.NewJobUseCase(repo).Cancel(ctx, 1)
+		assert.NoError(t, err)
+		assert.True(t, job.CancelRequested)
+	})
+
+	t.Run("already being canceled", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		repo.On("Cancel", ctx, 1).Return(notCanceled)
+		repo.On("FetchOne", ctx, 1).Return(&models.Job{ID: 1, Status: models.JobStatusRunning, CancelRequested: true}, nil)
+
+		_, err := usecases.NewJobUseCase(repo).Cancel(ctx, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("finished job", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		repo.On("Cancel", ctx, 1).Return(notCanceled)
+		repo.On("FetchOne", ctx, 1).Return(&models.Job{ID: 1, Status: models.JobStatusSucceeded}, nil)
+
+		_, err := usecases.NewJobUseCase(repo).Cancel(ctx, 1)
+		assert.ErrorIs(t, err, usecases.ErrJobFinished)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		repo.On("Cancel", ctx, 1).Return(notCanceled)
+		repo.On("FetchOne", ctx, 1).Return(nil, sql.ErrNoRows)
+
+		_, err := usecases.NewJobUseCase(repo).Cancel(ctx, 1)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ErrNotificationNotFailed is returned when resending a notification that has not failed.
+var ErrNotificationNotFailed = errors.New("only failed notifications can be resent")
+
+// NotificationUseCase defines the interface for the usecase layer of queued notifications.
+type NotificationUseCase interface {
+	FetchOne(ctx context.Context, id int) (*models.QueuedNotification, error)
+	FetchManyWithPagination(ctx context.Context, filter models.NotificationFilter, page, limit int) ([]*models.QueuedNotification, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.NotificationFilter, pagination *db.CursorPagination) ([]*models.QueuedNotification, error)
+	Resend(ctx context.Context, id int) error
+}
+
+// notificationUseCase implements NotificationUseCase.
+type notificationUseCase struct {
+	repo repositories.NotificationRepository
+}
+
+// NewNotificationUseCase creates a new instance of NotificationUseCase.
+func NewNotificationUseCase(repo repositories.NotificationRepository) NotificationUseCase {
+	return &notificationUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *notificationUseCase) FetchOne(ctx context.Context, id int) (*models.QueuedNotification, error) {
+	return uc.repo.FetchOne(ctx, id)
+}
+
+func (uc *notificationUseCase) FetchManyWithPagination(ctx context.Context, filter models.NotificationFilter, page, limit int) ([]*models.QueuedNotification, *db.Pagination, error) {
+	return uc.repo.FetchManyWithPagination(ctx, filter, page, limit)
+}
+
+func (uc *notificationUseCase) FetchManyWithCursor(ctx context.Context, filter models.NotificationFilter, pagination *db.CursorPagination) ([]*models.QueuedNotification, error) {
+	return uc.repo.FetchManyWithCursor(ctx, filter, pagination)
+}
+
+// Resend queues a failed notification for delivery again.
+func (uc *notificationUseCase) Resend(ctx context.Context, id int) error {
+	notification, err := uc.repo.FetchOne(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch notification with ID %d: %w", id, err)
+	}
+	if notification.Status != models.NotificationStatusFailed {
+		return ErrNotificationNotFailed
+	}
+
+	return uc.repo.Resend(ctx, id)
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ProductBillerStatUseCase defines the interface for the usecase layer of ProductBiller transaction statistics.
+type ProductBillerStatUseCase interface {
+	FetchMany(ctx context.Context, productBillerID int, bucket string, from, to time.Time) ([]*models.ProductBillerStat, error)
+}
+
+// productBillerStatUseCase implements ProductBillerStatUseCase.
+type productBillerStatUseCase struct {
+	repo   repositories.ProductBillerStatRepository
+	pbRepo repositories.ProductBillerRepository
+}
+
+// NewProductBillerStatUseCase creates a new instance of ProductBillerStatUseCase.
+func NewProductBillerStatUseCase(repo repositories.ProductBillerStatRepository, pbRepo repositories.ProductBillerRepository) ProductBillerStatUseCase {
+	return &productBillerStatUseCase{
+		repo:   repo,
+		pbRepo: pbRepo,
+	}
+}
+
+func (uc *productBillerStatUseCase) FetchMany(ctx context.Context, productBillerID int, bucket string, from, to time.Time) ([]*models.ProductBillerStat, error) {
+	if !models.IsValidStatBucket(bucket) {
+		return nil, fmt.Errorf("invalid bucket %q", bucket)
+	}
+	if !from.Before(to) {
+		return nil, errors.New("from must be before to")
+	}
+
+	if _, err := uc.pbRepo.FetchOne(ctx, productBillerID); err != nil {
+		return nil, fmt.Errorf("failed to fetch product biller with ID %d: %w", productBillerID, err)
+	}
+
+	return uc.repo.FetchMany(ctx, productBillerID, bucket, from, to)
+}
@@ -2,9 +2,13 @@ package usecases
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+
+	"golang-boilerplate/internal/pkg/catalog"
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/models"
@@ -12,12 +16,20 @@ import (
 
 // ProductBillerUseCase defines the interface for the usecase layer of ProductBiller entities.
 type ProductBillerUseCase interface {
-	Create(ctx context.Context, productBiller *models.ProductBiller) error
-	Update(ctx context.Context, id int, productBiller *models.ProductBiller) error
-	Delete(ctx context.Context, id int) error
+	Create(ctx context.Context, productBiller *models.ProductBiller) (*models.ProductBiller, error)
+	Update(ctx context.Context, id, version int, productBiller *models.ProductBiller) error
+	Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) (*models.ProductBiller, error)
 	FetchOne(ctx context.Context, id int) (*models.ProductBiller, error)
-	FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.ProductBiller, error)
-	FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.ProductBiller, *db.Pagination, error)
+	FetchMany(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBiller, error)
+	FetchManyWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBiller, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBiller, error)
+	FetchManyDetailed(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBillerDetail, error)
+	FetchManyDetailedWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBillerDetail, *db.Pagination, error)
+	FetchManyDetailedWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBillerDetail, error)
+	Import(ctx context.Context, rows []*models.ProductBillerImportRow) (*models.ProductBillerImportReport, error)
+	Export(ctx context.Context, filter models.ProductBillerFilter, fn func(productBiller *models.ProductBiller) error) error
 }
 
 // productBillerUseCase implements ProductBillerUseCase.
@@ -25,6 +37,8 @@ type productBillerUseCase struct {
 	repo        repositories.ProductBillerRepository
 	productRepo repositories.ProductRepository
 	billerRepo  repositories.BillerRepository
+	uow         repositories.UnitOfWork
+	shared      catalog.ProductBillers
 }
 
 // NewProductBillerUseCase creates a new instance of ProductBillerUseCase.
@@ -32,56 +46,149 @@ func NewProductBillerUseCase(
 	repo repositories.ProductBillerRepository,
 	productRepo repositories.ProductRepository,
 	billerRepo repositories.BillerRepository,
+	uow repositories.UnitOfWork,
 ) ProductBillerUseCase {
 	return &productBillerUseCase{
 		repo:        repo,
 		productRepo: productRepo,
 		billerRepo:  billerRepo,
+		uow:         uow,
+		shared:      catalog.NewProductBillers(repo, uow),
 	}
 }
 
-func (uc *productBillerUseCase) Create(ctx context.Context, productBiller *models.ProductBiller) error {
+func (uc *productBillerUseCase) Create(ctx context.Context, productBiller *models.ProductBiller) (*models.ProductBiller, error) {
 	if productBiller == nil {
-		return errors.New("product biller is nil")
+		return nil, errors.New("product biller is nil")
 	}
 
 	_, err := uc.productRepo.FetchOne(ctx, productBiller.ProductID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch product with ID %d: %w", productBiller.ProductID, err)
+		return nil, fmt.Errorf("failed to fetch product with ID %d: %w", productBiller.ProductID, err)
 	}
 
 	_, err = uc.billerRepo.FetchOne(ctx, productBiller.BillerID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch biller with ID %d: %w", productBiller.BillerID, err)
+		return nil, fmt.Errorf("failed to fetch biller with ID %d: %w", productBiller.BillerID, err)
 	}
 
-	if err := uc.repo.Create(ctx, productBiller); err != nil {
-		return fmt.Errorf("failed to create product biller: %w", err)
+	created, err := uc.repo.Create(ctx, productBiller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product biller: %w", err)
 	}
 
-	return nil
+	return created, nil
 }
 
-func (uc *productBillerUseCase) Update(ctx context.Context, id int, productBiller *models.ProductBiller) error {
+// Update returns ErrVersionConflict when the product biller no longer has the given version.
+func (uc *productBillerUseCase) Update(ctx context.Context, id, version int, productBiller *models.ProductBiller) error {
 	if productBiller == nil {
 		return errors.New("product biller is nil")
 	}
 
-	return uc.repo.Update(ctx, id, productBiller)
+	return catalog.VersionConflict(ctx, uc.repo.FetchOne, id, uc.repo.Update(ctx, id, version, productBiller))
+}
+
+// Patch returns ErrVersionConflict when the product biller no longer has the given version.
+func (uc *productBillerUseCase) Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error {
+	return uc.shared.Patch(ctx, id, version, patch)
 }
 
-func (uc *productBillerUseCase) Delete(ctx context.Context, id int) error {
-	return uc.repo.Delete(ctx, id)
+// Delete returns ErrVersionConflict when the product biller no longer has the given version.
+func (uc *productBillerUseCase) Delete(ctx context.Context, id, version int) error {
+	return catalog.VersionConflict(ctx, uc.repo.FetchOne, id, uc.repo.Delete(ctx, id, version, uuid.NewString()))
+}
+
+// Restore restores the deleted product biller. It returns ErrNotDeleted when the product biller is not
+// deleted, ErrParentDeleted when its product or biller is deleted, and ErrDuplicateEntry when another
+// product biller of the same product and biller was created since.
+func (uc *productBillerUseCase) Restore(ctx context.Context, id int) (*models.ProductBiller, error) {
+	productBillers, err := uc.repo.FetchMany(ctx, models.ProductBillerFilter{ID: &id, IncludeDeleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product biller with ID %d: %w", id, err)
+	}
+	if len(productBillers) == 0 {
+		return nil, fmt.Errorf("failed to fetch product biller with ID %d: %w", id, sql.ErrNoRows)
+	}
+	pb := productBillers[0]
+	if pb.DeletedAt == nil {
+		return nil, ErrNotDeleted
+	}
+
+	if err := uc.checkParents(ctx, pb); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.FetchMany(ctx, models.ProductBillerFilter{ProductID: &pb.ProductID, BillerID: &pb.BillerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product billers: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%w: product biller %d has the same product and biller", repositories.ErrDuplicateEntry, existing[0].ID)
+	}
+
+	if err := uc.repo.Restore(ctx, id); err != nil {
+		// Restored concurrently in the meantime.
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotDeleted
+		}
+		return nil, err
+	}
+
+	return uc.repo.FetchOne(ctx, id)
+}
+
+// checkParents returns ErrParentDeleted when the product or biller of pb is deleted.
+func (uc *productBillerUseCase) checkParents(ctx context.Context, pb *models.ProductBiller) error {
+	if _, err := uc.productRepo.FetchOne(ctx, pb.ProductID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: product %d", ErrParentDeleted, pb.ProductID)
+		}
+		return err
+	}
+	if _, err := uc.billerRepo.FetchOne(ctx, pb.BillerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: biller %d", ErrParentDeleted, pb.BillerID)
+		}
+		return err
+	}
+	return nil
 }
 
 func (uc *productBillerUseCase) FetchOne(ctx context.Context, id int) (*models.ProductBiller, error) {
 	return uc.repo.FetchOne(ctx, id)
 }
 
-func (uc *productBillerUseCase) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.ProductBiller, error) {
+func (uc *productBillerUseCase) FetchMany(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBiller, error) {
 	return uc.repo.FetchMany(ctx, filter)
 }
 
-func (uc *productBillerUseCase) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.ProductBiller, *db.Pagination, error) {
+func (uc *productBillerUseCase) FetchManyWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBiller, *db.Pagination, error) {
 	return uc.repo.FetchManyWithPagination(ctx, filter, page, limit)
 }
+
+func (uc *productBillerUseCase) FetchManyWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBiller, error) {
+	return uc.repo.FetchManyWithCursor(ctx, filter, pagination)
+}
+
+func (uc *productBillerUseCase) FetchManyDetailed(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBillerDetail, error) {
+	return uc.repo.FetchManyDetailed(ctx, filter)
+}
+
+func (uc *productBillerUseCase) FetchManyDetailedWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBillerDetail, *db.Pagination, error) {
+	return uc.repo.FetchManyDetailedWithPagination(ctx, filter, page, limit)
+}
+
+func (uc *productBillerUseCase) FetchManyDetailedWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBillerDetail, error) {
+	return uc.repo.FetchManyDetailedWithCursor(ctx, filter, pagination)
+}
+
+// Import creates the product billers of the rows, reporting every row as created, duplicate or invalid.
+func (uc *productBillerUseCase) Import(ctx context.Context, rows []*models.ProductBillerImportRow) (*models.ProductBillerImportReport, error) {
+	return uc.shared.Import(ctx, rows)
+}
+
+// Export passes the product billers matching the filter to fn one at a time, so they can be streamed out.
+func (uc *productBillerUseCase) Export(ctx context.Context, filter models.ProductBillerFilter, fn func(productBiller *models.ProductBiller) error) error {
+	return uc.repo.FetchEach(ctx, filter, fn)
+}
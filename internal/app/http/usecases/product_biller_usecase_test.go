@@ -2,14 +2,18 @@ package usecases_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"golang-boilerplate/internal/app/http/usecases"
 	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
 	"golang-boilerplate/internal/pkg/models"
 )
@@ -38,7 +42,7 @@ func TestProductBillerUseCase_Create(t *testing.T) {
 			setupMocks: func(productRepo *mocks.MockProductRepository, billerRepo *mocks.MockBillerRepository, repo *mocks.MockProductBillerRepository) {
 				productRepo.On("FetchOne", mock.Anything, 1).Return(&models.Product{}, nil)
 				billerRepo.On("FetchOne", mock.Anything, 1).Return(&models.Biller{}, nil)
-				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(&models.ProductBiller{ID: 7, ProductID: 1, BillerID: 1}, nil)
 			},
 			expectErr:   false,
 			expectedErr: nil,
@@ -86,7 +90,7 @@ func TestProductBillerUseCase_Create(t *testing.T) {
 			setupMocks: func(productRepo *mocks.MockProductRepository, billerRepo *mocks.MockBillerRepository, repo *mocks.MockProductBillerRepository) {
 				productRepo.On("FetchOne", mock.Anything, 1).Return(&models.Product{}, nil)
 				billerRepo.On("FetchOne", mock.Anything, 1).Return(&models.Biller{}, nil)
-				repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("create failed"))
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("create failed"))
 			},
 			expectErr:   true,
 			expectedErr: errors.New("failed to create product biller: create failed"),
@@ -99,18 +103,20 @@ func TestProductBillerUseCase_Create(t *testing.T) {
 			billerRepo := new(mocks.MockBillerRepository)
 			repo := new(mocks.MockProductBillerRepository)
 
-			uc := usecases.NewProductBillerUseCase(repo, productRepo, billerRepo)
+			uc := usecases.NewProductBillerUseCase(repo, productRepo, billerRepo, nil)
 
 			if tt.setupMocks != nil {
 				tt.setupMocks(productRepo, billerRepo, repo)
 			}
 
-			err := uc.Create(tt.args.ctx, tt.args.productBiller)
+			created, err := uc.Create(tt.args.ctx, tt.args.productBiller)
 			if tt.expectErr {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, created)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, 7, created.ID)
 			}
 
 			productRepo.AssertExpectations(t)
@@ -123,72 +129,136 @@ func TestProductBillerUseCase_Create(t *testing.T) {
 func TestProductBillerUseCase_Update(t *testing.T) {
 	ctx := context.Background()
 
-	id := 1
+	id, version := 1, 3
 	updatedProductBiller := &models.ProductBiller{IsActive: true}
 
 	t.Run("success", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
-		mockProductBillerRepo.On("Update", ctx, id, updatedProductBiller).Return(nil)
+		mockProductBillerRepo.On("Update", ctx, id, version, updatedProductBiller).Return(nil)
 
-		err := useCase.Update(ctx, id, updatedProductBiller)
+		err := useCase.Update(ctx, id, version, updatedProductBiller)
 		assert.NoError(t, err)
 
-		mockProductBillerRepo.AssertCalled(t, "Update", ctx, id, updatedProductBiller)
+		mockProductBillerRepo.AssertCalled(t, "Update", ctx, id, version, updatedProductBiller)
 	})
 
 	t.Run("error", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
-		mockProductBillerRepo.On("Update", ctx, id, updatedProductBiller).Return(errors.New("update failed"))
+		mockProductBillerRepo.On("Update", ctx, id, version, updatedProductBiller).Return(errors.New("update failed"))
 
-		err := useCase.Update(ctx, id, updatedProductBiller)
+		err := useCase.Update(ctx, id, version, updatedProductBiller)
 		assert.Error(t, err)
 
-		mockProductBillerRepo.AssertCalled(t, "Update", ctx, id, updatedProductBiller)
+		mockProductBillerRepo.AssertCalled(t, "Update", ctx, id, version, updatedProductBiller)
 	})
 
 	t.Run("nil product biller", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
-		err := useCase.Update(ctx, id, nil)
+		err := useCase.Update(ctx, id, version, nil)
 		assert.Error(t, err)
 		assert.Equal(t, "product biller is nil", err.Error())
 
-		mockProductBillerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		mockProductBillerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
+
+		mockProductBillerRepo.On("Update", ctx, id, version, updatedProductBiller).Return(fmt.Errorf("stale: %w", sql.ErrNoRows))
+		mockProductBillerRepo.On("FetchOne", ctx, id).Return(&models.ProductBiller{ID: id, Version: version + 1}, nil)
+
+		err := useCase.Update(ctx, id, version, updatedProductBiller)
+		assert.ErrorIs(t, err, usecases.ErrVersionConflict)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
+
+		mockProductBillerRepo.On("Update", ctx, id, version, updatedProductBiller).Return(fmt.Errorf("stale: %w", sql.ErrNoRows))
+		mockProductBillerRepo.On("FetchOne", ctx, id).Return(nil, sql.ErrNoRows)
+
+		err := useCase.Update(ctx, id, version, updatedProductBiller)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NotErrorIs(t, err, usecases.ErrVersionConflict)
+	})
+}
+
+func TestProductBillerUseCase_Patch(t *testing.T) {
+	ctx := context.Background()
+
+	id, version := 1, 3
+	weight := 5
+	patch := &models.ProductBillerPatch{Weight: &weight, UpdatedBy: "user1"}
+
+	t.Run("success", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
+
+		mockProductBillerRepo.On("Patch", ctx, id, version, patch).Return(nil)
+
+		err := useCase.Patch(ctx, id, version, patch)
+		assert.NoError(t, err)
+
+		mockProductBillerRepo.AssertCalled(t, "Patch", ctx, id, version, patch)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
+
+		mockProductBillerRepo.On("Patch", ctx, id, version, patch).Return(fmt.Errorf("stale: %w", sql.ErrNoRows))
+		mockProductBillerRepo.On("FetchOne", ctx, id).Return(&models.ProductBiller{ID: id, Version: version + 1}, nil)
+
+		err := useCase.Patch(ctx, id, version, patch)
+		assert.ErrorIs(t, err, usecases.ErrVersionConflict)
+	})
+
+	t.Run("nil patch", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
+
+		err := useCase.Patch(ctx, id, version, nil)
+		assert.EqualError(t, err, "product biller patch is nil")
+
+		mockProductBillerRepo.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
 
 func TestProductBillerUseCase_Delete(t *testing.T) {
 	ctx := context.Background()
 
-	id := 1
+	id, version := 1, 3
 
 	t.Run("success", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
-		mockProductBillerRepo.On("Delete", ctx, id).Return(nil)
+		mockProductBillerRepo.On("Delete", ctx, id, version, mock.AnythingOfType("string")).Return(nil)
 
-		err := useCase.Delete(ctx, id)
+		err := useCase.Delete(ctx, id, version)
 		assert.NoError(t, err)
 
-		mockProductBillerRepo.AssertCalled(t, "Delete", ctx, id)
+		mockProductBillerRepo.AssertCalled(t, "Delete", ctx, id, version, mock.AnythingOfType("string"))
 	})
 
 	t.Run("error", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
-		mockProductBillerRepo.On("Delete", ctx, id).Return(errors.New("delete failed"))
+		mockProductBillerRepo.On("Delete", ctx, id, version, mock.AnythingOfType("string")).Return(errors.New("delete failed"))
 
-		err := useCase.Delete(ctx, id)
+		err := useCase.Delete(ctx, id, version)
 		assert.Error(t, err)
 
-		mockProductBillerRepo.AssertCalled(t, "Delete", ctx, id)
+		mockProductBillerRepo.AssertCalled(t, "Delete", ctx, id, version, mock.AnythingOfType("string"))
 	})
 }
 
@@ -200,7 +270,7 @@ func TestProductBillerUseCase_FetchOne(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
 		mockProductBillerRepo.On("FetchOne", ctx, id).Return(expected, nil)
 
@@ -213,7 +283,7 @@ func TestProductBillerUseCase_FetchOne(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
 		mockProductBillerRepo.On("FetchOne", ctx, id).Return(nil, errors.New("not found"))
 
@@ -228,7 +298,8 @@ func TestProductBillerUseCase_FetchOne(t *testing.T) {
 func TestProductBillerUseCase_FetchMany(t *testing.T) {
 	ctx := context.Background()
 
-	filter := map[string]interface{}{"label": "Test"}
+	productID := 1
+	filter := models.ProductBillerFilter{ProductID: &productID}
 	expected := []*models.ProductBiller{
 		{ID: 1, ProductID: 1, BillerID: 1, IsActive: true},
 		{ID: 2, ProductID: 2, BillerID: 2, IsActive: false},
@@ -236,7 +307,7 @@ func TestProductBillerUseCase_FetchMany(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
 		mockProductBillerRepo.On("FetchMany", ctx, filter).Return(expected, nil)
 
@@ -249,7 +320,7 @@ func TestProductBillerUseCase_FetchMany(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
 		mockProductBillerRepo.On("FetchMany", ctx, filter).Return(nil, errors.New("fetch failed"))
 
@@ -264,7 +335,8 @@ func TestProductBillerUseCase_FetchMany(t *testing.T) {
 func TestProductBillerUseCase_FetchManyWithPagination(t *testing.T) {
 	ctx := context.Background()
 
-	filter := map[string]interface{}{"label": "Test"}
+	productID := 1
+	filter := models.ProductBillerFilter{ProductID: &productID}
 	page := 1
 	limit := 10
 	expectedData := []*models.ProductBiller{
@@ -278,7 +350,7 @@ func TestProductBillerUseCase_FetchManyWithPagination(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
 		mockProductBillerRepo.On("FetchManyWithPagination", ctx, filter, page, limit).Return(expectedData, expectedPagination, nil)
 
@@ -292,7 +364,7 @@ func TestProductBillerUseCase_FetchManyWithPagination(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
-		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, nil, nil, nil)
 
 		mockProductBillerRepo.On("FetchManyWithPagination", ctx, filter, page, limit).Return(nil, nil, errors.New("fetch failed"))
 
@@ -304,3 +376,114 @@ func TestProductBillerUseCase_FetchManyWithPagination(t *testing.T) {
 		mockProductBillerRepo.AssertCalled(t, "FetchManyWithPagination", ctx, filter, page, limit)
 	})
 }
+
+func TestProductBillerUseCase_Import(t *testing.T) {
+	ctx := context.Background()
+	isActive := true
+	row := func(n, productID, billerID int) *models.ProductBillerImportRow {
+		return &models.ProductBillerImportRow{
+			Row:     n,
+			Request: models.CreateProductBillerRequest{ProductID: productID, BillerID: billerID, IsActive: &isActive},
+		}
+	}
+
+	mockUow := new(mocks.MockUnitOfWork)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockBillerRepo := new(mocks.MockBillerRepository)
+	mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+	useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, mockUow)
+
+	mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+	mockUow.On("ProductRepo").Return(mockProductRepo)
+	mockUow.On("BillerRepo").Return(mockBillerRepo)
+	mockUow.On("ProductBillerRepo").Return(mockProductBillerRepo)
+
+	mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+	mockProductRepo.On("FetchOne", ctx, 9).Return(nil, fmt.Errorf("failed to fetch product: %w", sql.ErrNoRows))
+	mockBillerRepo.On("FetchOne", ctx, mock.Anything).Return(&models.Biller{}, nil)
+
+	productID, billerID, existingBillerID := 1, 2, 3
+	mockProductBillerRepo.On("FetchMany", ctx, models.ProductBillerFilter{ProductID: &productID, BillerID: &billerID}).Return([]*models.ProductBiller{}, nil)
+	mockProductBillerRepo.On("FetchMany", ctx, models.ProductBillerFilter{ProductID: &productID, BillerID: &existingBillerID}).Return([]*models.ProductBiller{{ID: 4}}, nil)
+	// Rows without a weight take the default one.
+	mockProductBillerRepo.On("Create", ctx, &models.ProductBiller{ProductID: 1, BillerID: 2, IsActive: true, Weight: models.DefaultProductBillerWeight}).Return(&models.ProductBiller{ID: 7}, nil).Once()
+
+	report, err := useCase.Import(ctx, []*models.ProductBillerImportRow{
+		row(1, 1, 2),
+		row(2, 1, 2),
+		row(3, 1, 3),
+		row(4, 9, 2),
+		{Row: 5, Error: "is_active is required"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Duplicate)
+	assert.Equal(t, 2, report.Invalid)
+	assert.Equal(t, []models.ProductBillerImportResult{
+		{Row: 1, Status: models.ProductBillerImportCreated, ID: 7},
+		{Row: 2, Status: models.ProductBillerImportDuplicate, Error: "product 1 is already mapped to biller 2"},
+		{Row: 3, Status: models.ProductBillerImportDuplicate, Error: "product 1 is already mapped to biller 3"},
+		{Row: 4, Status: models.ProductBillerImportInvalid, Error: "product 9 does not exist"},
+		{Row: 5, Status: models.ProductBillerImportInvalid, Error: "is_active is required"},
+	}, report.Rows)
+	mockProductBillerRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestProductBillerUseCase_Restore(t *testing.T) {
+	ctx := context.Background()
+	id, productID, billerID := 5, 1, 2
+	deletedAt := time.Now()
+	deleted := &models.ProductBiller{ID: 5, ProductID: 1, BillerID: 2, DeletedAt: &deletedAt}
+	filter := models.ProductBillerFilter{ID: &id, IncludeDeleted: true}
+	siblings := models.ProductBillerFilter{ProductID: &productID, BillerID: &billerID}
+
+	t.Run("success", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, nil)
+
+		mockProductBillerRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{deleted}, nil)
+		mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+		mockBillerRepo.On("FetchOne", ctx, 2).Return(&models.Biller{ID: 2}, nil)
+		mockProductBillerRepo.On("FetchMany", ctx, siblings).Return([]*models.ProductBiller{}, nil)
+		mockProductBillerRepo.On("Restore", ctx, 5).Return(nil)
+		mockProductBillerRepo.On("FetchOne", ctx, 5).Return(&models.ProductBiller{ID: 5}, nil)
+
+		restored, err := useCase.Restore(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, restored.ID)
+	})
+
+	t.Run("biller deleted", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, nil)
+
+		mockProductBillerRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{deleted}, nil)
+		mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+		mockBillerRepo.On("FetchOne", ctx, 2).Return(nil, fmt.Errorf("failed to fetch biller: %w", sql.ErrNoRows))
+
+		_, err := useCase.Restore(ctx, 5)
+		assert.ErrorIs(t, err, usecases.ErrParentDeleted)
+		mockProductBillerRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("replaced since", func(t *testing.T) {
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockBillerRepo := new(mocks.MockBillerRepository)
+		useCase := usecases.NewProductBillerUseCase(mockProductBillerRepo, mockProductRepo, mockBillerRepo, nil)
+
+		mockProductBillerRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{deleted}, nil)
+		mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+		mockBillerRepo.On("FetchOne", ctx, 2).Return(&models.Biller{ID: 2}, nil)
+		mockProductBillerRepo.On("FetchMany", ctx, siblings).Return([]*models.ProductBiller{{ID: 9}}, nil)
+
+		_, err := useCase.Restore(ctx, 5)
+		assert.ErrorIs(t, err, repositories.ErrDuplicateEntry)
+		mockProductBillerRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
@@ -2,9 +2,13 @@ package usecases
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+
+	"golang-boilerplate/internal/pkg/catalog"
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/models"
@@ -12,72 +16,156 @@ import (
 
 // ProductUseCase defines the interface for the usecase layer of Product entities.
 type ProductUseCase interface {
-	Create(ctx context.Context, product *models.Product) error
-	Update(ctx context.Context, id int, product *models.Product) error
-	Delete(ctx context.Context, id int) error
+	Create(ctx context.Context, product *models.Product) (*models.Product, error)
+	Update(ctx context.Context, id, version int, product *models.Product) error
+	Patch(ctx context.Context, id, version int, patch *models.ProductPatch) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int, cascade bool) (*models.Product, error)
 	FetchOne(ctx context.Context, id int) (*models.Product, error)
-	FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Product, error)
-	FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Product, *db.Pagination, error)
+	FetchMany(ctx context.Context, filter models.ProductFilter) ([]*models.Product, error)
+	Export(ctx context.Context, filter models.ProductFilter, fn func(product *models.Product) error) error
+	FetchManyWithPagination(ctx context.Context, filter models.ProductFilter, page, limit int) ([]*models.Product, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.ProductFilter, pagination *db.CursorPagination) ([]*models.Product, error)
 }
 
 // productUseCase implements ProductUseCase.
 type productUseCase struct {
-	repo repositories.ProductRepository
-	uow  repositories.UnitOfWork
+	repo   repositories.ProductRepository
+	uow    repositories.UnitOfWork
+	policy DeletionPolicy
 }
 
-// NewProductUseCase creates a new instance of ProductUseCase.
-func NewProductUseCase(repo repositories.ProductRepository, uow repositories.UnitOfWork) ProductUseCase {
+// NewProductUseCase creates a new instance of ProductUseCase, which applies policy to the product billers
+// of deleted products.
+func NewProductUseCase(repo repositories.ProductRepository, uow repositories.UnitOfWork, policy DeletionPolicy) ProductUseCase {
 	return &productUseCase{
-		repo: repo,
-		uow:  uow,
+		repo:   repo,
+		uow:    uow,
+		policy: policy,
 	}
 }
 
-func (uc *productUseCase) Create(ctx context.Context, product *models.Product) error {
+func (uc *productUseCase) Create(ctx context.Context, product *models.Product) (*models.Product, error) {
 	if product == nil {
-		return errors.New("product is nil")
+		return nil, errors.New("product is nil")
 	}
 
 	return uc.repo.Create(ctx, product)
 }
 
-func (uc *productUseCase) Update(ctx context.Context, id int, product *models.Product) error {
+// Update returns ErrVersionConflict when the product no longer has the given version.
+func (uc *productUseCase) Update(ctx context.Context, id, version int, product *models.Product) error {
 	if product == nil {
 		return errors.New("product is nil")
 	}
 
-	return uc.repo.Update(ctx, id, product)
+	return catalog.VersionConflict(ctx, uc.repo.FetchOne, id, uc.repo.Update(ctx, id, version, product))
 }
 
-func (uc *productUseCase) Delete(ctx context.Context, id int) error {
+// Patch returns ErrVersionConflict when the product no longer has the given version.
+func (uc *productUseCase) Patch(ctx context.Context, id, version int, patch *models.ProductPatch) error {
+	if patch == nil {
+		return errors.New("product patch is nil")
+	}
+
+	return catalog.VersionConflict(ctx, uc.repo.FetchOne, id, uc.repo.Patch(ctx, id, version, patch))
+}
+
+// Delete deletes the product and applies the deletion policy to its product billers. It returns
+// ErrVersionConflict when the product no longer has the given version, and ErrDeleteRestricted when the
+// restrict policy refuses the deletion.
+func (uc *productUseCase) Delete(ctx context.Context, id, version int) error {
+	// Deleted product billers share the batch of the product, so they can be restored along with it.
+	batch := uuid.NewString()
 	err := uc.uow.Execute(ctx, func(uow repositories.UnitOfWork) error {
-		if err := uow.ProductBillerRepo().DeleteByProductID(ctx, id); err != nil {
-			return fmt.Errorf("failed to delete product billers for product ID %d: %w", id, err)
+		// The product goes first so a version conflict is detected before touching its product billers.
+		if err := uow.ProductRepo().Delete(ctx, id, version, batch); err != nil {
+			return fmt.Errorf("failed to delete product with ID %d: %w", id, err)
 		}
 
-		if err := uow.ProductRepo().Delete(ctx, id); err != nil {
-			return fmt.Errorf("failed to delete product with ID %d: %w", id, err)
+		productBillerRepo := uow.ProductBillerRepo()
+		err := applyDeletionPolicy(ctx, uc.policy, productBillerRepo, models.ProductBillerFilter{ProductID: &id},
+			func() error {
+				return productBillerRepo.DeleteByProductID(ctx, id, batch)
+			},
+			func() error {
+				return productBillerRepo.DeactivateByProductID(ctx, id, deletionActor, fmt.Sprintf("product %d deleted", id))
+			},
+		)
+		if errors.Is(err, ErrDeleteRestricted) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply deletion policy to product billers for product ID %d: %w", id, err)
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("transaction failed while deleting product with ID %d: %w", id, err)
+		return fmt.Errorf("transaction failed while deleting product with ID %d: %w", id, catalog.VersionConflict(ctx, uc.repo.FetchOne, id, err))
 	}
 
 	return nil
 }
 
+// Restore restores the deleted product and, with cascade, the product billers deleted along with it,
+// except those whose biller is deleted. It returns ErrNotDeleted when the product is not deleted.
+func (uc *productUseCase) Restore(ctx context.Context, id int, cascade bool) (*models.Product, error) {
+	var restored *models.Product
+	err := uc.uow.Execute(ctx, func(uow repositories.UnitOfWork) error {
+		products, err := uow.ProductRepo().FetchMany(ctx, models.ProductFilter{ID: &id, IncludeDeleted: true})
+		if err != nil {
+			return fmt.Errorf("failed to fetch product with ID %d: %w", id, err)
+		}
+		if len(products) == 0 {
+			return fmt.Errorf("failed to fetch product with ID %d: %w", id, sql.ErrNoRows)
+		}
+		if products[0].DeletedAt == nil {
+			return ErrNotDeleted
+		}
+
+		if err := uow.ProductRepo().Restore(ctx, id); err != nil {
+			// Restored concurrently in the meantime.
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotDeleted
+			}
+			return fmt.Errorf("failed to restore product with ID %d: %w", id, err)
+		}
+
+		if cascade && products[0].DeletedBatch != nil {
+			if _, err := uow.ProductBillerRepo().RestoreBatch(ctx, *products[0].DeletedBatch); err != nil {
+				return fmt.Errorf("failed to restore product billers for product ID %d: %w", id, err)
+			}
+		}
+
+		restored, err = uow.ProductRepo().FetchOne(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
 func (uc *productUseCase) FetchOne(ctx context.Context, id int) (*models.Product, error) {
 	return uc.repo.FetchOne(ctx, id)
 }
 
-func (uc *productUseCase) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Product, error) {
+func (uc *productUseCase) FetchMany(ctx context.Context, filter models.ProductFilter) ([]*models.Product, error) {
 	return uc.repo.FetchMany(ctx, filter)
 }
 
-func (uc *productUseCase) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Product, *db.Pagination, error) {
+// Export passes the products matching the filter to fn one at a time, so they can be streamed out.
+func (uc *productUseCase) Export(ctx context.Context, filter models.ProductFilter, fn func(product *models.Product) error) error {
+	return uc.repo.FetchEach(ctx, filter, fn)
+}
+
+func (uc *productUseCase) FetchManyWithPagination(ctx context.Context, filter models.ProductFilter, page, limit int) ([]*models.Product, *db.Pagination, error) {
 	return uc.repo.FetchManyWithPagination(ctx, filter, page, limit)
 }
+
+func (uc *productUseCase) FetchManyWithCursor(ctx context.Context, filter models.ProductFilter, pagination *db.CursorPagination) ([]*models.Product, error) {
+	return uc.repo.FetchManyWithCursor(ctx, filter, pagination)
+}
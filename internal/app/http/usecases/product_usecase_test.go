@@ -0,0 +1,153 @@
+package usecases_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestProductUseCase_Delete(t *testing.T) {
+	ctx := context.Background()
+	id := 1
+	active := true
+	activeFilter := models.ProductBillerFilter{ProductID: &id, IsActive: &active}
+
+	newUseCase := func(policy usecases.DeletionPolicy) (usecases.ProductUseCase, *mocks.MockProductRepository, *mocks.MockProductBillerRepository) {
+		mockUow := new(mocks.MockUnitOfWork)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+
+		mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+		mockUow.On("ProductRepo").Return(mockProductRepo)
+		mockUow.On("ProductBillerRepo").Return(mockProductBillerRepo)
+
+		return usecases.NewProductUseCase(mockProductRepo, mockUow, policy), mockProductRepo, mockProductBillerRepo
+	}
+
+	t.Run("cascade", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyCascade)
+
+		var batch string
+		mockProductRepo.On("Delete", ctx, 1, 3, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { batch = args.String(3) }).
+			Return(nil)
+		mockProductBillerRepo.On("DeleteByProductID", ctx, 1, mock.AnythingOfType("string")).Return(nil)
+
+		require.NoError(t, useCase.Delete(ctx, 1, 3))
+
+		// The product billers are deleted in the batch of the product, so they can be restored with it.
+		assert.NotEmpty(t, batch)
+		mockProductBillerRepo.AssertCalled(t, "DeleteByProductID", ctx, 1, batch)
+		mockProductBillerRepo.AssertNotCalled(t, "FetchMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("restrict with active product billers", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyRestrict)
+		mockProductRepo.On("Delete", ctx, 1, 3, mock.AnythingOfType("string")).Return(nil)
+		mockProductBillerRepo.On("FetchMany", ctx, activeFilter).Return([]*models.ProductBiller{{ID: 7, ProductID: 1, IsActive: true}}, nil)
+
+		err := useCase.Delete(ctx, 1, 3)
+		assert.ErrorIs(t, err, usecases.ErrDeleteRestricted)
+		mockProductBillerRepo.AssertNotCalled(t, "DeleteByProductID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("restrict without active product billers", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyRestrict)
+		mockProductRepo.On("Delete", ctx, 1, 3, mock.AnythingOfType("string")).Return(nil)
+		mockProductBillerRepo.On("FetchMany", ctx, activeFilter).Return([]*models.ProductBiller{}, nil)
+		mockProductBillerRepo.On("DeleteByProductID", ctx, 1, mock.AnythingOfType("string")).Return(nil)
+
+		require.NoError(t, useCase.Delete(ctx, 1, 3))
+		mockProductBillerRepo.AssertExpectations(t)
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyDetach)
+		mockProductRepo.On("Delete", ctx, 1, 3, mock.AnythingOfType("string")).Return(nil)
+		mockProductBillerRepo.On("DeactivateByProductID", ctx, 1, "system", "product 1 deleted").Return(nil)
+
+		require.NoError(t, useCase.Delete(ctx, 1, 3))
+		mockProductBillerRepo.AssertExpectations(t)
+		mockProductBillerRepo.AssertNotCalled(t, "DeleteByProductID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase(usecases.DeletionPolicyRestrict)
+		mockProductRepo.On("Delete", ctx, 1, 2, mock.AnythingOfType("string")).Return(sql.ErrNoRows)
+		mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1, Version: 3}, nil)
+
+		err := useCase.Delete(ctx, 1, 2)
+		assert.ErrorIs(t, err, usecases.ErrVersionConflict)
+		mockProductBillerRepo.AssertNotCalled(t, "FetchMany", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductUseCase_Restore(t *testing.T) {
+	ctx := context.Background()
+	id := 1
+	batch := "5f0c2a4e-7a8b-4c1d-9e2f-3a4b5c6d7e8f"
+	deletedAt := time.Now()
+	filter := models.ProductFilter{ID: &id, IncludeDeleted: true}
+
+	newUseCase := func() (usecases.ProductUseCase, *mocks.MockProductRepository, *mocks.MockProductBillerRepository) {
+		mockUow := new(mocks.MockUnitOfWork)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockProductBillerRepo := new(mocks.MockProductBillerRepository)
+
+		mockUow.On("Execute", ctx, mock.Anything).Return(nil)
+		mockUow.On("ProductRepo").Return(mockProductRepo)
+		mockUow.On("ProductBillerRepo").Return(mockProductBillerRepo)
+
+		return usecases.NewProductUseCase(mockProductRepo, mockUow, usecases.DeletionPolicyCascade), mockProductRepo, mockProductBillerRepo
+	}
+
+	t.Run("cascade", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase()
+		mockProductRepo.On("FetchMany", ctx, filter).Return([]*models.Product{{ID: 1, DeletedAt: &deletedAt, DeletedBatch: &batch}}, nil)
+		mockProductRepo.On("Restore", ctx, 1).Return(nil)
+		mockProductBillerRepo.On("RestoreBatch", ctx, batch).Return(int64(2), nil)
+		mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1, Version: 5}, nil)
+
+		product, err := useCase.Restore(ctx, 1, true)
+		require.NoError(t, err)
+		assert.Equal(t, 5, product.Version)
+		mockProductBillerRepo.AssertExpectations(t)
+	})
+
+	t.Run("without cascade", func(t *testing.T) {
+		useCase, mockProductRepo, mockProductBillerRepo := newUseCase()
+		mockProductRepo.On("FetchMany", ctx, filter).Return([]*models.Product{{ID: 1, DeletedAt: &deletedAt, DeletedBatch: &batch}}, nil)
+		mockProductRepo.On("Restore", ctx, 1).Return(nil)
+		mockProductRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+
+		_, err := useCase.Restore(ctx, 1, false)
+		require.NoError(t, err)
+		mockProductBillerRepo.AssertNotCalled(t, "RestoreBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not deleted", func(t *testing.T) {
+		useCase, mockProductRepo, _ := newUseCase()
+		mockProductRepo.On("FetchMany", ctx, filter).Return([]*models.Product{{ID: 1}}, nil)
+
+		_, err := useCase.Restore(ctx, 1, true)
+		assert.ErrorIs(t, err, usecases.ErrNotDeleted)
+		mockProductRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		useCase, mockProductRepo, _ := newUseCase()
+		mockProductRepo.On("FetchMany", ctx, filter).Return([]*models.Product{}, nil)
+
+		_, err := useCase.Restore(ctx, 1, true)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotDeleted is returned when restoring an entity that is not deleted.
+	ErrNotDeleted = errors.New("entity is not deleted")
+	// ErrParentDeleted is returned when restoring a product biller whose product or biller is deleted.
+	ErrParentDeleted = errors.New("product or biller of the product biller is deleted")
+)
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/routing"
+)
+
+// ErrNoActiveBiller is returned when a product has no healthy biller to route to.
+var ErrNoActiveBiller = errors.New("no active biller available")
+
+// RoutingUseCase defines the interface for selecting the biller that serves a product.
+type RoutingUseCase interface {
+	Route(ctx context.Context, productID int) (*models.ProductBillerRoute, error)
+}
+
+// routingUseCase implements RoutingUseCase.
+type routingUseCase struct {
+	repo        repositories.ProductBillerRepository
+	productRepo repositories.ProductRepository
+	billerRepo  repositories.BillerRepository
+	strategy    routing.Strategy
+}
+
+// NewRoutingUseCase creates a new instance of RoutingUseCase.
+func NewRoutingUseCase(
+	repo repositories.ProductBillerRepository,
+	productRepo repositories.ProductRepository,
+	billerRepo repositories.BillerRepository,
+	strategy routing.Strategy,
+) RoutingUseCase {
+	return &routingUseCase{
+		repo:        repo,
+		productRepo: productRepo,
+		billerRepo:  billerRepo,
+		strategy:    strategy,
+	}
+}
+
+// Route selects a biller among the active product billers with the lowest priority value.
+// The remaining healthy candidates are returned as fallbacks, ordered by priority then weight.
+func (uc *routingUseCase) Route(ctx context.Context, productID int) (*models.ProductBillerRoute, error) {
+	if _, err := uc.productRepo.FetchOne(ctx, productID); err != nil {
+		return nil, fmt.Errorf("failed to fetch product with ID %d: %w", productID, err)
+	}
+
+	isActive := true
+	productBillers, err := uc.repo.FetchMany(ctx, models.ProductBillerFilter{
+		ProductID: &productID,
+		IsActive:  &isActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product billers for product ID %d: %w", productID, err)
+	}
+
+	if len(productBillers) == 0 {
+		return nil, fmt.Errorf("product ID %d: %w", productID, ErrNoActiveBiller)
+	}
+
+	// Only billers that still exist are considered healthy. They are fetched together, whatever the
+	// number of candidates.
+	billerIDs := make([]int, len(productBillers))
+	for i, pb := range productBillers {
+		billerIDs[i] = pb.BillerID
+	}
+	existing, err := uc.billerRepo.FetchMany(ctx, models.BillerFilter{IDs: billerIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch billers for product ID %d: %w", productID, err)
+	}
+
+	billers := make(map[int]*models.Biller, len(existing))
+	for _, biller := range existing {
+		billers[biller.ID] = biller
+	}
+	candidates := make([]*models.ProductBiller, 0, len(productBillers))
+	for _, pb := range productBillers {
+		if _, ok := billers[pb.BillerID]; ok {
+			candidates = append(candidates, pb)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("product ID %d: %w", productID, ErrNoActiveBiller)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority < candidates[j].Priority
+		}
+		if candidates[i].Weight != candidates[j].Weight {
+			return candidates[i].Weight > candidates[j].Weight
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	// The primary tier holds every candidate sharing the best priority.
+	tierSize := 1
+	for tierSize < len(candidates) && candidates[tierSize].Priority == candidates[0].Priority {
+		tierSize++
+	}
+
+	selected := uc.strategy.Select(strconv.Itoa(productID), candidates[:tierSize])
+
+	fallbacks := make([]*models.ProductBiller, 0, len(candidates)-1)
+	for _, candidate := range candidates {
+		if candidate != selected {
+			fallbacks = append(fallbacks, candidate)
+		}
+	}
+
+	return &models.ProductBillerRoute{
+		ProductID: productID,
+		Strategy:  uc.strategy.Name(),
+		Selected:  selected,
+		Biller:    billers[selected.BillerID],
+		Fallbacks: fallbacks,
+	}, nil
+}
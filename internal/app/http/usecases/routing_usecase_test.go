@@ -0,0 +1,100 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/app/http/usecases"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+	"golang-boilerplate/internal/pkg/routing"
+)
+
+func TestRoutingUseCase_Route(t *testing.T) {
+	ctx := context.Background()
+	productID, isActive := 1, true
+	filter := models.ProductBillerFilter{ProductID: &productID, IsActive: &isActive}
+
+	t.Run("selects from the best priority tier and orders fallbacks", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		billerRepo := new(mocks.MockBillerRepository)
+		repo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewRoutingUseCase(repo, productRepo, billerRepo, routing.NewRoundRobinStrategy())
+
+		productRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+		repo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{
+			{ID: 10, ProductID: 1, BillerID: 1, IsActive: true, Priority: 2, Weight: 1},
+			{ID: 11, ProductID: 1, BillerID: 2, IsActive: true, Priority: 1, Weight: 1},
+			{ID: 12, ProductID: 1, BillerID: 3, IsActive: true, Priority: 1, Weight: 5},
+		}, nil)
+		billerRepo.On("FetchMany", ctx, models.BillerFilter{IDs: []int{1, 2, 3}}).
+			Return([]*models.Biller{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+
+		route, err := useCase.Route(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, routing.StrategyRoundRobin, route.Strategy)
+		assert.Equal(t, 12, route.Selected.ID)
+		assert.Equal(t, 3, route.Biller.ID)
+		assert.Equal(t, []int{11, 10}, []int{route.Fallbacks[0].ID, route.Fallbacks[1].ID})
+
+		// Round-robin moves on to the next candidate of the primary tier.
+		route, err = useCase.Route(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 11, route.Selected.ID)
+		assert.Equal(t, []int{12, 10}, []int{route.Fallbacks[0].ID, route.Fallbacks[1].ID})
+
+		// The billers of every candidate are fetched in one query.
+		billerRepo.AssertNotCalled(t, "FetchOne", mock.Anything, mock.Anything)
+		billerRepo.AssertNumberOfCalls(t, "FetchMany", 2)
+	})
+
+	t.Run("skips product billers whose biller is deleted", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		billerRepo := new(mocks.MockBillerRepository)
+		repo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewRoutingUseCase(repo, productRepo, billerRepo, routing.NewRoundRobinStrategy())
+
+		productRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+		repo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{
+			{ID: 10, ProductID: 1, BillerID: 1, IsActive: true, Priority: 0},
+			{ID: 11, ProductID: 1, BillerID: 2, IsActive: true, Priority: 1},
+		}, nil)
+		// Deleted billers are left out of the billers fetched.
+		billerRepo.On("FetchMany", ctx, models.BillerFilter{IDs: []int{1, 2}}).Return([]*models.Biller{{ID: 2}}, nil)
+
+		route, err := useCase.Route(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 11, route.Selected.ID)
+		assert.Empty(t, route.Fallbacks)
+	})
+
+	t.Run("no active biller", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		repo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewRoutingUseCase(repo, productRepo, nil, routing.NewRoundRobinStrategy())
+
+		productRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1}, nil)
+		repo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{}, nil)
+
+		route, err := useCase.Route(ctx, 1)
+		assert.Nil(t, route)
+		assert.ErrorIs(t, err, usecases.ErrNoActiveBiller)
+	})
+
+	t.Run("error fetching product", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		repo := new(mocks.MockProductBillerRepository)
+		useCase := usecases.NewRoutingUseCase(repo, productRepo, nil, routing.NewRoundRobinStrategy())
+
+		productRepo.On("FetchOne", ctx, 1).Return(nil, errors.New("product not found"))
+
+		route, err := useCase.Route(ctx, 1)
+		assert.Nil(t, route)
+		assert.EqualError(t, err, "failed to fetch product with ID 1: product not found")
+		repo.AssertNotCalled(t, "FetchMany", mock.Anything, mock.Anything)
+	})
+}
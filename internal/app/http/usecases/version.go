@@ -0,0 +1,6 @@
+package usecases
+
+import "golang-boilerplate/internal/pkg/catalog"
+
+// ErrVersionConflict is returned when writing an entity that was modified since the given version was read.
+var ErrVersionConflict = catalog.ErrVersionConflict
@@ -15,6 +15,8 @@ type Config struct {
 	DB      config.DB
 	Redis   config.Redis
 	Lock    config.Lock
+	Alert   config.Alert
+	Job     config.Job
 	Logger  config.Logger
 }
 
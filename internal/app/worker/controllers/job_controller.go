@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"context"
+
+	"golang-boilerplate/internal/app/worker/usecases"
+	"golang-boilerplate/internal/pkg/logger"
+)
+
+// JobController runs the background jobs queued by the HTTP service.
+type JobController struct {
+	usecase usecases.JobUseCase
+}
+
+// NewJobController creates a new JobController.
+func NewJobController(usecase usecases.JobUseCase) *JobController {
+	return &JobController{usecase: usecase}
+}
+
+const eventClassJob = "controller.job"
+
+// RunJobs runs queued jobs one after the other until the queue is empty.
+func (jc *JobController) RunJobs(ctx context.Context) {
+	for {
+		ran, err := jc.usecase.RunNext(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Error(ctx, eventClassJob, "RunJobs", err.Error())
+			return
+		}
+		if !ran {
+			return
+		}
+	}
+}
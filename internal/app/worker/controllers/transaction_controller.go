@@ -21,10 +21,10 @@ func NewTransactionController(usecase usecases.TransactionUseCase) *TransactionC
 
 // HandleMessage parses a Kafka message and sends the Transaction to the usecase layer.
 func (tc *TransactionController) HandleMessage(ctx context.Context, message []byte) error {
-	var transaction *models.Transaction
-	if err := json.Unmarshal(message, transaction); err != nil {
+	var transaction models.Transaction
+	if err := json.Unmarshal(message, &transaction); err != nil {
 		return fmt.Errorf("failed to parse message: %w", err)
 	}
 
-	return tc.usecase.ProcessTransaction(ctx, transaction)
+	return tc.usecase.ProcessTransaction(ctx, &transaction)
 }
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// JobProgress reports that done of total units of a job's work are complete. It returns
+// repositories.ErrJobCanceled once the job was canceled, which the handler returns to stop.
+type JobProgress func(done, total int) error
+
+// JobHandler runs a job of one type, returning the result stored on the job. A handler that stops
+// early may still return the result of the work done so far along with its error.
+type JobHandler func(ctx context.Context, job *models.Job, progress JobProgress) (interface{}, error)
+
+type JobUseCase interface {
+	// RunNext claims the next queued job and runs it, reporting whether there was a job to run.
+	RunNext(ctx context.Context) (bool, error)
+}
+
+type jobUseCase struct {
+	repo     repositories.JobRepository
+	handlers map[string]JobHandler
+	config   config.Job
+}
+
+// NewJobUseCase creates a JobUseCase running jobs with the handler registered for their type.
+func NewJobUseCase(repo repositories.JobRepository, handlers map[string]JobHandler, config config.Job) JobUseCase {
+	return &jobUseCase{
+		repo:     repo,
+		handlers: handlers,
+		config:   config,
+	}
+}
+
+const eventClassJob = "usecase.job"
+
+func (uc *jobUseCase) RunNext(ctx context.Context) (bool, error) {
+	token := uuid.NewString()
+	job, err := uc.repo.Claim(ctx, token, time.Now().Add(-uc.config.StaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	status := models.JobStatusSucceeded
+	var lastError *string
+
+	result, runErr := uc.run(ctx, job, token)
+	switch {
+	case errors.Is(runErr, repositories.ErrJobCanceled):
+		status = models.JobStatusCanceled
+	case runErr != nil:
+		status = models.JobStatusFailed
+		message := runErr.Error()
+		lastError = &message
+		logger.FromContext(ctx).Error(ctx, eventClassJob, "RunNext", "[JobID: %d]: %s", job.ID, message)
+	}
+
+	var data json.RawMessage
+	if result != nil {
+		if data, err = json.Marshal(result); err != nil {
+			return true, fmt.Errorf("failed to encode result of job %d: %w", job.ID, err)
+		}
+	}
+
+	if err := uc.repo.Finish(ctx, job.ID, token, status, data, lastError); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// run runs the job with the handler of its type. Progress is written at most once per ProgressInterval,
+// besides the final report, and doubles as the heartbeat keeping the job claimed.
+func (uc *jobUseCase) run(ctx context.Context, job *models.Job, token string) (interface{}, error) {
+	handler, ok := uc.handlers[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+
+	var reportedAt time.Time
+	progress := func(done, total int) error {
+		if done < total && time.Since(reportedAt) < uc.config.ProgressInterval {
+			return nil
+		}
+		reportedAt = time.Now()
+		return uc.repo.ReportProgress(ctx, job.ID, token, done, total)
+	}
+
+	return handler(ctx, job, progress)
+}
@@ -0,0 +1,154 @@
+package usecases_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/app/worker/usecases"
+	"golang-boilerplate/internal/pkg/catalog"
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestJobUseCase_RunNext(t *testing.T) {
+	ctx := context.Background()
+	job := &models.Job{ID: 3, Type: "test", Payload: json.RawMessage(`{}`)}
+
+	// claim expects a job to be claimed and returns the token it was claimed under.
+	claim := func(repo *mocks.MockJobRepository, job *models.Job) *string {
+		var token string
+		repo.On("Claim", ctx, mock.AnythingOfType("string"), mock.Anything).
+			Run(func(args mock.Arguments) { token = args.String(1) }).
+			Return(job, nil).Once()
+		return &token
+	}
+
+	t.Run("empty queue", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		useCase := usecases.NewJobUseCase(repo, nil, config.Job{})
+		claim(repo, nil)
+
+		ran, err := useCase.RunNext(ctx)
+		assert.NoError(t, err)
+		assert.False(t, ran)
+		repo.AssertNotCalled(t, "Finish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success stores the result", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		handlers := map[string]usecases.JobHandler{
+			"test": func(ctx context.Context, job *models.Job, progress usecases.JobProgress) (interface{}, error) {
+				for done := 0; done <= 3; done++ {
+					if err := progress(done, 3); err != nil {
+						return nil, err
+					}
+				}
+				return map[string]int{"updated": 3}, nil
+			},
+		}
+		useCase := usecases.NewJobUseCase(repo, handlers, config.Job{ProgressInterval: time.Hour})
+		token := claim(repo, job)
+		repo.On("ReportProgress", ctx, 3, mock.Anything, mock.Anything, 3).Return(nil)
+		repo.On("Finish", ctx, 3, mock.Anything, models.JobStatusSucceeded, json.RawMessage(`{"updated":3}`), (*string)(nil)).Return(nil)
+
+		ran, err := useCase.RunNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, ran)
+
+		// Progress is throttled to the first report and the final one, under the claimed token.
+		repo.AssertNumberOfCalls(t, "ReportProgress", 2)
+		repo.AssertCalled(t, "ReportProgress", ctx, 3, *token, 0, 3)
+		repo.AssertCalled(t, "ReportProgress", ctx, 3, *token, 3, 3)
+		repo.AssertCalled(t, "Finish", ctx, 3, *token, models.JobStatusSucceeded, json.RawMessage(`{"updated":3}`), (*string)(nil))
+	})
+
+	t.Run("failure stores the error", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		handlers := map[string]usecases.JobHandler{
+			"test": func(ctx context.Context, job *models.Job, progress usecases.JobProgress) (interface{}, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		useCase := usecases.NewJobUseCase(repo, handlers, config.Job{})
+		claim(repo, job)
+		repo.On("Finish", ctx, 3, mock.Anything, models.JobStatusFailed, json.RawMessage(nil), mock.MatchedBy(func(lastError *string) bool {
+			return lastError != nil && *lastError == "db down"
+		})).Return(nil)
+
+		ran, err := useCase.RunNext(ctx)
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown type fails", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		useCase := usecases.NewJobUseCase(repo, nil, config.Job{})
+		claim(repo, job)
+		repo.On("Finish", ctx, 3, mock.Anything, models.JobStatusFailed, json.RawMessage(nil), mock.Anything).Return(nil)
+
+		_, err := useCase.RunNext(ctx)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("cancellation stops the handler", func(t *testing.T) {
+		repo := new(mocks.MockJobRepository)
+		handlers := map[string]usecases.JobHandler{
+			"test": func(ctx context.Context, job *models.Job, progress usecases.JobProgress) (interface{}, error) {
+				if err := progress(1, 3); err != nil {
+					return map[string]int{"updated": 1}, err
+				}
+				t.Fatal("handler kept running after cancellation")
+				return nil, nil
+			},
+		}
+		useCase := usecases.NewJobUseCase(repo, handlers, config.Job{})
+		claim(repo, job)
+		repo.On("ReportProgress", ctx, 3, mock.Anything, 1, 3).Return(repositories.ErrJobCanceled)
+		repo.On("Finish", ctx, 3, mock.Anything, models.JobStatusCanceled, json.RawMessage(`{"updated":1}`), (*string)(nil)).Return(nil)
+
+		_, err := useCase.RunNext(ctx)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestProductBillerJobHandlers_Activation(t *testing.T) {
+	ctx := context.Background()
+	pbRepo := new(mocks.MockProductBillerRepository)
+	jobRepo := new(mocks.MockJobRepository)
+	useCase := usecases.NewJobUseCase(jobRepo, usecases.NewProductBillerJobHandlers(catalog.NewProductBillers(pbRepo, nil)), config.Job{})
+
+	productID, active, inactive := 1, true, false
+	payload, err := json.Marshal(models.ProductBillerActivationRequest{ProductID: &productID, IsActive: &inactive})
+	require.NoError(t, err)
+	job := &models.Job{ID: 4, Type: models.JobTypeProductBillerActivation, Payload: payload, CreatedBy: "alice"}
+
+	jobRepo.On("Claim", ctx, mock.Anything, mock.Anything).Return(job, nil)
+	jobRepo.On("ReportProgress", ctx, 4, mock.Anything, mock.Anything, 2).Return(nil)
+	jobRepo.On("Finish", ctx, 4, mock.Anything, models.JobStatusSucceeded, json.RawMessage(`{"updated":1,"conflicts":1}`), (*string)(nil)).Return(nil)
+
+	pbRepo.On("FetchMany", ctx, models.ProductBillerFilter{ProductID: &productID, IsActive: &active}).
+		Return([]*models.ProductBiller{{ID: 10, Version: 2}, {ID: 11, Version: 5}}, nil)
+	patch := &models.ProductBillerPatch{IsActive: &inactive, UpdatedBy: "alice"}
+	pbRepo.On("Patch", ctx, 10, 2, patch).Return(nil)
+	pbRepo.On("Patch", ctx, 11, 5, patch).Return(sql.ErrNoRows)
+	pbRepo.On("FetchOne", ctx, 11).Return(&models.ProductBiller{ID: 11, Version: 6}, nil)
+
+	ran, err := useCase.RunNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	jobRepo.AssertExpectations(t)
+	pbRepo.AssertExpectations(t)
+}
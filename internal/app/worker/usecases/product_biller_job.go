@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang-boilerplate/internal/pkg/catalog"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// jobChunkSize is the number of product billers a job handles between two progress reports.
+const jobChunkSize = 100
+
+// NewProductBillerJobHandlers returns the handlers of the product biller jobs queued by the HTTP service.
+func NewProductBillerJobHandlers(productBillers catalog.ProductBillers) map[string]JobHandler {
+	return map[string]JobHandler{
+		models.JobTypeProductBillerImport:     importProductBillers(productBillers),
+		models.JobTypeProductBillerActivation: activateProductBillers(productBillers),
+	}
+}
+
+// importProductBillers imports the rows of a ProductBillerImportJob chunk by chunk. Rows duplicating
+// an earlier chunk are reported as duplicates, since that chunk is already committed.
+func importProductBillers(productBillers catalog.ProductBillers) JobHandler {
+	return func(ctx context.Context, job *models.Job, progress JobProgress) (interface{}, error) {
+		var payload models.ProductBillerImportJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode job payload: %w", err)
+		}
+
+		report := &models.ProductBillerImportReport{Rows: make([]models.ProductBillerImportResult, 0, len(payload.Rows))}
+		for start := 0; start < len(payload.Rows); start += jobChunkSize {
+			if err := progress(start, len(payload.Rows)); err != nil {
+				return report, err
+			}
+
+			end := min(start+jobChunkSize, len(payload.Rows))
+			chunk, err := productBillers.Import(ctx, payload.Rows[start:end])
+			if err != nil {
+				return report, err
+			}
+			for _, result := range chunk.Rows {
+				report.Add(result)
+			}
+		}
+
+		return report, progress(len(payload.Rows), len(payload.Rows))
+	}
+}
+
+// activateProductBillers activates or deactivates the product billers selected by a
+// ProductBillerActivationRequest on behalf of the user who queued the job.
+func activateProductBillers(productBillers catalog.ProductBillers) JobHandler {
+	return func(ctx context.Context, job *models.Job, progress JobProgress) (interface{}, error) {
+		var payload models.ProductBillerActivationRequest
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode job payload: %w", err)
+		}
+		if payload.IsActive == nil || (payload.ProductID == nil && payload.BillerID == nil) {
+			return nil, errors.New("invalid job payload: is_active and product_id or biller_id are required")
+		}
+
+		// Only product billers that need to change are selected.
+		isActive := !*payload.IsActive
+		selected, err := productBillers.FetchMany(ctx, models.ProductBillerFilter{
+			ProductID: payload.ProductID,
+			BillerID:  payload.BillerID,
+			IsActive:  &isActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := &models.ProductBillerActivationResult{}
+		for i, pb := range selected {
+			if i%jobChunkSize == 0 {
+				if err := progress(i, len(selected)); err != nil {
+					return result, err
+				}
+			}
+
+			patch := &models.ProductBillerPatch{IsActive: payload.IsActive, UpdatedBy: job.CreatedBy}
+			err := productBillers.Patch(ctx, pb.ID, pb.Version, patch)
+			switch {
+			case err == nil:
+				result.Updated++
+			case errors.Is(err, catalog.ErrVersionConflict):
+				result.Conflicts++
+			default:
+				return result, err
+			}
+		}
+
+		return result, progress(len(selected), len(selected))
+	}
+}
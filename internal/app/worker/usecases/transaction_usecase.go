@@ -2,9 +2,12 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"golang-boilerplate/internal/pkg/infrastructure/lock"
+	"golang-boilerplate/internal/pkg/infrastructure/notification"
 	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/logger"
 	"golang-boilerplate/internal/pkg/models"
@@ -15,22 +18,64 @@ type TransactionUseCase interface {
 }
 
 type transactionUseCase struct {
-	pbRepo repositories.ProductBillerRepository
-	lock   lock.Lock
+	pbRepo      repositories.ProductBillerRepository
+	productRepo repositories.ProductRepository
+	billerRepo  repositories.BillerRepository
+	statRepo    repositories.ProductBillerStatRepository
+	lock        lock.Lock
+	alerter     notification.DeactivationAlerter
 }
 
-func NewTransactionUseCase(pbRepo repositories.ProductBillerRepository, lock lock.Lock) TransactionUseCase {
+func NewTransactionUseCase(
+	pbRepo repositories.ProductBillerRepository,
+	productRepo repositories.ProductRepository,
+	billerRepo repositories.BillerRepository,
+	statRepo repositories.ProductBillerStatRepository,
+	lock lock.Lock,
+	alerter notification.DeactivationAlerter,
+) TransactionUseCase {
 	return &transactionUseCase{
-		pbRepo: pbRepo,
-		lock:   lock,
+		pbRepo:      pbRepo,
+		productRepo: productRepo,
+		billerRepo:  billerRepo,
+		statRepo:    statRepo,
+		lock:        lock,
+		alerter:     alerter,
 	}
 }
 
 const eventClassTransaction = "usecase.transaction"
 
-// ProcessTransaction processes a transaction.
+// errNoProductBiller is returned when no product-biller matches the transaction.
+var errNoProductBiller = errors.New("no product-biller data found")
+
+// deactivatedBy identifies the worker as the actor of automatic deactivations.
+const deactivatedBy = "worker"
+
+// ProcessTransaction records the transaction outcome and deactivates the product-biller on failure.
+// Transactions without a product-biller are skipped, since redelivering them cannot succeed.
 func (uc *transactionUseCase) ProcessTransaction(ctx context.Context, transaction *models.Transaction) error {
-	if transaction.Status == "success" {
+	err := uc.processTransaction(ctx, transaction)
+	if errors.Is(err, errNoProductBiller) {
+		logger.FromContext(ctx).Warn(ctx, eventClassTransaction, "ProcessTransaction", "[TransactionID: %d] [ProductID: %d] [BillerID: %d]: %s",
+			transaction.ID, transaction.ProductID, transaction.BillerID, err.Error())
+		return nil
+	}
+
+	return err
+}
+
+func (uc *transactionUseCase) processTransaction(ctx context.Context, transaction *models.Transaction) error {
+	// Record the outcome before deciding whether to deactivate
+	pb, err := uc.fetchProductBiller(ctx, transaction)
+	if err != nil {
+		return err
+	}
+	if err := uc.statRepo.Record(ctx, transaction.ID, pb.ID, transaction.IsSuccess(), time.Now()); err != nil {
+		return fmt.Errorf("failed to record product-biller stat: %w", err)
+	}
+
+	if transaction.IsSuccess() {
 		return nil
 	}
 
@@ -53,28 +98,69 @@ func (uc *transactionUseCase) ProcessTransaction(ctx context.Context, transactio
 		}
 	}()
 
-	// Fetch product-biller data
-	pbs, err := uc.pbRepo.FetchMany(ctx, map[string]interface{}{
-		"product_id": transaction.ProductID,
-		"biller_id":  transaction.BillerID,
-	})
+	// Re-fetch product-biller data now that the lock is held
+	pb, err = uc.fetchProductBiller(ctx, transaction)
 	if err != nil {
-		return fmt.Errorf("failed to fetch product-biller data: %w", err)
-	}
-	if len(pbs) == 0 {
-		return fmt.Errorf("no product-biller data found")
+		return err
 	}
 
 	// Check if the product-biller is active
-	pb := pbs[0]
 	if !pb.IsActive {
 		return nil
 	}
 
 	// Deactivate the product-biller
-	if err := uc.pbRepo.Update(ctx, pb.ID, &models.ProductBiller{IsActive: false}); err != nil {
+	reason := fmt.Sprintf("transaction %d returned status %q", transaction.ID, transaction.Status)
+	if err := uc.pbRepo.Deactivate(ctx, pb.ID, deactivatedBy, reason); err != nil {
 		return fmt.Errorf("failed to deactivate product-biller: %w", err)
 	}
 
+	// The deactivation already happened, so a failed alert is only logged
+	if err := uc.alerter.Alert(ctx, uc.deactivation(ctx, pb, transaction)); err != nil {
+		logger.FromContext(ctx).Error(ctx, eventClassTransaction, "ProcessTransaction.Alert", err.Error())
+	}
+
 	return nil
 }
+
+// deactivation describes the deactivation of pb for an alert, labelling its product and biller when they can be fetched.
+func (uc *transactionUseCase) deactivation(ctx context.Context, pb *models.ProductBiller, transaction *models.Transaction) models.ProductBillerDeactivation {
+	deactivation := models.ProductBillerDeactivation{
+		ProductBillerID:   pb.ID,
+		ProductID:         pb.ProductID,
+		BillerID:          pb.BillerID,
+		TransactionID:     transaction.ID,
+		TransactionStatus: transaction.Status,
+		DeactivatedAt:     time.Now(),
+	}
+
+	if product, err := uc.productRepo.FetchOne(ctx, pb.ProductID); err != nil {
+		logger.FromContext(ctx).Error(ctx, eventClassTransaction, "ProcessTransaction.FetchProduct", err.Error())
+	} else {
+		deactivation.ProductLabel = product.Label
+	}
+
+	if biller, err := uc.billerRepo.FetchOne(ctx, pb.BillerID); err != nil {
+		logger.FromContext(ctx).Error(ctx, eventClassTransaction, "ProcessTransaction.FetchBiller", err.Error())
+	} else {
+		deactivation.BillerLabel = biller.Label
+	}
+
+	return deactivation
+}
+
+// fetchProductBiller fetches the product-biller the transaction was routed through.
+func (uc *transactionUseCase) fetchProductBiller(ctx context.Context, transaction *models.Transaction) (*models.ProductBiller, error) {
+	pbs, err := uc.pbRepo.FetchMany(ctx, models.ProductBillerFilter{
+		ProductID: &transaction.ProductID,
+		BillerID:  &transaction.BillerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product-biller data: %w", err)
+	}
+	if len(pbs) == 0 {
+		return nil, errNoProductBiller
+	}
+
+	return pbs[0], nil
+}
@@ -0,0 +1,119 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/app/worker/usecases"
+	lockMocks "golang-boilerplate/internal/pkg/infrastructure/lock/mocks"
+	notificationMocks "golang-boilerplate/internal/pkg/infrastructure/notification/mocks"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestTransactionUseCase_ProcessTransaction(t *testing.T) {
+	ctx := context.Background()
+	productID, billerID := 1, 2
+	filter := models.ProductBillerFilter{ProductID: &productID, BillerID: &billerID}
+	lockKey := "worker:transaction:process_transaction:1:2"
+
+	t.Run("success records stat without deactivating", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		lock := new(lockMocks.MockLock)
+		useCase := usecases.NewTransactionUseCase(pbRepo, nil, nil, statRepo, lock, nil)
+
+		pbRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{{ID: 10, IsActive: true}}, nil)
+		statRepo.On("Record", ctx, 1, 10, true, mock.Anything).Return(nil)
+
+		err := useCase.ProcessTransaction(ctx, &models.Transaction{ID: 1, ProductID: 1, BillerID: 2, Status: "success"})
+		assert.NoError(t, err)
+
+		statRepo.AssertExpectations(t)
+		lock.AssertNotCalled(t, "AcquireLock", mock.Anything, mock.Anything)
+		pbRepo.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failure records stat, deactivates and alerts", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		productRepo := new(mocks.MockProductRepository)
+		billerRepo := new(mocks.MockBillerRepository)
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		lock := new(lockMocks.MockLock)
+		alerter := new(notificationMocks.MockDeactivationAlerter)
+		useCase := usecases.NewTransactionUseCase(pbRepo, productRepo, billerRepo, statRepo, lock, alerter)
+
+		pbRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{{ID: 10, ProductID: 1, BillerID: 2, IsActive: true}}, nil)
+		statRepo.On("Record", ctx, 1, 10, false, mock.Anything).Return(nil)
+		lock.On("AcquireLock", ctx, lockKey).Return(true, nil)
+		lock.On("ReleaseLock", ctx, lockKey).Return(nil)
+		pbRepo.On("Deactivate", ctx, 10, "worker", `transaction 1 returned status "failed"`).Return(nil)
+		productRepo.On("FetchOne", ctx, 1).Return(&models.Product{ID: 1, Label: "Pulsa 10K"}, nil)
+		billerRepo.On("FetchOne", ctx, 2).Return(nil, errors.New("biller lookup failed"))
+		alerter.On("Alert", ctx, mock.MatchedBy(func(d models.ProductBillerDeactivation) bool {
+			return d.ProductBillerID == 10 && d.ProductLabel == "Pulsa 10K" && d.BillerLabel == "" &&
+				d.TransactionID == 1 && d.TransactionStatus == "failed"
+		})).Return(errors.New("redis down"))
+
+		// Neither a failed label lookup nor a failed alert undoes the deactivation.
+		err := useCase.ProcessTransaction(ctx, &models.Transaction{ID: 1, ProductID: 1, BillerID: 2, Status: "failed"})
+		assert.NoError(t, err)
+
+		pbRepo.AssertExpectations(t)
+		statRepo.AssertExpectations(t)
+		lock.AssertExpectations(t)
+		alerter.AssertExpectations(t)
+	})
+
+	t.Run("failure on inactive product biller does not alert", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		lock := new(lockMocks.MockLock)
+		alerter := new(notificationMocks.MockDeactivationAlerter)
+		useCase := usecases.NewTransactionUseCase(pbRepo, nil, nil, statRepo, lock, alerter)
+
+		pbRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{{ID: 10, IsActive: false}}, nil)
+		statRepo.On("Record", ctx, 1, 10, false, mock.Anything).Return(nil)
+		lock.On("AcquireLock", ctx, lockKey).Return(true, nil)
+		lock.On("ReleaseLock", ctx, lockKey).Return(nil)
+
+		err := useCase.ProcessTransaction(ctx, &models.Transaction{ID: 1, ProductID: 1, BillerID: 2, Status: "failed"})
+		assert.NoError(t, err)
+
+		pbRepo.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		alerter.AssertNotCalled(t, "Alert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error recording stat", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		lock := new(lockMocks.MockLock)
+		useCase := usecases.NewTransactionUseCase(pbRepo, nil, nil, statRepo, lock, nil)
+
+		pbRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{{ID: 10, IsActive: true}}, nil)
+		statRepo.On("Record", ctx, 1, 10, false, mock.Anything).Return(errors.New("insert failed"))
+
+		err := useCase.ProcessTransaction(ctx, &models.Transaction{ID: 1, ProductID: 1, BillerID: 2, Status: "failed"})
+		assert.EqualError(t, err, "failed to record product-biller stat: insert failed")
+
+		lock.AssertNotCalled(t, "AcquireLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no product biller", func(t *testing.T) {
+		pbRepo := new(mocks.MockProductBillerRepository)
+		statRepo := new(mocks.MockProductBillerStatRepository)
+		useCase := usecases.NewTransactionUseCase(pbRepo, nil, nil, statRepo, nil, nil)
+
+		pbRepo.On("FetchMany", ctx, filter).Return([]*models.ProductBiller{}, nil)
+
+		// The transaction is skipped rather than failed, so it is not redelivered.
+		err := useCase.ProcessTransaction(ctx, &models.Transaction{ID: 1, ProductID: 1, BillerID: 2, Status: "failed"})
+		assert.NoError(t, err)
+
+		statRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type userCtxKey struct{}
+
+// UnaryServerInterceptor authenticates unary calls by the JWT in their authorization metadata, signed with
+// secret as for the HTTP API. Methods under the services of public, such as the health service, are
+// served without a token.
+func UnaryServerInterceptor(secret string, public ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod, public) {
+			return handler(ctx, req)
+		}
+
+		user, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ContextWithUser(ctx, user), req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls as UnaryServerInterceptor does unary ones.
+func StreamServerInterceptor(secret string, public ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod, public) {
+			return handler(srv, stream)
+		}
+
+		user, err := authenticate(stream.Context(), secret)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &userStream{ServerStream: stream, ctx: ContextWithUser(stream.Context(), user)})
+	}
+}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user.
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, user)
+}
+
+// UserFromContext returns the user authenticated by the gRPC interceptors, or the zero User when there is none.
+func UserFromContext(ctx context.Context) User {
+	user, _ := ctx.Value(userCtxKey{}).(User)
+	return user
+}
+
+// authenticate parses the bearer token of the authorization metadata of an incoming call.
+func authenticate(ctx context.Context, secret string) (User, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return User{}, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return User{}, status.Error(codes.Unauthenticated, "authorization metadata must be a bearer token")
+	}
+
+	claims := new(JwtCustomClaims)
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return User{}, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return User{
+		ID:       claims.ID,
+		Username: claims.Username,
+		RoleID:   claims.RoleID,
+		IsAdmin:  claims.IsAdmin,
+	}, nil
+}
+
+// isPublic reports whether method, such as /grpc.health.v1.Health/Check, belongs to one of the services.
+func isPublic(method string, services []string) bool {
+	for _, service := range services {
+		if strings.HasPrefix(method, "/"+service+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// userStream overrides the context of a server stream with one carrying the authenticated user.
+type userStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *userStream) Context() context.Context {
+	return s.ctx
+}
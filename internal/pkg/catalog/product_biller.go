@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ProductBillers holds the ProductBiller operations shared by the HTTP service and the worker, which runs
+// the import and activation jobs the HTTP service queues.
+type ProductBillers interface {
+	FetchMany(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBiller, error)
+	Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error
+	Import(ctx context.Context, rows []*models.ProductBillerImportRow) (*models.ProductBillerImportReport, error)
+}
+
+// importBatchSize is the number of rows imported per transaction.
+const importBatchSize = 100
+
+// productBillers implements ProductBillers.
+type productBillers struct {
+	repo repositories.ProductBillerRepository
+	uow  repositories.UnitOfWork
+}
+
+// NewProductBillers creates a new instance of ProductBillers.
+func NewProductBillers(repo repositories.ProductBillerRepository, uow repositories.UnitOfWork) ProductBillers {
+	return &productBillers{
+		repo: repo,
+		uow:  uow,
+	}
+}
+
+func (s *productBillers) FetchMany(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBiller, error) {
+	return s.repo.FetchMany(ctx, filter)
+}
+
+// Patch returns ErrVersionConflict when the product biller no longer has the given version.
+func (s *productBillers) Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error {
+	if patch == nil {
+		return errors.New("product biller patch is nil")
+	}
+
+	return VersionConflict(ctx, s.repo.FetchOne, id, s.repo.Patch(ctx, id, version, patch))
+}
+
+// Import creates the product billers of the rows in transactions of importBatchSize rows, reporting every row
+// as created, duplicate or invalid. Rows referring to a missing product or biller are invalid; rows mapping
+// a product to a biller it is already mapped to, or that an earlier row maps it to, are duplicates. An
+// unexpected error aborts the import; the batches committed until then are kept.
+func (s *productBillers) Import(ctx context.Context, rows []*models.ProductBillerImportRow) (*models.ProductBillerImportReport, error) {
+	report := &models.ProductBillerImportReport{Rows: make([]models.ProductBillerImportResult, 0, len(rows))}
+	imported := make(map[[2]int]bool)
+
+	for start := 0; start < len(rows); start += importBatchSize {
+		batch := rows[start:min(start+importBatchSize, len(rows))]
+
+		// Results and imported pairs of the batch are only recorded once it commits.
+		results := make([]models.ProductBillerImportResult, 0, len(batch))
+		batchImported := make(map[[2]int]bool)
+		err := s.uow.Execute(ctx, func(uow repositories.UnitOfWork) error {
+			for _, row := range batch {
+				result, err := importRow(ctx, uow, row, func(pair [2]int) bool { return imported[pair] || batchImported[pair] })
+				if err != nil {
+					return fmt.Errorf("failed to import row %d: %w", row.Row, err)
+				}
+				if result.Status == models.ProductBillerImportCreated {
+					batchImported[[2]int{row.Request.ProductID, row.Request.BillerID}] = true
+				}
+				results = append(results, result)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to import product billers: %w", err)
+		}
+
+		for pair := range batchImported {
+			imported[pair] = true
+		}
+		for _, result := range results {
+			report.Add(result)
+		}
+	}
+
+	return report, nil
+}
+
+// importRow creates the product biller of a row within a bulk import. seen reports whether an earlier row
+// of the import created the given product and biller pair.
+func importRow(ctx context.Context, uow repositories.UnitOfWork, row *models.ProductBillerImportRow, seen func(pair [2]int) bool) (models.ProductBillerImportResult, error) {
+	result := models.ProductBillerImportResult{Row: row.Row, Status: models.ProductBillerImportInvalid}
+	if row.Error != "" {
+		result.Error = row.Error
+		return result, nil
+	}
+
+	request := row.Request
+	if _, err := uow.ProductRepo().FetchOne(ctx, request.ProductID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return result, err
+		}
+		result.Error = fmt.Sprintf("product %d does not exist", request.ProductID)
+		return result, nil
+	}
+	if _, err := uow.BillerRepo().FetchOne(ctx, request.BillerID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return result, err
+		}
+		result.Error = fmt.Sprintf("biller %d does not exist", request.BillerID)
+		return result, nil
+	}
+
+	duplicate := models.ProductBillerImportResult{
+		Row:    row.Row,
+		Status: models.ProductBillerImportDuplicate,
+		Error:  fmt.Sprintf("product %d is already mapped to biller %d", request.ProductID, request.BillerID),
+	}
+	if seen([2]int{request.ProductID, request.BillerID}) {
+		return duplicate, nil
+	}
+	existing, err := uow.ProductBillerRepo().FetchMany(ctx, models.ProductBillerFilter{ProductID: &request.ProductID, BillerID: &request.BillerID})
+	if err != nil {
+		return result, err
+	}
+	if len(existing) > 0 {
+		return duplicate, nil
+	}
+
+	created, err := uow.ProductBillerRepo().Create(ctx, request.ToEntity())
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateEntry) {
+			return duplicate, nil
+		}
+		return result, err
+	}
+
+	return models.ProductBillerImportResult{Row: row.Row, Status: models.ProductBillerImportCreated, ID: created.ID}, nil
+}
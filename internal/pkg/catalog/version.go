@@ -0,0 +1,26 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrVersionConflict is returned when writing an entity that was modified since the given version was read.
+var ErrVersionConflict = errors.New("entity was modified since it was read")
+
+// VersionConflict explains why a versioned write matched no row: either the entity does not exist,
+// in which case the fetch error is returned, or it has moved on to another version. Other errors,
+// including nil, are returned unchanged.
+func VersionConflict[T any](ctx context.Context, fetch func(context.Context, int) (T, error), id int, writeErr error) error {
+	if !errors.Is(writeErr, sql.ErrNoRows) {
+		return writeErr
+	}
+
+	if _, err := fetch(ctx, id); err != nil {
+		return fmt.Errorf("failed to fetch entity with ID %d: %w", id, err)
+	}
+
+	return ErrVersionConflict
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type Alert struct {
+	// GroupWindow is how long deactivations are collected before being sent as one alert.
+	GroupWindow time.Duration `env:"ALERT_GROUP_WINDOW" env-default:"30s"`
+	// RateLimit is the minimum time between two alerts for the same product biller.
+	RateLimit time.Duration `env:"ALERT_RATE_LIMIT" env-default:"15m"`
+}
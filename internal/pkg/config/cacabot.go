@@ -1,8 +1,21 @@
 package config
 
+import "time"
+
 type Cacabot struct {
 	URL      string `env:"CACABOT_URL" env-required:"true"`
 	Username string `env:"CACABOT_USERNAME" env-required:"true"`
 	Password string `env:"CACABOT_PASSWORD" env-required:"true"`
 	Enabled  bool   `env:"CACABOT_ENABLED" env-required:"true"`
+
+	Timeout    time.Duration `env:"CACABOT_TIMEOUT" env-default:"10s"`
+	MaxRetries int           `env:"CACABOT_MAX_RETRIES" env-default:"3"`
+	// RetryBackoff is the delay before the first retry; it doubles on every further retry up to MaxRetryBackoff.
+	RetryBackoff    time.Duration `env:"CACABOT_RETRY_BACKOFF" env-default:"500ms"`
+	MaxRetryBackoff time.Duration `env:"CACABOT_MAX_RETRY_BACKOFF" env-default:"30s"`
+	// SigningSecret enables HMAC-SHA256 request signing when set.
+	SigningSecret string `env:"CACABOT_SIGNING_SECRET"`
+	// BreakerThreshold is the number of consecutive failed sends that opens the circuit; 0 disables it.
+	BreakerThreshold int           `env:"CACABOT_BREAKER_THRESHOLD" env-default:"5"`
+	BreakerCooldown  time.Duration `env:"CACABOT_BREAKER_COOLDOWN" env-default:"1m"`
 }
@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+type Job struct {
+	// PollInterval is how long a worker waits for new jobs once the queue is empty.
+	PollInterval time.Duration `env:"JOB_POLL_INTERVAL" env-default:"2s"`
+	// ProgressInterval is the minimum time between two progress writes of a running job, which is
+	// also how quickly it notices a cancellation.
+	ProgressInterval time.Duration `env:"JOB_PROGRESS_INTERVAL" env-default:"1s"`
+	// StaleAfter is how long a running job may go without reporting progress before it is considered
+	// abandoned by its worker and picked up again.
+	StaleAfter time.Duration `env:"JOB_STALE_AFTER" env-default:"5m"`
+}
@@ -0,0 +1,55 @@
+package config
+
+import "time"
+
+type Notification struct {
+	// Routes maps notification types to channels, e.g. "product_biller_summary=cacabot,slack;*=cacabot".
+	Routes      string        `env:"NOTIFICATION_ROUTES" env-default:"*=cacabot"`
+	TemplateDir string        `env:"NOTIFICATION_TEMPLATE_DIR"`
+	HTTPTimeout time.Duration `env:"NOTIFICATION_HTTP_TIMEOUT" env-default:"10s"`
+	Queue       NotificationQueue
+	Webhook     WebhookChannel
+	Slack       SlackChannel
+	Email       EmailChannel
+	Telegram    TelegramChannel
+}
+
+type NotificationQueue struct {
+	DispatchInterval time.Duration `env:"NOTIFICATION_DISPATCH_INTERVAL" env-default:"30s"`
+	BatchSize        int           `env:"NOTIFICATION_DISPATCH_BATCH_SIZE" env-default:"50"`
+	// ClaimTimeout is how long a dispatcher has to deliver a claimed batch before it can be claimed again.
+	ClaimTimeout time.Duration `env:"NOTIFICATION_CLAIM_TIMEOUT" env-default:"10m"`
+	// MaxAttempts is the number of deliveries tried before a notification is marked failed.
+	MaxAttempts     int           `env:"NOTIFICATION_MAX_ATTEMPTS" env-default:"8"`
+	RetryBackoff    time.Duration `env:"NOTIFICATION_RETRY_BACKOFF" env-default:"1m"`
+	MaxRetryBackoff time.Duration `env:"NOTIFICATION_MAX_RETRY_BACKOFF" env-default:"1h"`
+}
+
+type WebhookChannel struct {
+	URL    string `env:"NOTIFICATION_WEBHOOK_URL"`
+	Format string `env:"NOTIFICATION_WEBHOOK_FORMAT" env-default:"text"`
+}
+
+type SlackChannel struct {
+	WebhookURL string `env:"NOTIFICATION_SLACK_WEBHOOK_URL"`
+	Format     string `env:"NOTIFICATION_SLACK_FORMAT" env-default:"markdown"`
+}
+
+type EmailChannel struct {
+	Host     string `env:"NOTIFICATION_EMAIL_SMTP_HOST"`
+	Port     int    `env:"NOTIFICATION_EMAIL_SMTP_PORT" env-default:"587"`
+	Username string `env:"NOTIFICATION_EMAIL_SMTP_USERNAME"`
+	Password string `env:"NOTIFICATION_EMAIL_SMTP_PASSWORD"`
+	// Timeout bounds a whole delivery, from dialing the server to QUIT.
+	Timeout time.Duration `env:"NOTIFICATION_EMAIL_SMTP_TIMEOUT" env-default:"30s"`
+	From    string        `env:"NOTIFICATION_EMAIL_FROM"`
+	To      []string      `env:"NOTIFICATION_EMAIL_TO" env-separator:","`
+	Format  string        `env:"NOTIFICATION_EMAIL_FORMAT" env-default:"text"`
+}
+
+type TelegramChannel struct {
+	URL      string `env:"NOTIFICATION_TELEGRAM_API_URL" env-default:"https://api.telegram.org"`
+	BotToken string `env:"NOTIFICATION_TELEGRAM_BOT_TOKEN"`
+	ChatID   string `env:"NOTIFICATION_TELEGRAM_CHAT_ID"`
+	Format   string `env:"NOTIFICATION_TELEGRAM_FORMAT" env-default:"markdown"`
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type Purge struct {
+	// Retention is how long soft-deleted rows are kept, and can be restored, before they are purged.
+	Retention time.Duration `env:"PURGE_RETENTION" env-default:"720h"`
+	// StatRetention is how long the transaction stats of product billers are kept, by the start of their bucket.
+	StatRetention time.Duration `env:"PURGE_STAT_RETENTION" env-default:"2160h"`
+	// StatTransactionRetention is how long recorded transactions are remembered, so a redelivered transaction
+	// is not counted twice. It must exceed the longest time a transaction may be redelivered after.
+	StatTransactionRetention time.Duration `env:"PURGE_STAT_TRANSACTION_RETENTION" env-default:"168h"`
+	BatchSize                int           `env:"PURGE_BATCH_SIZE" env-default:"1000"`
+	Hour                     int           `env:"PURGE_HOUR" env-default:"3"`
+	Minute                   int           `env:"PURGE_MINUTE" env-default:"0"`
+}
@@ -3,32 +3,79 @@ package cacabot
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"golang-boilerplate/internal/pkg/config"
 	"golang-boilerplate/internal/pkg/logger"
 )
 
+const (
+	// maxErrorBodySize caps how much of an error response body is included in returned errors.
+	maxErrorBodySize = 1024
+
+	headerTimestamp = "X-Cacabot-Timestamp"
+	headerSignature = "X-Cacabot-Signature"
+)
+
 type Client struct {
 	URL      string
 	Username string
 	Password string
 	Enabled  bool
+
+	maxRetries      int
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	signingSecret   string
+	httpClient      *http.Client
+	breaker         *breaker
+	sleep           func(ctx context.Context, d time.Duration) error
 }
 
 // NewCacabotClient creates a new Cacabot client.
-func NewCacabotClient(url, username, password string, enabled bool) *Client {
+func NewCacabotClient(cfg *config.Cacabot) *Client {
 	return &Client{
-		URL:      url,
-		Username: username,
-		Password: password,
-		Enabled:  enabled,
+		URL:             cfg.URL,
+		Username:        cfg.Username,
+		Password:        cfg.Password,
+		Enabled:         cfg.Enabled,
+		maxRetries:      cfg.MaxRetries,
+		retryBackoff:    cfg.RetryBackoff,
+		maxRetryBackoff: cfg.MaxRetryBackoff,
+		signingSecret:   cfg.SigningSecret,
+		httpClient:      &http.Client{Timeout: cfg.Timeout},
+		breaker:         newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		sleep:           sleep,
 	}
 }
 
-// SendMessage sends a message to Cacabot.
+// responseError describes a failed response from Cacabot.
+type responseError struct {
+	statusCode int
+	body       []byte
+	retryAfter time.Duration
+}
+
+func (e *responseError) Error() string {
+	return fmt.Sprintf("request failed with status code %d: %s", e.statusCode, e.body)
+}
+
+// retryable reports whether the failure is worth another attempt.
+func (e *responseError) retryable() bool {
+	return e.statusCode >= http.StatusInternalServerError || e.statusCode == http.StatusTooManyRequests
+}
+
+// SendMessage sends a message to Cacabot, retrying on network errors and 5xx/429 responses.
 func (c *Client) SendMessage(ctx context.Context, path string, payload interface{}) error {
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
@@ -41,8 +88,46 @@ func (c *Client) SendMessage(ctx context.Context, path string, payload interface
 		return nil
 	}
 
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	attempts := 0
+	for {
+		attempts++
+		err = c.send(ctx, path, payloadJSON)
+		if err == nil {
+			c.breaker.record(true)
+			return nil
+		}
+
+		var respErr *responseError
+		isResponseErr := errors.As(err, &respErr)
+		if isResponseErr && !respErr.retryable() {
+			// Cacabot is up and rejected the request; that says nothing about its health.
+			c.breaker.record(true)
+			return err
+		}
+		if attempts > c.maxRetries || ctx.Err() != nil {
+			break
+		}
+
+		delay := c.backoff(attempts - 1)
+		if isResponseErr && respErr.retryAfter > 0 {
+			delay = min(respErr.retryAfter, c.maxRetryBackoff)
+		}
+		if err := c.sleep(ctx, delay); err != nil {
+			break
+		}
+	}
+
+	c.breaker.record(false)
+	return fmt.Errorf("failed after %d attempt(s): %w", attempts, err)
+}
+
+func (c *Client) send(ctx context.Context, path string, payloadJSON []byte) error {
 	url := fmt.Sprintf("%s%s", c.URL, path)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payloadJSON))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadJSON))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -52,15 +137,70 @@ func (c *Client) SendMessage(ctx context.Context, path string, payload interface
 	req.Header.Set("Authorization", "Basic "+auth)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	if c.signingSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(headerTimestamp, timestamp)
+		req.Header.Set(headerSignature, Sign(c.signingSecret, timestamp, payloadJSON))
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return &responseError{
+			statusCode: resp.StatusCode,
+			body:       body,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
 	return nil
 }
+
+// backoff returns the exponential delay before the retry following the given attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.retryBackoff
+	for i := 0; i < attempt && delay < c.maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.maxRetryBackoff)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>", as sent in the X-Cacabot-Signature header.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package cacabot
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/config"
+)
+
+func newTestClient(url string, cfg config.Cacabot) (*Client, *[]time.Duration) {
+	cfg.URL = url
+	cfg.Enabled = true
+	client := NewCacabotClient(&cfg)
+
+	var sleeps []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return client, &sleeps
+}
+
+func TestSendMessage_RetriesServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client, sleeps := newTestClient(server.URL, config.Cacabot{
+		MaxRetries:      3,
+		RetryBackoff:    100 * time.Millisecond,
+		MaxRetryBackoff: time.Minute,
+	})
+
+	require.NoError(t, client.SendMessage(context.Background(), "/test", map[string]string{"a": "b"}))
+	assert.EqualValues(t, 3, calls)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 2 * time.Second}, *sleeps)
+}
+
+func TestSendMessage_ClientErrorIncludesBodyWithoutRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid chat"}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL, config.Cacabot{MaxRetries: 3})
+
+	err := client.SendMessage(context.Background(), "/test", nil)
+	assert.ErrorContains(t, err, `status code 400: {"error":"invalid chat"}`)
+	assert.EqualValues(t, 1, calls)
+}
+
+func TestSendMessage_Signature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(headerSignature) != Sign("secret", r.Header.Get(headerTimestamp), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL, config.Cacabot{SigningSecret: "secret"})
+
+	assert.NoError(t, client.SendMessage(context.Background(), "/test", map[string]int{"n": 1}))
+}
+
+func TestSendMessage_CircuitBreaker(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL, config.Cacabot{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	assert.Error(t, client.SendMessage(context.Background(), "/test", nil))
+	assert.Error(t, client.SendMessage(context.Background(), "/test", nil))
+	assert.ErrorIs(t, client.SendMessage(context.Background(), "/test", nil), ErrCircuitOpen)
+	assert.EqualValues(t, 2, calls)
+
+	// After the cooldown a single trial request is let through.
+	now = now.Add(time.Minute)
+	assert.NotErrorIs(t, client.SendMessage(context.Background(), "/test", nil), ErrCircuitOpen)
+	assert.ErrorIs(t, client.SendMessage(context.Background(), "/test", nil), ErrCircuitOpen)
+	assert.EqualValues(t, 3, calls)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+}
@@ -0,0 +1,349 @@
+package db
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+)
+
+// ErrInvalidCursor is returned for a cursor that is malformed, was not signed with the current secret,
+// or was issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorSigner signs cursors so clients cannot forge the sort key values they carry.
+type CursorSigner struct {
+	secret []byte
+}
+
+// NewCursorSigner creates a CursorSigner using secret as the HMAC-SHA256 key.
+func NewCursorSigner(secret string) *CursorSigner {
+	return &CursorSigner{secret: []byte(secret)}
+}
+
+// cursor points at a boundary row of a page: the next page starts after it, or, when Backward is set,
+// the previous page ends before it.
+type cursor struct {
+	Order    string            `json:"o"`
+	Keys     []json.RawMessage `json:"k"`
+	Backward bool              `json:"b,omitempty"`
+}
+
+func (s *CursorSigner) encode(c cursor) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+func (s *CursorSigner) decode(raw string) (cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(raw, ".")
+	if !ok {
+		return cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(payload)) {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+func (s *CursorSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// CursorPagination holds parameters and metadata for keyset paginated queries.
+type CursorPagination struct {
+	Signer     *CursorSigner `json:"-"`           // Signs the returned cursors and verifies Cursor.
+	Order      []SortTerm    `json:"-"`           // Sort order; "id ASC" is appended as a tie-breaker when missing.
+	Cursor     string        `json:"-"`           // Cursor of the requested page, empty for the first page.
+	Limit      int           `json:"limit"`       // Number of rows per page.
+	CountRows  bool          `json:"-"`           // Whether to count the total rows, which costs a full scan.
+	NextCursor string        `json:"next_cursor"` // Cursor of the next page, empty on the last page.
+	PrevCursor string        `json:"prev_cursor"` // Cursor of the previous page, empty on the first page.
+	TotalRows  *int          `json:"total_rows,omitempty"`
+}
+
+// GetLimit ensures a valid limit is returned.
+func (p *CursorPagination) GetLimit() int {
+	if p.Limit <= 0 {
+		p.Limit = 10
+	}
+	return p.Limit
+}
+
+// PaginateCursor executes a keyset paginated query and populates the results slice. Rows are filtered on
+// the sort key of the cursor instead of skipped with OFFSET, so deep pages cost the same as the first one.
+// Every column of pagination.Order must be selected by baseSQL and mapped to a field of T.
+func PaginateCursor[T any](
+	ctx context.Context,
+	db DBExecutor,
+	baseSQL string,
+	args []interface{},
+	pagination *CursorPagination,
+	results *[]T,
+) error {
+	if db == nil {
+		return fmt.Errorf("db is nil")
+	}
+	if pagination == nil {
+		return fmt.Errorf("pagination is nil")
+	}
+	if pagination.Signer == nil {
+		return fmt.Errorf("cursor signer is nil")
+	}
+	if results == nil {
+		return fmt.Errorf("results slice is nil")
+	}
+
+	order := withIDTieBreaker(slices.Clone(pagination.Order))
+	orderKey := orderClause(order)
+
+	fields, err := sortKeyFields(reflect.TypeOf(results).Elem().Elem(), order)
+	if err != nil {
+		return err
+	}
+
+	var current cursor
+	var conditions []string
+	queryArgs := slices.Clone(args)
+	if pagination.Cursor != "" {
+		if current, err = pagination.Signer.decode(pagination.Cursor); err != nil {
+			return err
+		}
+		if current.Order != orderKey || len(current.Keys) != len(order) {
+			return ErrInvalidCursor
+		}
+
+		keys, err := decodeSortKeys(current.Keys, fields)
+		if err != nil {
+			return err
+		}
+
+		condition, conditionArgs := keysetCondition(queryOrder(order, current.Backward), keys, nullable(fields))
+		conditions = append(conditions, condition)
+		queryArgs = append(queryArgs, conditionArgs...)
+	}
+
+	if pagination.CountRows {
+		var totalRows int
+		sqlCount := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS count", baseSQL)
+		if err := db.QueryRowxContext(ctx, sqlCount, args...).Scan(&totalRows); err != nil {
+			return fmt.Errorf("failed to count rows: %w", err)
+		}
+		pagination.TotalRows = &totalRows
+	}
+
+	// The base query is wrapped so the keyset condition applies whatever its own WHERE clause looks like.
+	// One row more than the limit is fetched to find out whether another page follows.
+	sqlPaginated := fmt.Sprintf("SELECT * FROM (%s) AS page", baseSQL)
+	if len(conditions) > 0 {
+		sqlPaginated += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sqlPaginated += fmt.Sprintf(
+		" ORDER BY %s LIMIT %d",
+		orderClause(queryOrder(order, current.Backward)),
+		pagination.GetLimit()+1,
+	)
+
+	rows, err := db.QueryxContext(ctx, sqlPaginated, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to execute paginated query: %w", err)
+	}
+	defer rows.Close()
+
+	if err := sqlx.StructScan(rows, results); err != nil {
+		return fmt.Errorf("failed to scan rows into structs: %w", err)
+	}
+
+	hasMore := len(*results) > pagination.GetLimit()
+	if hasMore {
+		*results = (*results)[:pagination.GetLimit()]
+	}
+	if current.Backward {
+		slices.Reverse(*results)
+	}
+
+	// Walking forward, a previous page exists whenever we started from a cursor; walking backward,
+	// a next page always exists since that is where the cursor came from.
+	hasNext, hasPrev := hasMore, pagination.Cursor != ""
+	if current.Backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	pagination.NextCursor, pagination.PrevCursor = "", ""
+	if len(*results) == 0 {
+		return nil
+	}
+	if hasNext {
+		if pagination.NextCursor, err = boundaryCursor(pagination.Signer, orderKey, fields, (*results)[len(*results)-1], false); err != nil {
+			return err
+		}
+	}
+	if hasPrev {
+		if pagination.PrevCursor, err = boundaryCursor(pagination.Signer, orderKey, fields, (*results)[0], true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sortKeyField locates the struct field holding the value of a sort column.
+type sortKeyField struct {
+	term  SortTerm
+	index []int
+	typ   reflect.Type
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// nullable reports for every field whether its column may hold NULL, judging by whether the field can.
+func nullable(fields []sortKeyField) []bool {
+	flags := make([]bool, len(fields))
+	for i, field := range fields {
+		flags[i] = field.typ.Kind() == reflect.Pointer || reflect.PointerTo(field.typ).Implements(scannerType)
+	}
+	return flags
+}
+
+func sortKeyFields(rowType reflect.Type, order []SortTerm) ([]sortKeyField, error) {
+	structType := reflectx.Deref(rowType)
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cursor pagination requires struct rows, got %s", rowType)
+	}
+
+	mapper := reflectx.NewMapperFunc("db", sqlx.NameMapper)
+	typeMap := mapper.TypeMap(structType)
+
+	fields := make([]sortKeyField, len(order))
+	for i, term := range order {
+		field := typeMap.GetByPath(term.Column)
+		if field == nil {
+			return nil, fmt.Errorf("sort column %q is not mapped to a field of %s", term.Column, structType)
+		}
+		fields[i] = sortKeyField{term: term, index: field.Index, typ: field.Field.Type}
+	}
+
+	return fields, nil
+}
+
+// decodeSortKeys converts the JSON encoded cursor keys back to the Go types of their fields, so the driver
+// binds e.g. timestamps as timestamps rather than strings. A nil key stands for NULL.
+func decodeSortKeys(raw []json.RawMessage, fields []sortKeyField) ([]interface{}, error) {
+	keys := make([]interface{}, len(raw))
+	for i, field := range fields {
+		value := reflect.New(field.typ)
+		if err := json.Unmarshal(raw[i], value.Interface()); err != nil {
+			return nil, ErrInvalidCursor
+		}
+
+		v := value.Elem()
+		for v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Pointer {
+			keys[i] = v.Interface()
+		}
+	}
+
+	return keys, nil
+}
+
+func boundaryCursor(signer *CursorSigner, orderKey string, fields []sortKeyField, row interface{}, backward bool) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(row))
+
+	keys := make([]json.RawMessage, len(fields))
+	for i, field := range fields {
+		key, err := json.Marshal(reflectx.FieldByIndexesReadOnly(v, field.index).Interface())
+		if err != nil {
+			return "", fmt.Errorf("failed to encode sort key %q: %w", field.term.Column, err)
+		}
+		keys[i] = key
+	}
+
+	return signer.encode(cursor{Order: orderKey, Keys: keys, Backward: backward})
+}
+
+// queryOrder returns the order to query in: the previous page is read in reverse and flipped afterwards.
+func queryOrder(order []SortTerm, backward bool) []SortTerm {
+	if !backward {
+		return order
+	}
+
+	reversed := make([]SortTerm, len(order))
+	for i, term := range order {
+		reversed[i] = SortTerm{Column: term.Column, Desc: !term.Desc}
+	}
+	return reversed
+}
+
+// keysetCondition matches the rows coming after keys in order, i.e. those greater on the first column,
+// or equal on it and greater on the second one, and so on. NULLs sort first, as they do in MySQL, and are
+// only accounted for in the columns flagged nullable.
+func keysetCondition(order []SortTerm, keys []interface{}, nullable []bool) (string, []interface{}) {
+	var alternatives []string
+	var args []interface{}
+
+	var equal []string
+	var equalArgs []interface{}
+	for i, term := range order {
+		after, afterArgs := afterCondition(term, keys[i], nullable[i])
+		if after != "" {
+			alternatives = append(alternatives, "("+strings.Join(append(slices.Clone(equal), after), " AND ")+")")
+			args = append(append(args, equalArgs...), afterArgs...)
+		}
+
+		if keys[i] == nil {
+			equal = append(equal, term.Column+" IS NULL")
+		} else {
+			equal = append(equal, term.Column+" = ?")
+			equalArgs = append(equalArgs, keys[i])
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return "1 = 0", nil
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// afterCondition matches the values of a column coming after key, or returns "" when none can.
+func afterCondition(term SortTerm, key interface{}, nullable bool) (string, []interface{}) {
+	switch {
+	case key == nil && term.Desc:
+		return "", nil
+	case key == nil:
+		return term.Column + " IS NOT NULL", nil
+	case term.Desc && !nullable:
+		return term.Column + " < ?", []interface{}{key}
+	case term.Desc:
+		return "(" + term.Column + " < ? OR " + term.Column + " IS NULL)", []interface{}{key}
+	default:
+		return term.Column + " > ?", []interface{}{key}
+	}
+}
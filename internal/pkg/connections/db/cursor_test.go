@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cursorRow struct {
+	ID        int
+	Label     string
+	UpdatedAt *time.Time
+}
+
+func newCursorDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	sqlx.NameMapper = strcase.ToSnake
+	return sqlx.NewDb(sqlDB, "mysql"), mock
+}
+
+func TestPaginateCursor(t *testing.T) {
+	sqlxDB, mock := newCursorDB(t)
+	signer := NewCursorSigner("secret")
+	order := []SortTerm{{Column: "label"}}
+	const base = "SELECT id, label, updated_at FROM products WHERE deleted_at IS NULL"
+	columns := []string{"id", "label", "updated_at"}
+
+	// First page: one extra row tells that a next page exists, and no count is run unless asked for.
+	mock.ExpectQuery(`SELECT \* FROM \(` + base + `\) AS page ORDER BY label ASC, id ASC LIMIT 3`).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "a", nil).AddRow(2, "b", nil).AddRow(3, "c", nil))
+
+	first := &CursorPagination{Signer: signer, Order: order, Limit: 2}
+	var rows []*cursorRow
+	require.NoError(t, PaginateCursor(context.Background(), sqlxDB, base, nil, first, &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[1].ID)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Empty(t, first.PrevCursor)
+	assert.Nil(t, first.TotalRows)
+
+	// Next page: rows after ("b", 2), counted on request.
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM \(` + base + `\) AS count`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT \* FROM \(`+base+`\) AS page WHERE \(\(label > \?\) OR \(label = \? AND id > \?\)\) ORDER BY label ASC, id ASC LIMIT 3`).
+		WithArgs("b", "b", 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "c", nil))
+
+	second := &CursorPagination{Signer: signer, Order: order, Limit: 2, Cursor: first.NextCursor, CountRows: true}
+	rows = nil
+	require.NoError(t, PaginateCursor(context.Background(), sqlxDB, base, nil, second, &rows))
+	require.Len(t, rows, 1)
+	assert.Empty(t, second.NextCursor)
+	assert.NotEmpty(t, second.PrevCursor)
+	require.NotNil(t, second.TotalRows)
+	assert.Equal(t, 3, *second.TotalRows)
+
+	// Previous page: read in reverse before ("c", 3) and flipped back.
+	mock.ExpectQuery(`SELECT \* FROM \(`+base+`\) AS page WHERE \(\(label < \?\) OR \(label = \? AND id < \?\)\) ORDER BY label DESC, id DESC LIMIT 3`).
+		WithArgs("c", "c", 3).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "b", nil).AddRow(1, "a", nil))
+
+	previous := &CursorPagination{Signer: signer, Order: order, Limit: 2, Cursor: second.PrevCursor}
+	rows = nil
+	require.NoError(t, PaginateCursor(context.Background(), sqlxDB, base, nil, previous, &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, []int{1, 2}, []int{rows[0].ID, rows[1].ID})
+	assert.NotEmpty(t, previous.NextCursor)
+	assert.Empty(t, previous.PrevCursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaginateCursor_RejectsInvalidCursors(t *testing.T) {
+	sqlxDB, _ := newCursorDB(t)
+	signer := NewCursorSigner("secret")
+
+	valid, err := signer.encode(cursor{Order: "label ASC, id ASC", Keys: nil})
+	require.NoError(t, err)
+	forged, err := NewCursorSigner("other").encode(cursor{Order: "id ASC", Keys: []json.RawMessage{[]byte("1")}})
+	require.NoError(t, err)
+
+	for name, raw := range map[string]string{
+		"garbage":       "not-a-cursor",
+		"wrong secret":  forged,
+		"changed order": valid,
+	} {
+		t.Run(name, func(t *testing.T) {
+			pagination := &CursorPagination{Signer: signer, Order: []SortTerm{{Column: "id"}}, Cursor: raw}
+			var rows []*cursorRow
+			assert.ErrorIs(t, PaginateCursor(context.Background(), sqlxDB, "SELECT id FROM products", nil, pagination, &rows), ErrInvalidCursor)
+		})
+	}
+}
+
+func TestKeysetCondition_Nulls(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	condition, args := keysetCondition([]SortTerm{{Column: "updated_at", Desc: true}, {Column: "id"}}, []interface{}{updatedAt, 7}, []bool{true, false})
+	assert.Equal(t, "(((updated_at < ? OR updated_at IS NULL)) OR (updated_at = ? AND id > ?))", condition)
+	assert.Equal(t, []interface{}{updatedAt, updatedAt, 7}, args)
+
+	condition, args = keysetCondition([]SortTerm{{Column: "updated_at"}, {Column: "id"}}, []interface{}{nil, 7}, []bool{true, false})
+	assert.Equal(t, "((updated_at IS NOT NULL) OR (updated_at IS NULL AND id > ?))", condition)
+	assert.Equal(t, []interface{}{7}, args)
+
+	condition, args = keysetCondition([]SortTerm{{Column: "updated_at", Desc: true}, {Column: "id"}}, []interface{}{nil, 7}, []bool{true, false})
+	assert.Equal(t, "((updated_at IS NULL AND id > ?))", condition)
+	assert.Equal(t, []interface{}{7}, args)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // Pagination holds parameters and metadata for paginated queries.
@@ -86,16 +88,10 @@ func Paginate[T any](
 	}
 	defer rows.Close()
 
-	// Populate the results slice.
-	for rows.Next() {
-		var result T
-		if err := rows.StructScan(&result); err != nil {
-			return fmt.Errorf("failed to scan row into struct: %w", err)
-		}
-		*results = append(*results, result)
-	}
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("row iteration error: %w", err)
+	// Populate the results slice. sqlx.StructScan handles both []T and []*T, whereas scanning
+	// row by row into a T only works when T is a struct, not a pointer to one.
+	if err := sqlx.StructScan(rows, results); err != nil {
+		return fmt.Errorf("failed to scan rows into structs: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortTerm orders by a single column.
+type SortTerm struct {
+	Column string
+	Desc   bool
+}
+
+// String renders the term as used in an ORDER BY clause.
+func (t SortTerm) String() string {
+	if t.Desc {
+		return t.Column + " DESC"
+	}
+	return t.Column + " ASC"
+}
+
+// Sort is an ORDER BY clause built only from whitelisted columns, so it is safe to use as Pagination.Order.
+// The zero value means "use the default order".
+type Sort struct {
+	terms []SortTerm
+}
+
+// ParseSort parses a comma separated list of fields, each optionally prefixed with "-" for descending
+// order (e.g. "-updated_at,label"), mapping every field to its column through columns.
+// Fields missing from columns are rejected. "id ASC" is appended as a tie-breaker so pages are stable.
+func ParseSort(raw string, columns map[string]string) (Sort, error) {
+	if strings.TrimSpace(raw) == "" {
+		return Sort{}, nil
+	}
+
+	var terms []SortTerm
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		column, ok := columns[field]
+		if !ok {
+			return Sort{}, fmt.Errorf("invalid sort field %q", field)
+		}
+		if seen[column] {
+			return Sort{}, fmt.Errorf("duplicate sort field %q", field)
+		}
+		seen[column] = true
+
+		terms = append(terms, SortTerm{Column: column, Desc: desc})
+	}
+
+	return Sort{terms: withIDTieBreaker(terms)}, nil
+}
+
+// OrderOr returns the sort clause, or defaultOrder when no sort was requested.
+func (s Sort) OrderOr(defaultOrder string) string {
+	if len(s.terms) == 0 {
+		return defaultOrder
+	}
+	return orderClause(s.terms)
+}
+
+// TermsOr returns the sort terms, or defaultTerms when no sort was requested.
+func (s Sort) TermsOr(defaultTerms ...SortTerm) []SortTerm {
+	if len(s.terms) == 0 {
+		return defaultTerms
+	}
+	return s.terms
+}
+
+// withIDTieBreaker appends "id ASC" unless terms already order by id.
+func withIDTieBreaker(terms []SortTerm) []SortTerm {
+	for _, term := range terms {
+		if term.Column == "id" {
+			return terms
+		}
+	}
+	return append(terms, SortTerm{Column: "id"})
+}
+
+func orderClause(terms []SortTerm) string {
+	rendered := make([]string, len(terms))
+	for i, term := range terms {
+		rendered[i] = term.String()
+	}
+	return strings.Join(rendered, ", ")
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	columns := map[string]string{"id": "id", "label": "label", "updated_at": "updated_at"}
+
+	sort, err := ParseSort("-updated_at, label", columns)
+	require.NoError(t, err)
+	assert.Equal(t, "updated_at DESC, label ASC, id ASC", sort.OrderOr("id ASC"))
+
+	sort, err = ParseSort("-id", columns)
+	require.NoError(t, err)
+	assert.Equal(t, "id DESC", sort.OrderOr("id ASC"))
+
+	sort, err = ParseSort("", columns)
+	require.NoError(t, err)
+	assert.Equal(t, "id ASC", sort.OrderOr("id ASC"))
+
+	_, err = ParseSort("label; DROP TABLE products", columns)
+	assert.Error(t, err)
+
+	_, err = ParseSort("label,-label", columns)
+	assert.Error(t, err)
+}
+
+func TestSort_TermsOr(t *testing.T) {
+	sort, err := ParseSort("-label", map[string]string{"label": "label"})
+	require.NoError(t, err)
+	assert.Equal(t, []SortTerm{{Column: "label", Desc: true}, {Column: "id"}}, sort.TermsOr(SortTerm{Column: "id", Desc: true}))
+
+	assert.Equal(t, []SortTerm{{Column: "id", Desc: true}}, Sort{}.TermsOr(SortTerm{Column: "id", Desc: true}))
+}
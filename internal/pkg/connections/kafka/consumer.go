@@ -2,8 +2,10 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
@@ -30,7 +32,10 @@ func NewKafkaConsumer(brokers, groupID, topic string) (*KafkaConsumer, error) {
 	}, nil
 }
 
-// Consume starts listening for Kafka messages and passes them to the handler.
+// pollTimeout bounds how long Consume waits for a message, so it notices ctx being done in time.
+const pollTimeout = time.Second
+
+// Consume starts listening for Kafka messages and passes them to the handler, returning once ctx is done.
 func (kc *KafkaConsumer) Consume(ctx context.Context, handleFunc func(ctx context.Context, message []byte) error) {
 	defer kc.consumer.Close()
 
@@ -44,8 +49,12 @@ func (kc *KafkaConsumer) Consume(ctx context.Context, handleFunc func(ctx contex
 			log.Println("Kafka consumer shutting down...")
 			return
 		default:
-			ev, err := kc.consumer.ReadMessage(-1)
+			ev, err := kc.consumer.ReadMessage(pollTimeout)
 			if err != nil {
+				var kafkaErr kafka.Error
+				if errors.As(err, &kafkaErr) && kafkaErr.IsTimeout() {
+					continue
+				}
 				log.Printf("Error reading Kafka message: %v", err)
 				continue
 			}
@@ -0,0 +1,80 @@
+package smtp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/connections/smtp"
+)
+
+// silentServer accepts connections and never sends the SMTP greeting.
+func silentServer(t *testing.T) (string, int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		<-done
+	})
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func TestClient_Send(t *testing.T) {
+	t.Run("times out on an unresponsive server", func(t *testing.T) {
+		host, port := silentServer(t)
+		client := smtp.NewSMTPClient(host, port, "", "", "alerts@example.com", 50*time.Millisecond)
+
+		start := time.Now()
+		err := client.Send(context.Background(), []string{"ops@example.com"}, "subject", "body")
+		// Either the connection deadline or the context timer fires first; both are timeouts.
+		var timeout interface{ Timeout() bool }
+		require.ErrorAs(t, err, &timeout)
+		assert.True(t, timeout.Timeout())
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		host, port := silentServer(t)
+		client := smtp.NewSMTPClient(host, port, "", "", "alerts@example.com", time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		err := client.Send(ctx, []string{"ops@example.com"}, "subject", "body")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("does not dial with a canceled context", func(t *testing.T) {
+		client := smtp.NewSMTPClient("127.0.0.1", 1, "", "", "alerts@example.com", time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := client.Send(ctx, []string{"ops@example.com"}, "subject", "body")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,68 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Client struct {
+	URL        string
+	Token      string
+	httpClient *http.Client
+}
+
+// NewTelegramClient creates a client for a Telegram-style bot API rooted at url.
+func NewTelegramClient(url, token string, timeout time.Duration) *Client {
+	return &Client{
+		URL:        url,
+		Token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type sendMessageRequest struct {
+	ChatID    string `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
+type apiResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// SendMessage posts a text message to the given chat. parseMode may be empty for plain text.
+func (c *Client) SendMessage(ctx context.Context, chatID, text, parseMode string) error {
+	payloadJSON, err := json.Marshal(sendMessageRequest{ChatID: chatID, Text: text, ParseMode: parseMode})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", c.URL, c.Token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payloadJSON))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The URL embeds the bot token, so it must not leak through the error message.
+		return fmt.Errorf("failed to send request to %s", c.URL)
+	}
+	defer resp.Body.Close()
+
+	var result apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response with status code %d: %w", resp.StatusCode, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("request failed with status code %d: %s", resp.StatusCode, result.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxErrorBodySize caps how much of an error response body is included in returned errors.
+const maxErrorBodySize = 1024
+
+type Client struct {
+	URL        string
+	httpClient *http.Client
+}
+
+// NewWebhookClient creates a client that posts JSON payloads to a single webhook URL.
+func NewWebhookClient(url string, timeout time.Duration) *Client {
+	return &Client{
+		URL:        url,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Post sends the payload as a JSON request body.
+func (c *Client) Post(ctx context.Context, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewBuffer(payloadJSON))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("request failed with status code %d: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc fetches the values of keys at once, such as with a single IN query. Keys missing from the
+// returned map load as the zero value.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches the loads of values by key, typically for the lifetime of a single request,
+// so that resolving a field of every item of a list takes one query rather than one per item.
+//
+// Keys are batched deterministically rather than over a time window: the keys of the items of a list are
+// primed as they are listed, then the first load of any of them fetches them all.
+type Loader[K comparable, V any] struct {
+	fetch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	primed  []K
+	results map[K]*result[V]
+}
+
+// result is the outcome of the load of a key, shared by the keys fetched in the same batch.
+type result[V any] struct {
+	batch *batch
+	value V
+}
+
+type batch struct {
+	done chan struct{}
+	err  error
+}
+
+// New creates a Loader fetching its values with fetch.
+func New[K comparable, V any](fetch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		results: map[K]*result[V]{},
+	}
+}
+
+// Prime registers keys to be fetched along with the next load, which keys already loaded are not.
+func (l *Loader[K, V]) Prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := l.results[key]; !ok {
+			l.primed = append(l.primed, key)
+		}
+	}
+}
+
+// Load returns the value of key, fetching it along with the primed keys unless it was loaded before.
+// Concurrent loads of keys of the same batch wait for the one fetching it.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	if res, ok := l.results[key]; ok {
+		l.mu.Unlock()
+		return res.wait(ctx)
+	}
+
+	b := &batch{done: make(chan struct{})}
+	keys := make([]K, 0, len(l.primed)+1)
+	results := make(map[K]*result[V], len(l.primed)+1)
+	for _, k := range append(l.primed, key) {
+		if _, ok := l.results[k]; ok {
+			continue
+		}
+		res := &result[V]{batch: b}
+		l.results[k] = res
+		results[k] = res
+		keys = append(keys, k)
+	}
+	l.primed = nil
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, keys)
+	for k, res := range results {
+		res.value = values[k]
+	}
+	b.err = err
+	close(b.done)
+
+	return results[key].wait(ctx)
+}
+
+func (r *result[V]) wait(ctx context.Context) (V, error) {
+	select {
+	case <-r.batch.done:
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+
+	if r.batch.err != nil {
+		var zero V
+		return zero, r.batch.err
+	}
+	return r.value, nil
+}
@@ -0,0 +1,99 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golang-boilerplate/internal/pkg/dataloader"
+)
+
+// recorder fetches the doubles of keys, recording the batches it is called with.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (r *recorder) fetch(_ context.Context, keys []int) (map[int]int, error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, keys)
+	r.mu.Unlock()
+
+	values := make(map[int]int, len(keys))
+	for _, key := range keys {
+		if key != 0 {
+			values[key] = key * 2
+		}
+	}
+	return values, r.err
+}
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fetches primed keys in one batch", func(t *testing.T) {
+		r := &recorder{}
+		loader := dataloader.New(r.fetch)
+		loader.Prime(1, 2, 3, 2)
+
+		var wg sync.WaitGroup
+		values := make([]int, 3)
+		for i := range values {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := loader.Load(ctx, i+1)
+				assert.NoError(t, err)
+				values[i] = value
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, []int{2, 4, 6}, values)
+		assert.Len(t, r.batches, 1)
+		assert.ElementsMatch(t, []int{1, 2, 3}, r.batches[0])
+	})
+
+	t.Run("caches loaded keys", func(t *testing.T) {
+		r := &recorder{}
+		loader := dataloader.New(r.fetch)
+
+		value, err := loader.Load(ctx, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, value)
+
+		// Priming a loaded key leaves it out of the next batch.
+		loader.Prime(4, 5)
+		value, err = loader.Load(ctx, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, value)
+		_, err = loader.Load(ctx, 5)
+		assert.NoError(t, err)
+
+		assert.Equal(t, [][]int{{4}, {5}}, r.batches)
+	})
+
+	t.Run("loads missing keys as the zero value", func(t *testing.T) {
+		loader := dataloader.New((&recorder{}).fetch)
+
+		value, err := loader.Load(ctx, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, value)
+	})
+
+	t.Run("fails every key of a failed batch", func(t *testing.T) {
+		r := &recorder{err: errors.New("db down")}
+		loader := dataloader.New(r.fetch)
+		loader.Prime(1, 2)
+
+		_, err := loader.Load(ctx, 1)
+		assert.EqualError(t, err, "db down")
+		_, err = loader.Load(ctx, 2)
+		assert.EqualError(t, err, "db down")
+		assert.Len(t, r.batches, 1)
+	})
+}
@@ -0,0 +1,39 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// csvWriter writes a header line followed by a line per record.
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVWriter(w io.Writer, header []string) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), record: make([]string, len(header))}
+	if err := cw.w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(record []interface{}) error {
+	for i, value := range record {
+		cw.record[i] = formatValue(value)
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return fmt.Errorf("failed to write csv record: %w", err)
+	}
+	return nil
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonWriter writes a JSON array with an object per record, keyed by the header.
+type jsonWriter struct {
+	w      io.Writer
+	header []string
+	count  int
+	buf    bytes.Buffer
+}
+
+func newJSONWriter(w io.Writer, header []string) *jsonWriter {
+	return &jsonWriter{w: w, header: header}
+}
+
+func (jw *jsonWriter) Write(record []interface{}) error {
+	jw.buf.Reset()
+	if jw.count == 0 {
+		jw.buf.WriteString("[\n")
+	} else {
+		jw.buf.WriteString(",\n")
+	}
+
+	// Objects are assembled by hand to keep the columns in header order.
+	jw.buf.WriteByte('{')
+	for i, value := range record {
+		if i > 0 {
+			jw.buf.WriteByte(',')
+		}
+		key, err := json.Marshal(jw.header[i])
+		if err != nil {
+			return fmt.Errorf("failed to encode json key: %w", err)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode json value of %s: %w", jw.header[i], err)
+		}
+		jw.buf.Write(key)
+		jw.buf.WriteByte(':')
+		jw.buf.Write(encoded)
+	}
+	jw.buf.WriteByte('}')
+
+	if _, err := jw.w.Write(jw.buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write json record: %w", err)
+	}
+	jw.count++
+	return nil
+}
+
+func (jw *jsonWriter) Close() error {
+	end := "\n]\n"
+	if jw.count == 0 {
+		end = "[]\n"
+	}
+	if _, err := io.WriteString(jw.w, end); err != nil {
+		return fmt.Errorf("failed to write json: %w", err)
+	}
+	return nil
+}
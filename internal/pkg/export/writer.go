@@ -0,0 +1,93 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ErrUnsupportedFormat is returned for an export format other than those listed in Formats.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatXLSX Format = "xlsx"
+)
+
+// Formats lists the supported export formats.
+var Formats = []Format{FormatCSV, FormatJSON, FormatXLSX}
+
+// ParseFormat returns the format with the given name, or ErrUnsupportedFormat.
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, name)
+}
+
+// ContentType returns the media type of files in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=UTF-8"
+	case FormatJSON:
+		return "application/json; charset=UTF-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Writer writes the records of an export one at a time. Every record holds a value per column of the
+// header the Writer was created with; values are strings, integers, booleans, times or nil.
+type Writer interface {
+	Write(record []interface{}) error
+	// Close completes the file. It does not close the underlying io.Writer.
+	Close() error
+}
+
+// NewWriter creates a Writer of the format writing to w, with the given column names.
+func NewWriter(format Format, w io.Writer, header []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, header)
+	case FormatJSON:
+		return newJSONWriter(w, header), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, header)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// formatValue renders a value as text, for formats without types.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testHeader  = []string{"id", "label", "is_active", "deactivated_at"}
+	testTime    = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	testRecords = [][]interface{}{
+		{1, "Pulsa, prepaid", true, (*time.Time)(nil)},
+		{2, "<Data>", false, &testTime},
+	}
+)
+
+func writeAll(t *testing.T, format Format) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, testHeader)
+	require.NoError(t, err)
+	for _, record := range testRecords {
+		require.NoError(t, w.Write(record))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("xlsx")
+	require.NoError(t, err)
+	assert.Equal(t, FormatXLSX, format)
+
+	_, err = ParseFormat("pdf")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestCSVWriter(t *testing.T) {
+	assert.Equal(t,
+		"id,label,is_active,deactivated_at\n"+
+			"1,\"Pulsa, prepaid\",true,\n"+
+			"2,<Data>,false,2026-10-19T12:00:00Z\n",
+		string(writeAll(t, FormatCSV)))
+}
+
+func TestJSONWriter(t *testing.T) {
+	var objects []map[string]interface{}
+	require.NoError(t, json.Unmarshal(writeAll(t, FormatJSON), &objects))
+	require.Len(t, objects, 2)
+	assert.Equal(t, map[string]interface{}{"id": 1.0, "label": "Pulsa, prepaid", "is_active": true, "deactivated_at": nil}, objects[0])
+	assert.Equal(t, "2026-10-19T12:00:00Z", objects[1]["deactivated_at"])
+
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatJSON, &buf, testHeader)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.JSONEq(t, "[]", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	content := writeAll(t, FormatXLSX)
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	var names []string
+	var sheet []byte
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			r, err := file.Open()
+			require.NoError(t, err)
+			sheet, err = io.ReadAll(r)
+			require.NoError(t, err)
+		}
+	}
+	assert.Contains(t, names, "[Content_Types].xml")
+	assert.Contains(t, names, "xl/workbook.xml")
+
+	assert.Contains(t, string(sheet), `<c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, string(sheet), `<row r="2"><c r="A2"><v>1</v></c>`)
+	assert.Contains(t, string(sheet), `<c r="C2" t="b"><v>1</v></c></row>`)
+	assert.Contains(t, string(sheet), `&lt;Data&gt;`)
+	assert.Contains(t, string(sheet), `<c r="D3" t="inlineStr"><is><t xml:space="preserve">2026-10-19T12:00:00Z</t></is></c>`)
+}
+
+func TestColumnName(t *testing.T) {
+	for index, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, columnName(index))
+	}
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// The package parts of a workbook with a single worksheet, apart from the worksheet itself.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter writes an Office Open XML workbook with the records on a single worksheet, header first.
+// The worksheet is the last part of the package, so rows are streamed into it as they are written.
+// Cells hold inline strings rather than shared ones, and times are written as RFC 3339 text, which
+// keeps the workbook free of a shared string table and styles.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+func newXLSXWriter(w io.Writer, header []string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create xlsx part %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(pw, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write xlsx part %s: %w", part.name, err)
+		}
+	}
+
+	sw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create xlsx worksheet: %w", err)
+	}
+	xw := &xlsxWriter{zip: zw, sheet: bufio.NewWriter(sw)}
+	xw.sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	record := make([]interface{}, len(header))
+	for i, name := range header {
+		record[i] = name
+	}
+	if err := xw.Write(record); err != nil {
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (xw *xlsxWriter) Write(record []interface{}) error {
+	xw.row++
+	row := strconv.Itoa(xw.row)
+
+	xw.sheet.WriteString(`<row r="` + row + `">`)
+	for i, value := range record {
+		ref := columnName(i) + row
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int:
+			xw.sheet.WriteString(`<c r="` + ref + `"><v>` + strconv.Itoa(v) + `</v></c>`)
+		case bool:
+			b := "0"
+			if v {
+				b = "1"
+			}
+			xw.sheet.WriteString(`<c r="` + ref + `" t="b"><v>` + b + `</v></c>`)
+		case *time.Time:
+			if v == nil {
+				continue
+			}
+			xw.writeString(ref, formatValue(v))
+		default:
+			xw.writeString(ref, formatValue(v))
+		}
+	}
+	xw.sheet.WriteString(`</row>`)
+
+	// bufio.Writer keeps the first error and reports it on every later call.
+	if _, err := xw.sheet.WriteString(""); err != nil {
+		return fmt.Errorf("failed to write xlsx row: %w", err)
+	}
+	return nil
+}
+
+func (xw *xlsxWriter) writeString(ref, value string) {
+	xw.sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(xw.sheet, []byte(value))
+	xw.sheet.WriteString(`</t></is></c>`)
+}
+
+func (xw *xlsxWriter) Close() error {
+	xw.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := xw.sheet.Flush(); err != nil {
+		return fmt.Errorf("failed to write xlsx worksheet: %w", err)
+	}
+	if err := xw.zip.Close(); err != nil {
+		return fmt.Errorf("failed to complete xlsx: %w", err)
+	}
+	return nil
+}
+
+// columnName returns the spreadsheet name of the zero-based column index: A to Z, then AA, AB and so on.
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
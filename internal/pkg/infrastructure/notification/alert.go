@@ -0,0 +1,117 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang-boilerplate/internal/pkg/infrastructure/ratelimit"
+	"golang-boilerplate/internal/pkg/logger"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+const eventClassAlert = "notification.alert"
+
+// DeactivationAlerter reports automatic product biller deactivations as they happen.
+type DeactivationAlerter interface {
+	Alert(ctx context.Context, deactivation models.ProductBillerDeactivation) error
+}
+
+// AlertGrouper rate limits deactivation alerts per product biller and groups those arriving within
+// a window into a single notification, so a burst of failures produces one message.
+type AlertGrouper struct {
+	notif   Notification
+	limiter ratelimit.RateLimiter
+	window  time.Duration
+
+	mu         sync.Mutex
+	pending    []models.ProductBillerDeactivation
+	suppressed int
+	timer      *time.Timer
+}
+
+// NewAlertGrouper creates an AlertGrouper sending grouped alerts through notif.
+func NewAlertGrouper(notif Notification, limiter ratelimit.RateLimiter, window time.Duration) *AlertGrouper {
+	return &AlertGrouper{
+		notif:   notif,
+		limiter: limiter,
+		window:  window,
+	}
+}
+
+// Alert adds the deactivation to the current group, starting a new group window if none is open.
+// Deactivations of a product biller alerted within the rate limit are only counted in the open group,
+// and dropped when none is open.
+func (g *AlertGrouper) Alert(ctx context.Context, deactivation models.ProductBillerDeactivation) error {
+	allowed, err := g.limiter.Allow(ctx, "alert:product_biller_deactivation:"+strconv.Itoa(deactivation.ProductBillerID))
+	if err != nil {
+		return fmt.Errorf("failed to rate limit deactivation alert: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !allowed {
+		if g.timer != nil {
+			g.suppressed++
+		}
+		return nil
+	}
+
+	g.pending = append(g.pending, deactivation)
+	g.open(ctx)
+
+	return nil
+}
+
+// open starts a group window unless one is already open. The caller must hold g.mu.
+func (g *AlertGrouper) open(ctx context.Context) {
+	if g.timer != nil {
+		return
+	}
+
+	// The group outlives the message that opened it, so it must not inherit its cancellation.
+	flushCtx := context.WithoutCancel(ctx)
+	g.timer = time.AfterFunc(g.window, func() {
+		if err := g.Flush(flushCtx); err != nil {
+			logger.FromContext(flushCtx).Error(flushCtx, eventClassAlert, "Flush", err.Error())
+		}
+	})
+}
+
+// Flush sends the pending group immediately, e.g. on shutdown. It does nothing when no group is open.
+// When sending fails, the group is put back in front of the open group and sent with it once its
+// window elapses.
+func (g *AlertGrouper) Flush(ctx context.Context) error {
+	g.mu.Lock()
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	pending, suppressed := g.pending, g.suppressed
+	g.pending, g.suppressed = nil, 0
+	g.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	payload := models.ProductBillerDeactivationNotification{
+		DateTime:      time.Now(),
+		Deactivations: pending,
+		Suppressed:    suppressed,
+	}
+	if err := g.notif.SendProductBillerDeactivation(ctx, payload); err != nil {
+		g.mu.Lock()
+		g.pending = append(pending, g.pending...)
+		g.suppressed += suppressed
+		g.open(ctx)
+		g.mu.Unlock()
+
+		return fmt.Errorf("failed to send product biller deactivation alert: %w", err)
+	}
+
+	return nil
+}
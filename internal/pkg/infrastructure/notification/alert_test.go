@@ -0,0 +1,102 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/pkg/infrastructure/notification"
+	"golang-boilerplate/internal/pkg/infrastructure/notification/mocks"
+	ratelimitMocks "golang-boilerplate/internal/pkg/infrastructure/ratelimit/mocks"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestAlertGrouper(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("groups deactivations and counts rate limited ones", func(t *testing.T) {
+		notif := new(mocks.MockNotification)
+		limiter := new(ratelimitMocks.MockRateLimiter)
+		grouper := notification.NewAlertGrouper(notif, limiter, time.Hour)
+
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:1").Return(true, nil)
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:2").Return(true, nil)
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:3").Return(false, nil)
+		notif.On("SendProductBillerDeactivation", ctx, mock.MatchedBy(func(n models.ProductBillerDeactivationNotification) bool {
+			return len(n.Deactivations) == 2 && n.Deactivations[0].ProductBillerID == 1 && n.Suppressed == 1
+		})).Return(nil).Once()
+
+		for _, id := range []int{1, 2, 3} {
+			assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: id}))
+		}
+		notif.AssertNotCalled(t, "SendProductBillerDeactivation", mock.Anything, mock.Anything)
+
+		assert.NoError(t, grouper.Flush(ctx))
+		// The group was sent, so a second flush has nothing to send.
+		assert.NoError(t, grouper.Flush(ctx))
+		notif.AssertExpectations(t)
+	})
+
+	t.Run("sends when the window closes", func(t *testing.T) {
+		notif := new(mocks.MockNotification)
+		limiter := new(ratelimitMocks.MockRateLimiter)
+		grouper := notification.NewAlertGrouper(notif, limiter, 10*time.Millisecond)
+
+		sent := make(chan struct{})
+		limiter.On("Allow", ctx, mock.Anything).Return(true, nil)
+		notif.On("SendProductBillerDeactivation", mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) { close(sent) }).Return(nil).Once()
+
+		assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: 1}))
+
+		select {
+		case <-sent:
+		case <-time.After(time.Second):
+			t.Fatal("alert was not sent after the group window")
+		}
+	})
+	t.Run("does not count rate limited deactivations outside a group", func(t *testing.T) {
+		notif := new(mocks.MockNotification)
+		limiter := new(ratelimitMocks.MockRateLimiter)
+		grouper := notification.NewAlertGrouper(notif, limiter, time.Hour)
+
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:1").Return(false, nil)
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:2").Return(true, nil)
+		notif.On("SendProductBillerDeactivation", ctx, mock.MatchedBy(func(n models.ProductBillerDeactivationNotification) bool {
+			return len(n.Deactivations) == 1 && n.Suppressed == 0
+		})).Return(nil).Once()
+
+		assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: 1}))
+		assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: 2}))
+		assert.NoError(t, grouper.Flush(ctx))
+		notif.AssertExpectations(t)
+	})
+
+	t.Run("keeps the group when sending fails", func(t *testing.T) {
+		notif := new(mocks.MockNotification)
+		limiter := new(ratelimitMocks.MockRateLimiter)
+		grouper := notification.NewAlertGrouper(notif, limiter, time.Hour)
+
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:1").Return(true, nil)
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:2").Return(false, nil)
+		limiter.On("Allow", ctx, "alert:product_biller_deactivation:3").Return(true, nil)
+		notif.On("SendProductBillerDeactivation", ctx, mock.Anything).Return(errors.New("database down")).Once()
+		notif.On("SendProductBillerDeactivation", ctx, mock.MatchedBy(func(n models.ProductBillerDeactivationNotification) bool {
+			return len(n.Deactivations) == 2 && n.Deactivations[0].ProductBillerID == 1 &&
+				n.Deactivations[1].ProductBillerID == 3 && n.Suppressed == 1
+		})).Return(nil).Once()
+
+		assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: 1}))
+		assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: 2}))
+		assert.ErrorContains(t, grouper.Flush(ctx), "database down")
+
+		// The failed group is sent along with the deactivations that arrive afterwards.
+		assert.NoError(t, grouper.Alert(ctx, models.ProductBillerDeactivation{ProductBillerID: 3}))
+		assert.NoError(t, grouper.Flush(ctx))
+		notif.AssertExpectations(t)
+	})
+}
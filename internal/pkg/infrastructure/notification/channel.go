@@ -0,0 +1,206 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang-boilerplate/internal/pkg/config"
+	"golang-boilerplate/internal/pkg/connections/cacabot"
+	"golang-boilerplate/internal/pkg/connections/smtp"
+	"golang-boilerplate/internal/pkg/connections/telegram"
+	"golang-boilerplate/internal/pkg/connections/webhook"
+)
+
+const (
+	ChannelCacabot  = "cacabot"
+	ChannelWebhook  = "webhook"
+	ChannelSlack    = "slack"
+	ChannelEmail    = "email"
+	ChannelTelegram = "telegram"
+)
+
+// Message is a notification addressed to every channel its type is routed to.
+type Message struct {
+	Type    string
+	Subject string
+	Payload interface{}
+}
+
+// Channel delivers messages to a single notification backend.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, message Message) error
+}
+
+// NewChannels creates a channel for Cacabot and for every other backend configured in cfg.
+func NewChannels(cfg *config.Notification, cacabotClient *cacabot.Client, renderer *Renderer) ([]Channel, error) {
+	channels := []Channel{&cacabotChannel{client: cacabotClient}}
+
+	if cfg.Webhook.URL != "" {
+		format, err := ParseFormat(cfg.Webhook.Format)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, &webhookChannel{
+			client:   webhook.NewWebhookClient(cfg.Webhook.URL, cfg.HTTPTimeout),
+			renderer: renderer,
+			format:   format,
+		})
+	}
+
+	if cfg.Slack.WebhookURL != "" {
+		format, err := ParseFormat(cfg.Slack.Format)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, &slackChannel{
+			client:   webhook.NewWebhookClient(cfg.Slack.WebhookURL, cfg.HTTPTimeout),
+			renderer: renderer,
+			format:   format,
+		})
+	}
+
+	if cfg.Email.Host != "" {
+		format, err := ParseFormat(cfg.Email.Format)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, &emailChannel{
+			client:   smtp.NewSMTPClient(cfg.Email.Host, cfg.Email.Port, cfg.Email.Username, cfg.Email.Password, cfg.Email.From, cfg.Email.Timeout),
+			to:       cfg.Email.To,
+			renderer: renderer,
+			format:   format,
+		})
+	}
+
+	if cfg.Telegram.BotToken != "" {
+		format, err := ParseFormat(cfg.Telegram.Format)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, &telegramChannel{
+			client:   telegram.NewTelegramClient(cfg.Telegram.URL, cfg.Telegram.BotToken, cfg.HTTPTimeout),
+			chatID:   cfg.Telegram.ChatID,
+			renderer: renderer,
+			format:   format,
+		})
+	}
+
+	return channels, nil
+}
+
+// cacabotChannel posts the raw payload to the Cacabot path derived from the message type.
+type cacabotChannel struct {
+	client *cacabot.Client
+}
+
+func (c *cacabotChannel) Name() string {
+	return ChannelCacabot
+}
+
+func (c *cacabotChannel) Send(ctx context.Context, message Message) error {
+	return c.client.SendMessage(ctx, "/"+strings.ReplaceAll(message.Type, "_", "-"), message.Payload)
+}
+
+// webhookChannel posts the rendered text together with the raw payload.
+type webhookChannel struct {
+	client   *webhook.Client
+	renderer *Renderer
+	format   Format
+}
+
+type webhookPayload struct {
+	Type    string      `json:"type"`
+	Text    string      `json:"text"`
+	Payload interface{} `json:"payload"`
+}
+
+func (c *webhookChannel) Name() string {
+	return ChannelWebhook
+}
+
+func (c *webhookChannel) Send(ctx context.Context, message Message) error {
+	text, err := c.renderer.Render(message.Type, c.format, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Post(ctx, webhookPayload{Type: message.Type, Text: text, Payload: message.Payload})
+}
+
+// slackChannel posts to a Slack-compatible incoming webhook.
+type slackChannel struct {
+	client   *webhook.Client
+	renderer *Renderer
+	format   Format
+}
+
+type slackPayload struct {
+	Text   string `json:"text"`
+	Mrkdwn bool   `json:"mrkdwn"`
+}
+
+func (c *slackChannel) Name() string {
+	return ChannelSlack
+}
+
+func (c *slackChannel) Send(ctx context.Context, message Message) error {
+	text, err := c.renderer.Render(message.Type, c.format, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Post(ctx, slackPayload{Text: text, Mrkdwn: c.format == FormatMarkdown})
+}
+
+// emailChannel sends the rendered message over SMTP.
+type emailChannel struct {
+	client   *smtp.Client
+	to       []string
+	renderer *Renderer
+	format   Format
+}
+
+func (c *emailChannel) Name() string {
+	return ChannelEmail
+}
+
+func (c *emailChannel) Send(ctx context.Context, message Message) error {
+	if len(c.to) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	body, err := c.renderer.Render(message.Type, c.format, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Send(ctx, c.to, message.Subject, body)
+}
+
+// telegramChannel sends the rendered message through a Telegram-style bot API.
+type telegramChannel struct {
+	client   *telegram.Client
+	chatID   string
+	renderer *Renderer
+	format   Format
+}
+
+func (c *telegramChannel) Name() string {
+	return ChannelTelegram
+}
+
+func (c *telegramChannel) Send(ctx context.Context, message Message) error {
+	text, err := c.renderer.Render(message.Type, c.format, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	parseMode := ""
+	if c.format == FormatMarkdown {
+		parseMode = "Markdown"
+	}
+
+	return c.client.SendMessage(ctx, c.chatID, text, parseMode)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/pkg/infrastructure/notification"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type MockNotification struct {
+	mock.Mock
+}
+
+func (m *MockNotification) SendProductBillerSummary(ctx context.Context, payload models.ProductBillerSummaryNotification) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+func (m *MockNotification) SendProductBillerDeactivation(ctx context.Context, payload models.ProductBillerDeactivationNotification) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+type MockDeactivationAlerter struct {
+	mock.Mock
+}
+
+func (m *MockDeactivationAlerter) Alert(ctx context.Context, deactivation models.ProductBillerDeactivation) error {
+	args := m.Called(ctx, deactivation)
+	return args.Error(0)
+}
+
+type MockDispatcher struct {
+	mock.Mock
+}
+
+func (m *MockDispatcher) Dispatch(ctx context.Context, message notification.Message, delivered []string) ([]string, error) {
+	args := m.Called(ctx, message, delivered)
+	sent, _ := args.Get(0).([]string)
+	return sent, args.Error(1)
+}
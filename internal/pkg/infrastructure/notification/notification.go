@@ -2,23 +2,89 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
-	"golang-boilerplate/internal/pkg/connections/cacabot"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
 	"golang-boilerplate/internal/pkg/models"
 )
 
+const (
+	TypeProductBillerSummary      = "product_biller_summary"
+	TypeProductBillerDeactivation = "product_biller_deactivation"
+)
+
+// payloadTypes creates the payload value each notification type is decoded into when dequeued,
+// so templates can use the typed fields.
+var payloadTypes = map[string]func() interface{}{
+	TypeProductBillerSummary:      func() interface{} { return &models.ProductBillerSummaryNotification{} },
+	TypeProductBillerDeactivation: func() interface{} { return &models.ProductBillerDeactivationNotification{} },
+}
+
+// Notification enqueues notifications for delivery by the dispatcher.
 type Notification interface {
 	SendProductBillerSummary(ctx context.Context, payload models.ProductBillerSummaryNotification) error
+	SendProductBillerDeactivation(ctx context.Context, payload models.ProductBillerDeactivationNotification) error
 }
 
 type notification struct {
-	client *cacabot.Client
+	repo repositories.NotificationRepository
 }
 
-func NewNotification(client *cacabot.Client) Notification {
-	return &notification{client: client}
+func NewNotification(repo repositories.NotificationRepository) Notification {
+	return &notification{repo: repo}
 }
 
 func (n *notification) SendProductBillerSummary(ctx context.Context, payload models.ProductBillerSummaryNotification) error {
-	return n.client.SendMessage(ctx, "/product-biller-summary", payload)
+	return n.enqueue(ctx, Message{
+		Type:    TypeProductBillerSummary,
+		Subject: "Product biller summary " + payload.DateTime.Format("2006-01-02"),
+		Payload: payload,
+	})
+}
+
+func (n *notification) SendProductBillerDeactivation(ctx context.Context, payload models.ProductBillerDeactivationNotification) error {
+	return n.enqueue(ctx, Message{
+		Type:    TypeProductBillerDeactivation,
+		Subject: fmt.Sprintf("%d product biller(s) deactivated", len(payload.Deactivations)),
+		Payload: payload,
+	})
+}
+
+func (n *notification) enqueue(ctx context.Context, message Message) error {
+	payload, err := json.Marshal(message.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s notification payload: %w", message.Type, err)
+	}
+
+	if err := n.repo.Create(ctx, &models.QueuedNotification{
+		Type:    message.Type,
+		Subject: message.Subject,
+		Payload: payload,
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue %s notification: %w", message.Type, err)
+	}
+
+	return nil
+}
+
+// Dispatcher delivers a message to its channels, skipping those it was already delivered to, and returns the
+// channels it was sent to even when others failed.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, message Message, delivered []string) ([]string, error)
+}
+
+// DecodeMessage rebuilds the message of a queued notification with its typed payload.
+func DecodeMessage(queued *models.QueuedNotification) (Message, error) {
+	newPayload, ok := payloadTypes[queued.Type]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown notification type: %q", queued.Type)
+	}
+
+	payload := newPayload()
+	if err := json.Unmarshal(queued.Payload, payload); err != nil {
+		return Message{}, fmt.Errorf("failed to unmarshal %s notification payload: %w", queued.Type, err)
+	}
+
+	return Message{Type: queued.Type, Subject: queued.Subject, Payload: payload}, nil
 }
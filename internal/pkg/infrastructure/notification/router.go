@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// defaultRoute matches every notification type without a route of its own.
+const defaultRoute = "*"
+
+// Router delivers messages to the channels their type is routed to.
+type Router struct {
+	channels map[string]Channel
+	routes   map[string][]string
+}
+
+// NewRouter parses routing rules of the form "type=channel,channel;*=channel".
+// Every channel named in a rule must be among the given channels.
+func NewRouter(channels []Channel, rules string) (*Router, error) {
+	router := &Router{
+		channels: make(map[string]Channel, len(channels)),
+		routes:   make(map[string][]string),
+	}
+	for _, channel := range channels {
+		router.channels[channel.Name()] = channel
+	}
+
+	for _, rule := range strings.Split(rules, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		notificationType, names, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid notification route %q: expected type=channel[,channel]", rule)
+		}
+		notificationType = strings.TrimSpace(notificationType)
+
+		for _, name := range strings.Split(names, ",") {
+			name = strings.TrimSpace(name)
+			if _, ok := router.channels[name]; !ok {
+				return nil, fmt.Errorf("notification route %q uses unconfigured channel %q", notificationType, name)
+			}
+			router.routes[notificationType] = append(router.routes[notificationType], name)
+		}
+	}
+
+	return router, nil
+}
+
+// Dispatch sends the message to every routed channel not in delivered, attempting all of them even if some
+// fail, and returns the channels it was sent to.
+func (r *Router) Dispatch(ctx context.Context, message Message, delivered []string) ([]string, error) {
+	names, ok := r.routes[message.Type]
+	if !ok {
+		names = r.routes[defaultRoute]
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no notification channel routed for type %q", message.Type)
+	}
+
+	var sent []string
+	var errs []error
+	for _, name := range names {
+		if slices.Contains(delivered, name) {
+			continue
+		}
+		if err := r.channels[name].Send(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("channel %s: %w", name, err))
+			continue
+		}
+		sent = append(sent, name)
+	}
+
+	return sent, errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChannel struct {
+	name string
+	err  error
+	sent []Message
+}
+
+func (c *fakeChannel) Name() string {
+	return c.name
+}
+
+func (c *fakeChannel) Send(_ context.Context, message Message) error {
+	c.sent = append(c.sent, message)
+	return c.err
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	cacabot := &fakeChannel{name: ChannelCacabot}
+	slack := &fakeChannel{name: ChannelSlack}
+	email := &fakeChannel{name: ChannelEmail, err: errors.New("smtp down")}
+
+	router, err := NewRouter([]Channel{cacabot, slack, email}, "product_biller_summary=slack, email; *=cacabot")
+	require.NoError(t, err)
+
+	sent, err := router.Dispatch(context.Background(), Message{Type: TypeProductBillerSummary}, nil)
+	assert.ErrorContains(t, err, "channel email: smtp down")
+	assert.Equal(t, []string{ChannelSlack}, sent)
+	assert.Len(t, slack.sent, 1)
+	assert.Len(t, email.sent, 1)
+	assert.Empty(t, cacabot.sent)
+
+	// A retry only goes to the channels that have not received the message yet.
+	email.err = nil
+	sent, err = router.Dispatch(context.Background(), Message{Type: TypeProductBillerSummary}, []string{ChannelSlack})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ChannelEmail}, sent)
+	assert.Len(t, slack.sent, 1)
+	assert.Len(t, email.sent, 2)
+
+	sent, err = router.Dispatch(context.Background(), Message{Type: "other"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ChannelCacabot}, sent)
+	assert.Len(t, cacabot.sent, 1)
+}
+
+func TestRouter_Errors(t *testing.T) {
+	cacabot := &fakeChannel{name: ChannelCacabot}
+
+	_, err := NewRouter([]Channel{cacabot}, "*=slack")
+	assert.ErrorContains(t, err, "unconfigured channel")
+
+	_, err = NewRouter([]Channel{cacabot}, "cacabot")
+	assert.ErrorContains(t, err, "invalid notification route")
+
+	router, err := NewRouter([]Channel{cacabot}, "product_biller_summary=cacabot")
+	require.NoError(t, err)
+	_, err = router.Dispatch(context.Background(), Message{Type: "other"}, nil)
+	assert.ErrorContains(t, err, "no notification channel")
+}
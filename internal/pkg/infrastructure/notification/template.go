@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+// Format is the markup a channel expects its messages in.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+)
+
+// ParseFormat validates a format name coming from configuration.
+func ParseFormat(name string) (Format, error) {
+	switch Format(name) {
+	case FormatText, FormatMarkdown:
+		return Format(name), nil
+	default:
+		return "", fmt.Errorf("unknown notification format: %q", name)
+	}
+}
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+var templateFuncs = template.FuncMap{
+	"datetime": func(t time.Time) string {
+		return t.Format("2006-01-02 15:04 MST")
+	},
+	"signed": func(n int) string {
+		if n > 0 {
+			return "+" + strconv.Itoa(n)
+		}
+		return strconv.Itoa(n)
+	},
+}
+
+// Renderer renders notification payloads with templates named "<type>.<format>.tmpl".
+type Renderer struct {
+	templates *template.Template
+}
+
+// NewRenderer loads the built-in templates, overridden by any "*.tmpl" file found in dir.
+func NewRenderer(dir string) (*Renderer, error) {
+	templates, err := template.New("").Funcs(templateFuncs).ParseFS(defaultTemplates, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default notification templates: %w", err)
+	}
+
+	if dir != "" {
+		overrides, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notification templates in %q: %w", dir, err)
+		}
+		if len(overrides) > 0 {
+			if templates, err = templates.ParseFiles(overrides...); err != nil {
+				return nil, fmt.Errorf("failed to parse notification templates in %q: %w", dir, err)
+			}
+		}
+	}
+
+	return &Renderer{templates: templates}, nil
+}
+
+// Render executes the template registered for the notification type and format.
+func (r *Renderer) Render(notificationType string, format Format, data interface{}) (string, error) {
+	name := fmt.Sprintf("%s.%s.tmpl", notificationType, format)
+
+	tmpl := r.templates.Lookup(name)
+	if tmpl == nil {
+		return "", fmt.Errorf("notification template %q not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render notification template %q: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	summary := models.ProductBillerSummaryNotification{
+		DateTime: now,
+		Total:    3,
+		Active:   2,
+		Inactive: 1,
+		Billers:  []models.ProductBillerGroupSummary{{ID: 1, Label: "Biller A", Total: 3, Active: 2, Inactive: 1}},
+		Deactivated: []models.DeactivatedProductBiller{
+			{ID: 7, ProductLabel: "Pulsa 10k", BillerLabel: "Biller A", DeactivatedAt: now, DeactivatedBy: "worker", Reason: "failed"},
+		},
+		Changes: &models.ProductBillerSummaryChanges{Since: now.Add(-24 * time.Hour), Total: 1, Active: -1, Inactive: 2},
+	}
+
+	renderer, err := NewRenderer("")
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatText, FormatMarkdown} {
+		text, err := renderer.Render(TypeProductBillerSummary, format, summary)
+		require.NoError(t, err)
+		assert.Contains(t, text, "Biller A")
+		assert.Contains(t, text, "Pulsa 10k")
+		assert.Contains(t, text, "+2")
+		assert.Contains(t, text, "-1")
+	}
+
+	_, err = renderer.Render("unknown", FormatText, summary)
+	assert.Error(t, err)
+}
+
+func TestRenderer_RenderManyProducts(t *testing.T) {
+	summary := models.ProductBillerSummaryNotification{DateTime: time.Now()}
+	for id := 1; id <= models.SummaryProductLimit+5; id++ {
+		summary.Products = append(summary.Products, models.ProductBillerGroupSummary{ID: id, Label: fmt.Sprintf("Product %02d", id), Total: 1, Active: 1})
+	}
+	// The last product has inactive product billers, so it is listed first.
+	last := &summary.Products[len(summary.Products)-1]
+	last.Active, last.Inactive = 0, 1
+	summary.Changes = &models.ProductBillerSummaryChanges{Since: summary.DateTime.Add(-24 * time.Hour), Products: summary.Products}
+
+	renderer, err := NewRenderer("")
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatText, FormatMarkdown} {
+		text, err := renderer.Render(TypeProductBillerSummary, format, summary)
+		require.NoError(t, err)
+		assert.Contains(t, text, "Product 25: 0/1 active")
+		assert.Contains(t, text, "Product 19: 1/1 active")
+		assert.NotContains(t, text, "Product 20: 1/1 active")
+		assert.Contains(t, text, "Product Product 20:", "changes are listed in their own order")
+		assert.NotContains(t, text, "Product Product 21:")
+		assert.Contains(t, text, "and 5 more products")
+	}
+}
+
+func TestRenderer_Override(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, TypeProductBillerSummary+".text.tmpl")
+	require.NoError(t, os.WriteFile(name, []byte("custom {{ .Total }}"), 0o600))
+
+	renderer, err := NewRenderer(dir)
+	require.NoError(t, err)
+
+	text, err := renderer.Render(TypeProductBillerSummary, FormatText, models.ProductBillerSummaryNotification{Total: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "custom 5", text)
+
+	// Formats without an override keep the built-in template.
+	text, err = renderer.Render(TypeProductBillerSummary, FormatMarkdown, models.ProductBillerSummaryNotification{Total: 5})
+	require.NoError(t, err)
+	assert.NotEqual(t, "custom 5", text)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("markdown")
+	require.NoError(t, err)
+	assert.Equal(t, FormatMarkdown, format)
+
+	_, err = ParseFormat("html")
+	assert.Error(t, err)
+}
+
+func TestRenderer_RenderDeactivation(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	alert := &models.ProductBillerDeactivationNotification{
+		DateTime: now,
+		Deactivations: []models.ProductBillerDeactivation{
+			{ProductBillerID: 7, ProductID: 1, ProductLabel: "Pulsa 10k", BillerID: 2, BillerLabel: "Biller A", TransactionID: 99, TransactionStatus: "failed", DeactivatedAt: now},
+		},
+		Suppressed: 4,
+	}
+
+	renderer, err := NewRenderer("")
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatText, FormatMarkdown} {
+		text, err := renderer.Render(TypeProductBillerDeactivation, format, alert)
+		require.NoError(t, err)
+		assert.Contains(t, text, "transaction 99")
+		assert.Contains(t, text, "Biller A")
+		assert.Contains(t, text, "4 further")
+	}
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimiter allows an action at most once per interval for each key, across all processes
+// sharing the same Redis.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string) (bool, error)
+}
+
+type rateLimiter struct {
+	client   *redis.Client
+	interval time.Duration
+}
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+func NewRateLimiter(client *redis.Client, interval time.Duration) RateLimiter {
+	return &rateLimiter{
+		client:   client,
+		interval: interval,
+	}
+}
+
+// Allow reports whether the action for key may proceed, reserving the key for the interval if so.
+func (r *rateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	ok, err := r.client.SetNX(ctx, rateLimitKeyPrefix+key, 1, r.interval).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	return ok, nil
+}
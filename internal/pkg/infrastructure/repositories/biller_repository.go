@@ -2,8 +2,7 @@ package repositories
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"time"
 
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/models"
@@ -11,139 +10,70 @@ import (
 
 // BillerRepository defines the interface for managing Biller entities.
 type BillerRepository interface {
-	Create(ctx context.Context, biller *models.Biller) error
-	Update(ctx context.Context, id int, biller *models.Biller) error
-	Delete(ctx context.Context, id int) error
+	Create(ctx context.Context, biller *models.Biller) (*models.Biller, error)
+	Update(ctx context.Context, id, version int, biller *models.Biller) error
+	Patch(ctx context.Context, id, version int, patch *models.BillerPatch) error
+	Delete(ctx context.Context, id, version int, batch string) error
+	Restore(ctx context.Context, id int) error
+	Purge(ctx context.Context, before time.Time, limit int) (int64, error)
 	FetchOne(ctx context.Context, id int) (*models.Biller, error)
-	FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Biller, error)
-	FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Biller, *db.Pagination, error)
+	FetchMany(ctx context.Context, filter models.BillerFilter) ([]*models.Biller, error)
+	FetchEach(ctx context.Context, filter models.BillerFilter, fn func(biller *models.Biller) error) error
+	FetchManyWithPagination(ctx context.Context, filter models.BillerFilter, page, limit int) ([]*models.Biller, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.BillerFilter, pagination *db.CursorPagination) ([]*models.Biller, error)
 }
 
-// billerRepository implements BillerRepository.
-type billerRepository struct {
-	db db.DBExecutor
+var billerTable = &Table[models.BillerFilter, models.BillerPatch]{
+	Name:               "billers",
+	Entity:             "biller",
+	Columns:            []string{"id", "label", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "deleted_batch", "version"},
+	InsertColumns:      []string{"label", "created_at", "created_by", "updated_at", "updated_by"},
+	UpdateColumns:      []string{"label", "updated_at"},
+	SoftDeleteColumn:   "deleted_at",
+	DeletedBatchColumn: "deleted_batch",
+	// Billers stay while product billers, even soft-deleted ones, still reference them.
+	PurgeConditions: []string{"NOT EXISTS (SELECT 1 FROM product_billers pb WHERE pb.biller_id = billers.id)"},
+	VersionColumn:   "version",
+	DefaultOrder:    db.SortTerm{Column: "id"},
+	Where: func(filter models.BillerFilter) ([]string, []interface{}) {
+		var conditions []string
+		var args []interface{}
+
+		conditions = append(conditions, softDeleteConditions(filter.IncludeDeleted)...)
+
+		if filter.ID != nil {
+			conditions = append(conditions, "id = ?")
+			args = append(args, *filter.ID)
+		}
+		if filter.IDs != nil {
+			idCond, idArgs := inCondition("id", filter.IDs)
+			conditions = append(conditions, idCond)
+			args = append(args, idArgs...)
+		}
+
+		labelConds, labelArgs := labelConditions(filter.Label, filter.LabelPrefix)
+		conditions = append(conditions, labelConds...)
+		args = append(args, labelArgs...)
+
+		createdConds, createdArgs := createdConditions(filter.ListFilter)
+		conditions = append(conditions, createdConds...)
+		args = append(args, createdArgs...)
+
+		return conditions, args
+	},
+	Sort: func(filter models.BillerFilter) db.Sort {
+		return filter.Sort
+	},
+	Patch: func(patch models.BillerPatch) map[string]interface{} {
+		values := map[string]interface{}{}
+		if patch.Label != nil {
+			values["label"] = *patch.Label
+		}
+		return values
+	},
 }
 
 // NewBillerRepository creates a new instance of BillerRepository.
 func NewBillerRepository(db db.DBExecutor) BillerRepository {
-	return &billerRepository{
-		db: db,
-	}
-}
-
-func (r *billerRepository) Create(ctx context.Context, biller *models.Biller) error {
-	const query = `
-		INSERT INTO billers 
-		(label, created_at, created_by, updated_at, updated_by)
-		VALUES (:label, NOW(6), :created_by, NOW(6), :updated_by)
-	`
-
-	_, err := r.db.NamedExecContext(ctx, query, biller)
-	if err != nil {
-		return fmt.Errorf("failed to create biller: %w", err)
-	}
-
-	return nil
-}
-
-func (r *billerRepository) Update(ctx context.Context, id int, biller *models.Biller) error {
-	const query = `
-		UPDATE billers
-		SET label = :label, updated_at = NOW(6)
-		WHERE id = :id AND deleted_at IS NULL
-	`
-
-	params := map[string]interface{}{
-		"id":    id,
-		"label": biller.Label,
-	}
-
-	_, err := r.db.NamedExecContext(ctx, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to update biller: %w", err)
-	}
-
-	return nil
-}
-
-func (r *billerRepository) Delete(ctx context.Context, id int) error {
-	const query = `
-		UPDATE billers
-		SET deleted_at = NOW(6)
-		WHERE id = :id AND deleted_at IS NULL
-	`
-
-	params := map[string]interface{}{
-		"id": id,
-	}
-
-	_, err := r.db.NamedExecContext(ctx, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to delete biller: %w", err)
-	}
-
-	return nil
-}
-
-func (r *billerRepository) getBaseQuery(filters map[string]interface{}) (string, []interface{}) {
-	var baseQuery = `
-		SELECT id, label, created_at, created_by, updated_at, updated_by
-		FROM billers
-	`
-
-	var conditions []string
-	var args []interface{}
-
-	conditions = append(conditions, "deleted_at IS NULL")
-
-	if id, ok := filters["id"].(int); ok {
-		conditions = append(conditions, "id = ?")
-		args = append(args, id)
-	}
-	if label, ok := filters["label"].(string); ok {
-		conditions = append(conditions, "label LIKE ?")
-		args = append(args, "%"+label+"%")
-	}
-
-	if len(conditions) > 0 {
-		baseQuery = fmt.Sprintf("%s WHERE %s", baseQuery, strings.Join(conditions, " AND "))
-	}
-
-	return baseQuery, args
-}
-
-func (r *billerRepository) FetchOne(ctx context.Context, id int) (*models.Biller, error) {
-	query, args := r.getBaseQuery(map[string]interface{}{
-		"id": id,
-	})
-
-	var biller models.Biller
-	if err := r.db.GetContext(ctx, &biller, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to fetch biller: %w", err)
-	}
-
-	return &biller, nil
-}
-
-func (r *billerRepository) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Biller, error) {
-	query, args := r.getBaseQuery(filter)
-
-	var billers []*models.Biller
-	if err := r.db.SelectContext(ctx, &billers, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to fetch billers: %w", err)
-	}
-
-	return billers, nil
-}
-
-func (r *billerRepository) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Biller, *db.Pagination, error) {
-	query, args := r.getBaseQuery(filter)
-
-	pagination := &db.Pagination{Order: "id ASC", Page: page, Limit: limit}
-	var billers []*models.Biller
-	if err := db.Paginate(ctx, r.db, query, args, pagination, &billers); err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch billers with pagination: %w", err)
-	}
-
-	return billers, pagination, nil
+	return NewRepository[models.Biller](db, billerTable)
 }
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ErrJobCanceled is returned by ReportProgress once cancellation of the job has been requested.
+var ErrJobCanceled = errors.New("job was canceled")
+
+// JobRepository defines the interface for the background job queue.
+type JobRepository interface {
+	Create(ctx context.Context, job *models.Job) (*models.Job, error)
+	FetchOne(ctx context.Context, id int) (*models.Job, error)
+	Claim(ctx context.Context, token string, staleBefore time.Time) (*models.Job, error)
+	ReportProgress(ctx context.Context, id int, token string, done, total int) error
+	Finish(ctx context.Context, id int, token, status string, result json.RawMessage, lastError *string) error
+	Cancel(ctx context.Context, id int) error
+}
+
+// jobRepository implements JobRepository.
+type jobRepository struct {
+	db db.DBExecutor
+}
+
+// NewJobRepository creates a new instance of JobRepository.
+func NewJobRepository(db db.DBExecutor) JobRepository {
+	return &jobRepository{
+		db: db,
+	}
+}
+
+const jobColumns = `
+	id, type, payload, status, progress_done, progress_total, result, last_error, cancel_requested, claim_token,
+	heartbeat_at, started_at, finished_at, created_at, created_by, updated_at
+`
+
+// Create enqueues a pending job and returns it as stored.
+func (r *jobRepository) Create(ctx context.Context, job *models.Job) (*models.Job, error) {
+	const query = `
+		INSERT INTO jobs (type, payload, status, created_at, created_by, updated_at)
+		VALUES (:type, :payload, :status, NOW(6), :created_by, NOW(6))
+	`
+
+	params := map[string]interface{}{
+		"type":       job.Type,
+		"payload":    []byte(job.Payload),
+		"status":     models.JobStatusPending,
+		"created_by": job.CreatedBy,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ID of created job: %w", err)
+	}
+
+	return r.FetchOne(ctx, int(id))
+}
+
+func (r *jobRepository) FetchOne(ctx context.Context, id int) (*models.Job, error) {
+	query := "SELECT " + jobColumns + " FROM jobs WHERE id = ?"
+
+	var job models.Job
+	if err := r.db.GetContext(ctx, &job, query, id); err != nil {
+		return nil, fmt.Errorf("failed to fetch job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// Claim marks the oldest pending job as running under token and returns it, or returns nil when no job is
+// waiting. Running jobs whose last heartbeat is before staleBefore were abandoned by their worker and are
+// claimed again, unless their cancellation was requested: those are finished as canceled instead. The claim
+// is a single UPDATE, so concurrent workers never claim the same job.
+func (r *jobRepository) Claim(ctx context.Context, token string, staleBefore time.Time) (*models.Job, error) {
+	if err := r.cancelAbandoned(ctx, staleBefore); err != nil {
+		return nil, err
+	}
+
+	const query = `
+		UPDATE jobs
+		SET status = :running, claim_token = :token, heartbeat_at = NOW(6), started_at = NOW(6), updated_at = NOW(6)
+		WHERE cancel_requested = 0 AND (status = :pending OR (status = :running AND heartbeat_at < :stale_before))
+		ORDER BY id ASC
+		LIMIT 1
+	`
+
+	params := map[string]interface{}{
+		"token":        token,
+		"pending":      models.JobStatusPending,
+		"running":      models.JobStatusRunning,
+		"stale_before": staleBefore,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	var job models.Job
+	if err := r.db.GetContext(ctx, &job, "SELECT "+jobColumns+" FROM jobs WHERE claim_token = ?", token); err != nil {
+		return nil, fmt.Errorf("failed to fetch claimed job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// abandonedCancellation is the error stored on a job canceled while its worker was gone.
+const abandonedCancellation = "canceled after its worker stopped"
+
+// cancelAbandoned finishes as canceled the running jobs whose cancellation was requested and whose last
+// heartbeat is before staleBefore, since no worker is left to honor the request.
+func (r *jobRepository) cancelAbandoned(ctx context.Context, staleBefore time.Time) error {
+	const query = `
+		UPDATE jobs
+		SET status = :canceled, last_error = :last_error, finished_at = NOW(6), updated_at = NOW(6)
+		WHERE status = :running AND cancel_requested = 1 AND heartbeat_at < :stale_before
+	`
+
+	params := map[string]interface{}{
+		"canceled":     models.JobStatusCanceled,
+		"last_error":   abandonedCancellation,
+		"running":      models.JobStatusRunning,
+		"stale_before": staleBefore,
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, query, params); err != nil {
+		return fmt.Errorf("failed to cancel abandoned jobs: %w", err)
+	}
+
+	return nil
+}
+
+// ReportProgress records the progress of a job run under token, which also serves as its heartbeat.
+// It returns ErrJobCanceled when cancellation was requested, and sql.ErrNoRows when the job was claimed
+// again by another worker in the meantime.
+func (r *jobRepository) ReportProgress(ctx context.Context, id int, token string, done, total int) error {
+	// The heartbeat changes on every report, so a row is affected whenever the job matches.
+	const query = `
+		UPDATE jobs
+		SET progress_done = :done, progress_total = :total, heartbeat_at = NOW(6), updated_at = NOW(6)
+		WHERE id = :id AND claim_token = :token AND status = :running AND cancel_requested = 0
+	`
+
+	params := map[string]interface{}{
+		"id":      id,
+		"token":   token,
+		"done":    done,
+		"total":   total,
+		"running": models.JobStatusRunning,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to report job progress: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to report job progress: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	job, err := r.FetchOne(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job.CancelRequested && job.ClaimToken != nil && *job.ClaimToken == token {
+		return ErrJobCanceled
+	}
+	return fmt.Errorf("job %d is no longer run under this claim: %w", id, sql.ErrNoRows)
+}
+
+// Finish stores the final status of a job run under token, along with its result or error.
+// It returns sql.ErrNoRows when the job was claimed again by another worker in the meantime.
+func (r *jobRepository) Finish(ctx context.Context, id int, token, status string, result json.RawMessage, lastError *string) error {
+	const query = `
+		UPDATE jobs
+		SET status = :status, result = :result, last_error = :last_error, finished_at = NOW(6), updated_at = NOW(6)
+		WHERE id = :id AND claim_token = :token AND status = :running
+	`
+
+	var resultValue interface{}
+	if result != nil {
+		resultValue = []byte(result)
+	}
+
+	params := map[string]interface{}{
+		"id":         id,
+		"token":      token,
+		"status":     status,
+		"result":     resultValue,
+		"last_error": lastError,
+		"running":    models.JobStatusRunning,
+	}
+
+	res, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("job %d is no longer run under this claim: %w", id, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Cancel cancels a pending job right away, and asks the worker running a running job to stop.
+// It returns sql.ErrNoRows when no pending or running job has the given ID.
+func (r *jobRepository) Cancel(ctx context.Context, id int) error {
+	// finished_at must be assigned before status: MySQL evaluates SET assignments left to right,
+	// so the later reference to status would see the new value.
+	const query = `
+		UPDATE jobs
+		SET cancel_requested = 1,
+			finished_at = CASE WHEN status = :pending THEN NOW(6) ELSE finished_at END,
+			status = CASE WHEN status = :pending THEN :canceled ELSE status END,
+			updated_at = NOW(6)
+		WHERE id = :id AND status IN (:pending, :running) AND cancel_requested = 0
+	`
+
+	params := map[string]interface{}{
+		"id":       id,
+		"pending":  models.JobStatusPending,
+		"running":  models.JobStatusRunning,
+		"canceled": models.JobStatusCanceled,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("failed to cancel job: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+)
+
+func newJobRepository(t *testing.T) (repositories.JobRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	sqlx.NameMapper = strcase.ToSnake
+	return repositories.NewJobRepository(sqlx.NewDb(db, "mysql")), mock
+}
+
+func TestJobRepository_Claim(t *testing.T) {
+	ctx := context.Background()
+	staleBefore := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cancelQuery := `UPDATE jobs SET status = \?, last_error = \?, .* WHERE status = \? AND cancel_requested = 1 AND heartbeat_at < \?`
+	claimQuery := `UPDATE jobs SET status = \?, claim_token = \?, .* WHERE cancel_requested = 0 AND \(status = \? OR \(status = \? AND heartbeat_at < \?\)\) ORDER BY id ASC LIMIT 1`
+
+	t.Run("claims the next job", func(t *testing.T) {
+		repo, mock := newJobRepository(t)
+
+		mock.ExpectExec(cancelQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(claimQuery).
+			WithArgs("running", "token", "pending", "running", staleBefore).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM jobs WHERE claim_token = \?`).
+			WithArgs("token").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type", "payload", "status", "claim_token"}).
+				AddRow(3, "product_biller_import", []byte(`{"rows":[]}`), "running", "token"))
+
+		job, err := repo.Claim(ctx, "token", staleBefore)
+		require.NoError(t, err)
+		assert.Equal(t, 3, job.ID)
+		assert.JSONEq(t, `{"rows":[]}`, string(job.Payload))
+		assert.Equal(t, "token", *job.ClaimToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty queue", func(t *testing.T) {
+		repo, mock := newJobRepository(t)
+
+		mock.ExpectExec(cancelQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(claimQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		job, err := repo.Claim(ctx, "token", staleBefore)
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("finishes abandoned jobs whose cancellation was requested", func(t *testing.T) {
+		repo, mock := newJobRepository(t)
+
+		mock.ExpectExec(cancelQuery).
+			WithArgs("canceled", "canceled after its worker stopped", "running", staleBefore).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(claimQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		job, err := repo.Claim(ctx, "token", staleBefore)
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_ReportProgress(t *testing.T) {
+	ctx := context.Background()
+	progressQuery := `UPDATE jobs SET progress_done = \?, progress_total = \?, .* WHERE id = \? AND claim_token = \? AND status = \? AND cancel_requested = 0`
+
+	t.Run("canceled", func(t *testing.T) {
+		repo, mock := newJobRepository(t)
+
+		mock.ExpectExec(progressQuery).
+			WithArgs(5, 10, 3, "token", "running").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT .* FROM jobs WHERE id = \?`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "cancel_requested", "claim_token"}).
+				AddRow(3, "running", true, "token"))
+
+		err := repo.ReportProgress(ctx, 3, "token", 5, 10)
+		assert.ErrorIs(t, err, repositories.ErrJobCanceled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("claimed by another worker", func(t *testing.T) {
+		repo, mock := newJobRepository(t)
+
+		mock.ExpectExec(progressQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT .* FROM jobs WHERE id = \?`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "cancel_requested", "claim_token"}).
+				AddRow(3, "running", false, "other"))
+
+		err := repo.ReportProgress(ctx, 3, "token", 5, 10)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"strings"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// Sortable columns of each entity, keyed by the field name accepted in the sort query parameter.
+var (
+	ProductSortColumns = map[string]string{
+		"id": "id", "label": "label", "created_at": "created_at", "updated_at": "updated_at",
+	}
+	BillerSortColumns = map[string]string{
+		"id": "id", "label": "label", "created_at": "created_at", "updated_at": "updated_at",
+	}
+	ProductBillerSortColumns = map[string]string{
+		"id": "id", "product_id": "product_id", "biller_id": "biller_id", "is_active": "is_active",
+		"priority": "priority", "weight": "weight", "created_at": "created_at", "updated_at": "updated_at",
+	}
+	NotificationSortColumns = map[string]string{
+		"id": "id", "type": "type", "status": "status", "attempts": "attempts",
+		"next_attempt_at": "next_attempt_at", "created_at": "created_at", "updated_at": "updated_at",
+	}
+)
+
+// softDeleteConditions excludes soft-deleted rows unless includeDeleted is set.
+func softDeleteConditions(includeDeleted bool) []string {
+	if includeDeleted {
+		return nil
+	}
+	return []string{"deleted_at IS NULL"}
+}
+
+// createdConditions applies the created_at range of the list filter, inclusive of CreatedFrom and exclusive of CreatedTo.
+func createdConditions(filter models.ListFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.CreatedTo)
+	}
+
+	return conditions, args
+}
+
+// labelConditions applies the label contains and prefix searches.
+func labelConditions(label, prefix string) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if label != "" {
+		conditions = append(conditions, "label LIKE ?")
+		args = append(args, "%"+escapeLike(label)+"%")
+	}
+	if prefix != "" {
+		conditions = append(conditions, "label LIKE ?")
+		args = append(args, escapeLike(prefix)+"%")
+	}
+
+	return conditions, args
+}
+
+// inCondition matches the rows whose column holds one of values, and none when values is empty.
+func inCondition(column string, values []int) (string, []interface{}) {
+	if len(values) == 0 {
+		return "1 = 0", nil
+	}
+
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return column + " IN (?" + strings.Repeat(", ?", len(values)-1) + ")", args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes the LIKE wildcards in s so it is matched literally.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -13,21 +14,39 @@ type MockBillerRepository struct {
 	mock.Mock
 }
 
-func (m *MockBillerRepository) Create(ctx context.Context, biller *models.Biller) error {
+func (m *MockBillerRepository) Create(ctx context.Context, biller *models.Biller) (*models.Biller, error) {
 	args := m.Called(ctx, biller)
+	if p, ok := args.Get(0).(*models.Biller); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBillerRepository) Update(ctx context.Context, id, version int, biller *models.Biller) error {
+	args := m.Called(ctx, id, version, biller)
 	return args.Error(0)
 }
 
-func (m *MockBillerRepository) Update(ctx context.Context, id int, biller *models.Biller) error {
-	args := m.Called(ctx, id, biller)
+func (m *MockBillerRepository) Patch(ctx context.Context, id, version int, patch *models.BillerPatch) error {
+	args := m.Called(ctx, id, version, patch)
 	return args.Error(0)
 }
 
-func (m *MockBillerRepository) Delete(ctx context.Context, id int) error {
+func (m *MockBillerRepository) Delete(ctx context.Context, id, version int, batch string) error {
+	args := m.Called(ctx, id, version, batch)
+	return args.Error(0)
+}
+
+func (m *MockBillerRepository) Restore(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockBillerRepository) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockBillerRepository) FetchOne(ctx context.Context, id int) (*models.Biller, error) {
 	args := m.Called(ctx, id)
 	if b, ok := args.Get(0).(*models.Biller); ok {
@@ -36,7 +55,7 @@ func (m *MockBillerRepository) FetchOne(ctx context.Context, id int) (*models.Bi
 	return nil, args.Error(1)
 }
 
-func (m *MockBillerRepository) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Biller, error) {
+func (m *MockBillerRepository) FetchMany(ctx context.Context, filter models.BillerFilter) ([]*models.Biller, error) {
 	args := m.Called(ctx, filter)
 	if b, ok := args.Get(0).([]*models.Biller); ok {
 		return b, args.Error(1)
@@ -44,7 +63,20 @@ func (m *MockBillerRepository) FetchMany(ctx context.Context, filter map[string]
 	return nil, args.Error(1)
 }
 
-func (m *MockBillerRepository) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Biller, *db.Pagination, error) {
+// FetchEach passes the billers given to Return to fn, as the repository would stream them.
+func (m *MockBillerRepository) FetchEach(ctx context.Context, filter models.BillerFilter, fn func(biller *models.Biller) error) error {
+	args := m.Called(ctx, filter, fn)
+	if billers, ok := args.Get(0).([]*models.Biller); ok {
+		for _, biller := range billers {
+			if err := fn(biller); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockBillerRepository) FetchManyWithPagination(ctx context.Context, filter models.BillerFilter, page, limit int) ([]*models.Biller, *db.Pagination, error) {
 	args := m.Called(ctx, filter, page, limit)
 	if b, ok := args.Get(0).([]*models.Biller); ok {
 		if p, ok := args.Get(1).(*db.Pagination); ok {
@@ -53,3 +85,11 @@ func (m *MockBillerRepository) FetchManyWithPagination(ctx context.Context, filt
 	}
 	return nil, nil, args.Error(2)
 }
+
+func (m *MockBillerRepository) FetchManyWithCursor(ctx context.Context, filter models.BillerFilter, pagination *db.CursorPagination) ([]*models.Biller, error) {
+	args := m.Called(ctx, filter, pagination)
+	if p, ok := args.Get(0).([]*models.Biller); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
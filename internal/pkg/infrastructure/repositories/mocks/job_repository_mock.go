@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type MockJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobRepository) Create(ctx context.Context, job *models.Job) (*models.Job, error) {
+	args := m.Called(ctx, job)
+	if j, ok := args.Get(0).(*models.Job); ok {
+		return j, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockJobRepository) FetchOne(ctx context.Context, id int) (*models.Job, error) {
+	args := m.Called(ctx, id)
+	if j, ok := args.Get(0).(*models.Job); ok {
+		return j, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockJobRepository) Claim(ctx context.Context, token string, staleBefore time.Time) (*models.Job, error) {
+	args := m.Called(ctx, token, staleBefore)
+	if j, ok := args.Get(0).(*models.Job); ok {
+		return j, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockJobRepository) ReportProgress(ctx context.Context, id int, token string, done, total int) error {
+	args := m.Called(ctx, id, token, done, total)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) Finish(ctx context.Context, id int, token, status string, result json.RawMessage, lastError *string) error {
+	args := m.Called(ctx, id, token, status, result, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) Cancel(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
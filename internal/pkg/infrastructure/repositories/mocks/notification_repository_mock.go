@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *models.QueuedNotification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) UpdateDelivery(ctx context.Context, notification *models.QueuedNotification, token string) error {
+	args := m.Called(ctx, notification, token)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) Resend(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) FetchOne(ctx context.Context, id int) (*models.QueuedNotification, error) {
+	args := m.Called(ctx, id)
+	if n, ok := args.Get(0).(*models.QueuedNotification); ok {
+		return n, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) Claim(ctx context.Context, token string, now, leaseUntil time.Time, limit int) ([]*models.QueuedNotification, error) {
+	args := m.Called(ctx, token, now, leaseUntil, limit)
+	if n, ok := args.Get(0).([]*models.QueuedNotification); ok {
+		return n, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) FetchManyWithPagination(ctx context.Context, filter models.NotificationFilter, page, limit int) ([]*models.QueuedNotification, *db.Pagination, error) {
+	args := m.Called(ctx, filter, page, limit)
+	n, _ := args.Get(0).([]*models.QueuedNotification)
+	p, _ := args.Get(1).(*db.Pagination)
+	return n, p, args.Error(2)
+}
+
+func (m *MockNotificationRepository) FetchManyWithCursor(ctx context.Context, filter models.NotificationFilter, pagination *db.CursorPagination) ([]*models.QueuedNotification, error) {
+	args := m.Called(ctx, filter, pagination)
+	if p, ok := args.Get(0).([]*models.QueuedNotification); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
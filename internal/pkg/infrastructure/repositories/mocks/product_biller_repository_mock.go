@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -13,28 +14,66 @@ type MockProductBillerRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductBillerRepository) Create(ctx context.Context, productBiller *models.ProductBiller) error {
+func (m *MockProductBillerRepository) Create(ctx context.Context, productBiller *models.ProductBiller) (*models.ProductBiller, error) {
 	args := m.Called(ctx, productBiller)
+	if p, ok := args.Get(0).(*models.ProductBiller); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProductBillerRepository) Update(ctx context.Context, id, version int, productBiller *models.ProductBiller) error {
+	args := m.Called(ctx, id, version, productBiller)
+	return args.Error(0)
+}
+
+func (m *MockProductBillerRepository) Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error {
+	args := m.Called(ctx, id, version, patch)
+	return args.Error(0)
+}
+
+func (m *MockProductBillerRepository) Deactivate(ctx context.Context, id int, deactivatedBy, reason string) error {
+	args := m.Called(ctx, id, deactivatedBy, reason)
 	return args.Error(0)
 }
 
-func (m *MockProductBillerRepository) Update(ctx context.Context, id int, productBiller *models.ProductBiller) error {
-	args := m.Called(ctx, id, productBiller)
+func (m *MockProductBillerRepository) Delete(ctx context.Context, id, version int, batch string) error {
+	args := m.Called(ctx, id, version, batch)
 	return args.Error(0)
 }
 
-func (m *MockProductBillerRepository) Delete(ctx context.Context, id int) error {
+func (m *MockProductBillerRepository) Restore(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockProductBillerRepository) DeleteByProductID(ctx context.Context, productID int) error {
-	args := m.Called(ctx, productID)
+func (m *MockProductBillerRepository) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductBillerRepository) RestoreBatch(ctx context.Context, batch string) (int64, error) {
+	args := m.Called(ctx, batch)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductBillerRepository) DeactivateByProductID(ctx context.Context, productID int, deactivatedBy, reason string) error {
+	args := m.Called(ctx, productID, deactivatedBy, reason)
+	return args.Error(0)
+}
+
+func (m *MockProductBillerRepository) DeactivateByBillerID(ctx context.Context, billerID int, deactivatedBy, reason string) error {
+	args := m.Called(ctx, billerID, deactivatedBy, reason)
+	return args.Error(0)
+}
+
+func (m *MockProductBillerRepository) DeleteByProductID(ctx context.Context, productID int, batch string) error {
+	args := m.Called(ctx, productID, batch)
 	return args.Error(0)
 }
 
-func (m *MockProductBillerRepository) DeleteByBillerID(ctx context.Context, billerID int) error {
-	args := m.Called(ctx, billerID)
+func (m *MockProductBillerRepository) DeleteByBillerID(ctx context.Context, billerID int, batch string) error {
+	args := m.Called(ctx, billerID, batch)
 	return args.Error(0)
 }
 
@@ -46,7 +85,7 @@ func (m *MockProductBillerRepository) FetchOne(ctx context.Context, id int) (*mo
 	return nil, args.Error(1)
 }
 
-func (m *MockProductBillerRepository) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.ProductBiller, error) {
+func (m *MockProductBillerRepository) FetchMany(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBiller, error) {
 	args := m.Called(ctx, filter)
 	if pbs, ok := args.Get(0).([]*models.ProductBiller); ok {
 		return pbs, args.Error(1)
@@ -54,7 +93,20 @@ func (m *MockProductBillerRepository) FetchMany(ctx context.Context, filter map[
 	return nil, args.Error(1)
 }
 
-func (m *MockProductBillerRepository) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.ProductBiller, *db.Pagination, error) {
+// FetchEach passes the product billers given to Return to fn, as the repository would stream them.
+func (m *MockProductBillerRepository) FetchEach(ctx context.Context, filter models.ProductBillerFilter, fn func(productBiller *models.ProductBiller) error) error {
+	args := m.Called(ctx, filter, fn)
+	if productBillers, ok := args.Get(0).([]*models.ProductBiller); ok {
+		for _, pb := range productBillers {
+			if err := fn(pb); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockProductBillerRepository) FetchManyWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBiller, *db.Pagination, error) {
 	args := m.Called(ctx, filter, page, limit)
 	if pbs, ok := args.Get(0).([]*models.ProductBiller); ok {
 		if pagination, ok := args.Get(1).(*db.Pagination); ok {
@@ -63,3 +115,45 @@ func (m *MockProductBillerRepository) FetchManyWithPagination(ctx context.Contex
 	}
 	return nil, nil, args.Error(2)
 }
+
+func (m *MockProductBillerRepository) FetchManyWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBiller, error) {
+	args := m.Called(ctx, filter, pagination)
+	if p, ok := args.Get(0).([]*models.ProductBiller); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProductBillerRepository) FetchManyDetailed(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBillerDetail, error) {
+	args := m.Called(ctx, filter)
+	if p, ok := args.Get(0).([]*models.ProductBillerDetail); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProductBillerRepository) FetchManyDetailedWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBillerDetail, *db.Pagination, error) {
+	args := m.Called(ctx, filter, page, limit)
+	if details, ok := args.Get(0).([]*models.ProductBillerDetail); ok {
+		if pagination, ok := args.Get(1).(*db.Pagination); ok {
+			return details, pagination, args.Error(2)
+		}
+	}
+	return nil, nil, args.Error(2)
+}
+
+func (m *MockProductBillerRepository) FetchManyDetailedWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBillerDetail, error) {
+	args := m.Called(ctx, filter, pagination)
+	if p, ok := args.Get(0).([]*models.ProductBillerDetail); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProductBillerRepository) Summarize(ctx context.Context) (*models.ProductBillerSummary, error) {
+	args := m.Called(ctx)
+	if s, ok := args.Get(0).(*models.ProductBillerSummary); ok {
+		return s, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
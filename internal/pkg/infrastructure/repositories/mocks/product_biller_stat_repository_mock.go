@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type MockProductBillerStatRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductBillerStatRepository) Record(ctx context.Context, transactionID, productBillerID int, success bool, at time.Time) error {
+	args := m.Called(ctx, transactionID, productBillerID, success, at)
+	return args.Error(0)
+}
+
+func (m *MockProductBillerStatRepository) FetchMany(ctx context.Context, productBillerID int, bucket string, from, to time.Time) ([]*models.ProductBillerStat, error) {
+	args := m.Called(ctx, productBillerID, bucket, from, to)
+	if s, ok := args.Get(0).([]*models.ProductBillerStat); ok {
+		return s, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProductBillerStatRepository) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductBillerStatRepository) PurgeTransactions(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
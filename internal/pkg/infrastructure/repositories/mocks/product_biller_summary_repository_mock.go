@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+type MockProductBillerSummaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductBillerSummaryRepository) Create(ctx context.Context, summary *models.ProductBillerSummaryNotification) error {
+	args := m.Called(ctx, summary)
+	return args.Error(0)
+}
+
+func (m *MockProductBillerSummaryRepository) FetchLatest(ctx context.Context) (*models.ProductBillerSummaryNotification, error) {
+	args := m.Called(ctx)
+	if s, ok := args.Get(0).(*models.ProductBillerSummaryNotification); ok {
+		return s, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -13,21 +14,39 @@ type MockProductRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) error {
+func (m *MockProductRepository) Create(ctx context.Context, product *models.Product) (*models.Product, error) {
 	args := m.Called(ctx, product)
+	if p, ok := args.Get(0).(*models.Product); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, id, version int, product *models.Product) error {
+	args := m.Called(ctx, id, version, product)
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) Update(ctx context.Context, id int, product *models.Product) error {
-	args := m.Called(ctx, id, product)
+func (m *MockProductRepository) Patch(ctx context.Context, id, version int, patch *models.ProductPatch) error {
+	args := m.Called(ctx, id, version, patch)
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) Delete(ctx context.Context, id int) error {
+func (m *MockProductRepository) Delete(ctx context.Context, id, version int, batch string) error {
+	args := m.Called(ctx, id, version, batch)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Restore(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockProductRepository) FetchOne(ctx context.Context, id int) (*models.Product, error) {
 	args := m.Called(ctx, id)
 	if p, ok := args.Get(0).(*models.Product); ok {
@@ -36,7 +55,7 @@ func (m *MockProductRepository) FetchOne(ctx context.Context, id int) (*models.P
 	return nil, args.Error(1)
 }
 
-func (m *MockProductRepository) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Product, error) {
+func (m *MockProductRepository) FetchMany(ctx context.Context, filter models.ProductFilter) ([]*models.Product, error) {
 	args := m.Called(ctx, filter)
 	if p, ok := args.Get(0).([]*models.Product); ok {
 		return p, args.Error(1)
@@ -44,7 +63,20 @@ func (m *MockProductRepository) FetchMany(ctx context.Context, filter map[string
 	return nil, args.Error(1)
 }
 
-func (m *MockProductRepository) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Product, *db.Pagination, error) {
+// FetchEach passes the products given to Return to fn, as the repository would stream them.
+func (m *MockProductRepository) FetchEach(ctx context.Context, filter models.ProductFilter, fn func(product *models.Product) error) error {
+	args := m.Called(ctx, filter, fn)
+	if products, ok := args.Get(0).([]*models.Product); ok {
+		for _, product := range products {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockProductRepository) FetchManyWithPagination(ctx context.Context, filter models.ProductFilter, page, limit int) ([]*models.Product, *db.Pagination, error) {
 	args := m.Called(ctx, filter, page, limit)
 	if p, ok := args.Get(0).([]*models.Product); ok {
 		if pagination, ok := args.Get(1).(*db.Pagination); ok {
@@ -53,3 +85,11 @@ func (m *MockProductRepository) FetchManyWithPagination(ctx context.Context, fil
 	}
 	return nil, nil, args.Error(2)
 }
+
+func (m *MockProductRepository) FetchManyWithCursor(ctx context.Context, filter models.ProductFilter, pagination *db.CursorPagination) ([]*models.Product, error) {
+	args := m.Called(ctx, filter, pagination)
+	if p, ok := args.Get(0).([]*models.Product); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -15,7 +15,10 @@ type MockUnitOfWork struct {
 func (m *MockUnitOfWork) Execute(ctx context.Context, fn func(uow repositories.UnitOfWork) error) error {
 	args := m.Called(ctx, fn)
 	if fn != nil {
-		_ = fn(m) // Call the function with the mock
+		// Call the function with the mock, failing like a transaction would when it fails
+		if err := fn(m); err != nil {
+			return err
+		}
 	}
 	return args.Error(0)
 }
@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// NotificationRepository defines the interface for the notification delivery queue.
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.QueuedNotification) error
+	UpdateDelivery(ctx context.Context, notification *models.QueuedNotification, token string) error
+	Resend(ctx context.Context, id int) error
+	FetchOne(ctx context.Context, id int) (*models.QueuedNotification, error)
+	Claim(ctx context.Context, token string, now, leaseUntil time.Time, limit int) ([]*models.QueuedNotification, error)
+	FetchManyWithPagination(ctx context.Context, filter models.NotificationFilter, page, limit int) ([]*models.QueuedNotification, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.NotificationFilter, pagination *db.CursorPagination) ([]*models.QueuedNotification, error)
+}
+
+// notificationRepository implements NotificationRepository.
+type notificationRepository struct {
+	db db.DBExecutor
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository.
+func NewNotificationRepository(db db.DBExecutor) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+// Create enqueues a pending notification, due immediately unless NextAttemptAt is set.
+func (r *notificationRepository) Create(ctx context.Context, notification *models.QueuedNotification) error {
+	const query = `
+		INSERT INTO notifications
+		(type, subject, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES (:type, :subject, :payload, :status, 0, COALESCE(:next_attempt_at, NOW(6)), NOW(6), NOW(6))
+	`
+
+	params := map[string]interface{}{
+		"type":            notification.Type,
+		"subject":         notification.Subject,
+		"payload":         []byte(notification.Payload),
+		"status":          models.NotificationStatusPending,
+		"next_attempt_at": notification.NextAttemptAt,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get notification ID: %w", err)
+	}
+	notification.ID = int(id)
+
+	return nil
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt made under token and releases the claim.
+// It returns sql.ErrNoRows when the claim expired and the notification was claimed again in the meantime.
+func (r *notificationRepository) UpdateDelivery(ctx context.Context, notification *models.QueuedNotification, token string) error {
+	const query = `
+		UPDATE notifications
+		SET status = :status, claim_token = NULL, attempts = :attempts, delivered_channels = :delivered_channels,
+			next_attempt_at = :next_attempt_at, last_error = :last_error, sent_at = :sent_at, updated_at = NOW(6)
+		WHERE id = :id AND claim_token = :token AND status = :pending
+	`
+
+	params := map[string]interface{}{
+		"id":                 notification.ID,
+		"token":              token,
+		"pending":            models.NotificationStatusPending,
+		"status":             notification.Status,
+		"attempts":           notification.Attempts,
+		"delivered_channels": notification.DeliveredChannels,
+		"next_attempt_at":    notification.NextAttemptAt,
+		"last_error":         notification.LastError,
+		"sent_at":            notification.SentAt,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("notification %d is no longer delivered under this claim: %w", notification.ID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Resend puts a failed notification back in the queue with a fresh attempt budget.
+// It returns sql.ErrNoRows when no failed notification has the given ID.
+func (r *notificationRepository) Resend(ctx context.Context, id int) error {
+	const query = `
+		UPDATE notifications
+		SET status = :pending, attempts = 0, next_attempt_at = NOW(6), updated_at = NOW(6)
+		WHERE id = :id AND status = :failed
+	`
+
+	params := map[string]interface{}{
+		"id":      id,
+		"pending": models.NotificationStatusPending,
+		"failed":  models.NotificationStatusFailed,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to resend notification: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to resend notification: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("failed to resend notification: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) getBaseQuery(filter models.NotificationFilter) (string, []interface{}) {
+	var baseQuery = `
+		SELECT id, type, subject, payload, status, claim_token, attempts, delivered_channels, next_attempt_at, last_error,
+			sent_at, created_at, updated_at
+		FROM notifications
+	`
+
+	var conditions []string
+	var args []interface{}
+
+	if filter.ID != nil {
+		conditions = append(conditions, "id = ?")
+		args = append(args, *filter.ID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.ClaimToken != "" {
+		conditions = append(conditions, "claim_token = ?")
+		args = append(args, filter.ClaimToken)
+	}
+
+	createdConds, createdArgs := createdConditions(filter.ListFilter)
+	conditions = append(conditions, createdConds...)
+	args = append(args, createdArgs...)
+
+	if len(conditions) > 0 {
+		baseQuery = fmt.Sprintf("%s WHERE %s", baseQuery, strings.Join(conditions, " AND "))
+	}
+
+	return baseQuery, args
+}
+
+func (r *notificationRepository) FetchOne(ctx context.Context, id int) (*models.QueuedNotification, error) {
+	query, args := r.getBaseQuery(models.NotificationFilter{ID: &id})
+
+	var notification models.QueuedNotification
+	if err := r.db.GetContext(ctx, &notification, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch notification: %w", err)
+	}
+
+	return &notification, nil
+}
+
+// Claim marks up to limit pending notifications whose next attempt is due, oldest first, as delivered under
+// token until leaseUntil, and returns them. The claim is a single UPDATE, so concurrent dispatchers never claim
+// the same notification; one whose dispatcher stopped before storing the outcome is due again once the lease
+// expires.
+func (r *notificationRepository) Claim(ctx context.Context, token string, now, leaseUntil time.Time, limit int) ([]*models.QueuedNotification, error) {
+	query := fmt.Sprintf(`
+		UPDATE notifications
+		SET claim_token = :token, next_attempt_at = :lease_until, updated_at = NOW(6)
+		WHERE status = :pending AND next_attempt_at <= :now
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT %d
+	`, limit)
+
+	params := map[string]interface{}{
+		"token":       token,
+		"lease_until": leaseUntil,
+		"pending":     models.NotificationStatusPending,
+		"now":         now,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	query, args := r.getBaseQuery(models.NotificationFilter{
+		Status:     models.NotificationStatusPending,
+		ClaimToken: token,
+	})
+	query += " ORDER BY id ASC"
+
+	var notifications []*models.QueuedNotification
+	if err := r.db.SelectContext(ctx, &notifications, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch claimed notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) FetchManyWithPagination(ctx context.Context, filter models.NotificationFilter, page, limit int) ([]*models.QueuedNotification, *db.Pagination, error) {
+	query, args := r.getBaseQuery(filter)
+
+	pagination := &db.Pagination{Order: filter.Sort.OrderOr("id DESC"), Page: page, Limit: limit}
+	var notifications []*models.QueuedNotification
+	if err := db.Paginate(ctx, r.db, query, args, pagination, &notifications); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch notifications with pagination: %w", err)
+	}
+
+	return notifications, pagination, nil
+}
+
+func (r *notificationRepository) FetchManyWithCursor(ctx context.Context, filter models.NotificationFilter, pagination *db.CursorPagination) ([]*models.QueuedNotification, error) {
+	query, args := r.getBaseQuery(filter)
+
+	pagination.Order = filter.Sort.TermsOr(db.SortTerm{Column: "id", Desc: true})
+	var notifications []*models.QueuedNotification
+	if err := db.PaginateCursor(ctx, r.db, query, args, pagination, &notifications); err != nil {
+		return nil, fmt.Errorf("failed to fetch notifications with cursor: %w", err)
+	}
+
+	return notifications, nil
+}
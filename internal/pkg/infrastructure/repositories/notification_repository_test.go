@@ -0,0 +1,110 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestNotificationRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewNotificationRepository(sqlxDB)
+
+	query := `INSERT INTO notifications .* VALUES \(\?, \?, \?, \?, 0, COALESCE\(\?, NOW\(6\)\), NOW\(6\), NOW\(6\)\)`
+	mock.ExpectExec(query).
+		WithArgs("product_biller_summary", "Summary", []byte(`{"total":3}`), "pending", nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	notification := &models.QueuedNotification{Type: "product_biller_summary", Subject: "Summary", Payload: []byte(`{"total":3}`)}
+	err = repo.Create(context.Background(), notification)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, notification.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_Resend(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewNotificationRepository(sqlxDB)
+
+	query := `UPDATE notifications SET status = \?, attempts = 0, next_attempt_at = NOW\(6\), updated_at = NOW\(6\) WHERE id = \? AND status = \?`
+	mock.ExpectExec(query).WithArgs("pending", 1, "failed").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs("pending", 2, "failed").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.Resend(context.Background(), 1))
+	assert.ErrorIs(t, repo.Resend(context.Background(), 2), sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_Claim(t *testing.T) {
+	sqlx.NameMapper = strcase.ToSnake
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewNotificationRepository(sqlxDB)
+
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(10 * time.Minute)
+
+	claimQuery := `UPDATE notifications SET claim_token = \?, next_attempt_at = \?, updated_at = NOW\(6\) ` +
+		`WHERE status = \? AND next_attempt_at <= \? ORDER BY next_attempt_at ASC, id ASC LIMIT 10`
+	mock.ExpectExec(claimQuery).WithArgs("token", leaseUntil, "pending", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM notifications WHERE status = \? AND claim_token = \? ORDER BY id ASC`).
+		WithArgs("pending", "token").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "status", "claim_token", "delivered_channels"}).
+			AddRow(1, "product_biller_summary", "pending", "token", "slack"))
+
+	notifications, err := repo.Claim(context.Background(), "token", now, leaseUntil, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, []string{"slack"}, notifications[0].Delivered())
+
+	// Nothing is fetched when no notification was due.
+	mock.ExpectExec(claimQuery).WithArgs("token", leaseUntil, "pending", now).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	notifications, err = repo.Claim(context.Background(), "token", now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_UpdateDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewNotificationRepository(sqlxDB)
+
+	query := `UPDATE notifications SET status = \?, claim_token = NULL, .* WHERE id = \? AND claim_token = \? AND status = \?`
+	notification := &models.QueuedNotification{ID: 1, Status: "sent", Attempts: 1, DeliveredChannels: "cacabot"}
+	mock.ExpectExec(query).
+		WithArgs("sent", 1, "cacabot", nil, nil, nil, 1, "token", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs("sent", 1, "cacabot", nil, nil, nil, 1, "stale", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.UpdateDelivery(context.Background(), notification, "token"))
+	assert.ErrorIs(t, repo.UpdateDelivery(context.Background(), notification, "stale"), sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
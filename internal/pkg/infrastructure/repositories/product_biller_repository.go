@@ -2,109 +2,326 @@ package repositories
 
 import (
 	"context"
-	"errors"
+	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
-
-	"github.com/go-sql-driver/mysql"
+	"time"
 
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/models"
-	"golang-boilerplate/internal/pkg/utils"
 )
 
 // ProductBillerRepository defines the interface for managing ProductBiller entities.
 type ProductBillerRepository interface {
-	Create(ctx context.Context, productBiller *models.ProductBiller) error
-	Update(ctx context.Context, id int, productBiller *models.ProductBiller) error
-	Delete(ctx context.Context, id int) error
-	DeleteByProductID(ctx context.Context, productID int) error
-	DeleteByBillerID(ctx context.Context, billerID int) error
+	Create(ctx context.Context, productBiller *models.ProductBiller) (*models.ProductBiller, error)
+	Update(ctx context.Context, id, version int, productBiller *models.ProductBiller) error
+	Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error
+	Deactivate(ctx context.Context, id int, deactivatedBy, reason string) error
+	DeactivateByProductID(ctx context.Context, productID int, deactivatedBy, reason string) error
+	DeactivateByBillerID(ctx context.Context, billerID int, deactivatedBy, reason string) error
+	Delete(ctx context.Context, id, version int, batch string) error
+	DeleteByProductID(ctx context.Context, productID int, batch string) error
+	DeleteByBillerID(ctx context.Context, billerID int, batch string) error
+	Restore(ctx context.Context, id int) error
+	RestoreBatch(ctx context.Context, batch string) (int64, error)
+	Purge(ctx context.Context, before time.Time, limit int) (int64, error)
 	FetchOne(ctx context.Context, id int) (*models.ProductBiller, error)
-	FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.ProductBiller, error)
-	FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.ProductBiller, *db.Pagination, error)
+	FetchMany(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBiller, error)
+	FetchEach(ctx context.Context, filter models.ProductBillerFilter, fn func(productBiller *models.ProductBiller) error) error
+	FetchManyWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBiller, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBiller, error)
+	FetchManyDetailed(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBillerDetail, error)
+	FetchManyDetailedWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBillerDetail, *db.Pagination, error)
+	FetchManyDetailedWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBillerDetail, error)
+	Summarize(ctx context.Context) (*models.ProductBillerSummary, error)
+}
+
+var productBillerTable = &Table[models.ProductBillerFilter, models.ProductBillerPatch]{
+	Name:   "product_billers",
+	Entity: "product biller",
+	Columns: []string{
+		"id", "product_id", "biller_id", "is_active", "priority", "weight", "deactivated_at", "deactivated_by", "deactivation_reason",
+		"created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "deleted_batch", "version",
+	},
+	InsertColumns:      []string{"product_id", "biller_id", "is_active", "priority", "weight", "created_at", "created_by", "updated_at", "updated_by"},
+	UpdateColumns:      []string{"is_active", "priority", "weight", "updated_at", "updated_by"},
+	SoftDeleteColumn:   "deleted_at",
+	DeletedBatchColumn: "deleted_batch",
+	// Stats are kept per product biller, so they go along with it.
+	PurgeDependents: []Dependent{
+		{Table: "product_biller_stats", Column: "product_biller_id"},
+		{Table: "product_biller_stat_transactions", Column: "product_biller_id"},
+	},
+	VersionColumn: "version",
+	DefaultOrder:  db.SortTerm{Column: "id"},
+	Where: func(filter models.ProductBillerFilter) ([]string, []interface{}) {
+		var conditions []string
+		var args []interface{}
+
+		conditions = append(conditions, softDeleteConditions(filter.IncludeDeleted)...)
+
+		if filter.ID != nil {
+			conditions = append(conditions, "id = ?")
+			args = append(args, *filter.ID)
+		}
+		if filter.ProductID != nil {
+			conditions = append(conditions, "product_id = ?")
+			args = append(args, *filter.ProductID)
+		}
+		if filter.ProductIDs != nil {
+			productCond, productArgs := inCondition("product_id", filter.ProductIDs)
+			conditions = append(conditions, productCond)
+			args = append(args, productArgs...)
+		}
+		if filter.BillerID != nil {
+			conditions = append(conditions, "biller_id = ?")
+			args = append(args, *filter.BillerID)
+		}
+		if filter.BillerIDs != nil {
+			billerCond, billerArgs := inCondition("biller_id", filter.BillerIDs)
+			conditions = append(conditions, billerCond)
+			args = append(args, billerArgs...)
+		}
+		if filter.IsActive != nil {
+			conditions = append(conditions, "is_active = ?")
+			args = append(args, *filter.IsActive)
+		}
+		if filter.DeactivatedSince != nil {
+			conditions = append(conditions, "is_active = 0 AND deactivated_at >= ?")
+			args = append(args, *filter.DeactivatedSince)
+		}
+
+		createdConds, createdArgs := createdConditions(filter.ListFilter)
+		conditions = append(conditions, createdConds...)
+		args = append(args, createdArgs...)
+
+		return conditions, args
+	},
+	Sort: func(filter models.ProductBillerFilter) db.Sort {
+		return filter.Sort
+	},
+	Patch: func(patch models.ProductBillerPatch) map[string]interface{} {
+		values := map[string]interface{}{"updated_by": patch.UpdatedBy}
+		if patch.IsActive != nil {
+			values["is_active"] = *patch.IsActive
+		}
+		if patch.Priority != nil {
+			values["priority"] = *patch.Priority
+		}
+		if patch.Weight != nil {
+			values["weight"] = *patch.Weight
+		}
+		return values
+	},
+}
+
+// productBillerDetailTable reads product billers joined with their product and biller. The join is a derived
+// table named after product_billers, so the conditions and sort of productBillerTable apply to it unchanged.
+// Deleted products and billers are joined too: the filter decides which product billers are listed.
+var productBillerDetailTable = func() *Table[models.ProductBillerFilter, models.ProductBillerPatch] {
+	table := *productBillerTable
+
+	selected := make([]string, len(productBillerTable.Columns))
+	for i, column := range productBillerTable.Columns {
+		selected[i] = "pb." + column
+	}
+	productSelected, productColumns := joinedColumns("p", "product", productTable.Columns)
+	billerSelected, billerColumns := joinedColumns("b", "biller", billerTable.Columns)
+
+	table.Name = fmt.Sprintf(
+		"(SELECT %s FROM product_billers pb JOIN products p ON p.id = pb.product_id JOIN billers b ON b.id = pb.biller_id) AS product_billers",
+		strings.Join(append(append(selected, productSelected...), billerSelected...), ", "),
+	)
+	table.Columns = append(append(slices.Clone(productBillerTable.Columns), productColumns...), billerColumns...)
+	return &table
+}()
+
+// joinedColumns returns the columns of a joined table, selected from its alias, and the names they are
+// selected as: their path in the model they are scanned into, e.g. `product.id`.
+func joinedColumns(alias, prefix string, columns []string) (selected, names []string) {
+	for _, column := range columns {
+		name := fmt.Sprintf("`%s.%s`", prefix, column)
+		selected = append(selected, alias+"."+column+" AS "+name)
+		names = append(names, name)
+	}
+	return selected, names
 }
 
-// productBillerRepository implements ProductBillerRepository.
+// deactivationAssignments keep the deactivation columns in step with is_active when it is set from
+// :is_active. They must come before the is_active assignment: MySQL evaluates SET assignments left to
+// right, so later references to is_active would see the new value.
+const deactivationAssignments = `deactivated_at = CASE WHEN :is_active THEN NULL WHEN is_active THEN NOW(6) ELSE deactivated_at END,
+			deactivated_by = CASE WHEN :is_active THEN '' WHEN is_active THEN :updated_by ELSE deactivated_by END,
+			deactivation_reason = CASE WHEN :is_active THEN '' WHEN is_active THEN 'manual' ELSE deactivation_reason END`
+
+// productBillerRepository implements ProductBillerRepository on top of the generic Repository,
+// replacing its Update and Patch to keep the deactivation columns in step with is_active. The detailed
+// fetches read productBillerDetailTable through a second Repository.
 type productBillerRepository struct {
-	db db.DBExecutor
+	*Repository[models.ProductBiller, models.ProductBillerFilter, models.ProductBillerPatch]
+	details *Repository[models.ProductBillerDetail, models.ProductBillerFilter, models.ProductBillerPatch]
+	db      db.DBExecutor
 }
 
 // NewProductBillerRepository creates a new instance of ProductBillerRepository.
 func NewProductBillerRepository(db db.DBExecutor) ProductBillerRepository {
 	return &productBillerRepository{
-		db: db,
+		Repository: NewRepository[models.ProductBiller](db, productBillerTable),
+		details:    NewRepository[models.ProductBillerDetail](db, productBillerDetailTable),
+		db:         db,
 	}
 }
 
-func (r *productBillerRepository) Create(ctx context.Context, productBiller *models.ProductBiller) error {
+// FetchManyDetailed returns the product billers matching the filter along with their product and biller,
+// in a single query.
+func (r *productBillerRepository) FetchManyDetailed(ctx context.Context, filter models.ProductBillerFilter) ([]*models.ProductBillerDetail, error) {
+	return r.details.FetchMany(ctx, filter)
+}
+
+func (r *productBillerRepository) FetchManyDetailedWithPagination(ctx context.Context, filter models.ProductBillerFilter, page, limit int) ([]*models.ProductBillerDetail, *db.Pagination, error) {
+	return r.details.FetchManyWithPagination(ctx, filter, page, limit)
+}
+
+func (r *productBillerRepository) FetchManyDetailedWithCursor(ctx context.Context, filter models.ProductBillerFilter, pagination *db.CursorPagination) ([]*models.ProductBillerDetail, error) {
+	return r.details.FetchManyWithCursor(ctx, filter, pagination)
+}
+
+// Update returns sql.ErrNoRows when the product biller does not exist or no longer has the given version.
+func (r *productBillerRepository) Update(ctx context.Context, id, version int, productBiller *models.ProductBiller) error {
 	const query = `
-		INSERT INTO product_billers 
-		(product_id, biller_id, is_active, created_at, created_by, updated_at, updated_by)
-		VALUES (:product_id, :biller_id, :is_active, NOW(6), :created_by, NOW(6), :updated_by)
+		UPDATE product_billers
+		SET ` + deactivationAssignments + `,
+			is_active = :is_active, priority = :priority, weight = :weight, updated_at = NOW(6), updated_by = :updated_by,
+			version = version + 1
+		WHERE id = :id AND deleted_at IS NULL AND version = :version
 	`
 
-	_, err := r.db.NamedExecContext(ctx, query, productBiller)
+	params := map[string]interface{}{
+		"id":         id,
+		"version":    version,
+		"is_active":  productBiller.IsActive,
+		"priority":   productBiller.Priority,
+		"weight":     productBiller.Weight,
+		"updated_by": productBiller.UpdatedBy,
+	}
+
+	return r.exec(ctx, "update", id, version, query, params)
+}
+
+// Patch writes the columns changed by the patch, resetting or recording the deactivation when it sets
+// is_active. It returns sql.ErrNoRows when the product biller does not exist or no longer has the given version.
+func (r *productBillerRepository) Patch(ctx context.Context, id, version int, patch *models.ProductBillerPatch) error {
+	params := productBillerTable.Patch(*patch)
+
+	var assignments []string
+	if _, ok := params["is_active"]; ok {
+		assignments = append(assignments, deactivationAssignments)
+	}
+	for _, column := range productBillerTable.UpdateColumns {
+		if _, ok := params[column]; ok || timestampColumns[column] {
+			assignments = append(assignments, column+" = "+r.namedValue(column))
+		}
+	}
+	assignments = append(assignments, "version = version + 1")
+
+	params["id"], params["version"] = id, version
+	query := fmt.Sprintf(`
+		UPDATE product_billers
+		SET %s
+		WHERE id = :id AND deleted_at IS NULL AND version = :version
+	`, strings.Join(assignments, ", "))
+
+	return r.exec(ctx, "patch", id, version, query, params)
+}
+
+// exec runs a versioned write of the product biller with the given ID, reporting sql.ErrNoRows when it matched no row.
+func (r *productBillerRepository) exec(ctx context.Context, operation string, id, version int, query string, params map[string]interface{}) error {
+	result, err := r.db.NamedExecContext(ctx, query, params)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
-			duplicateField := utils.ParseDuplicateEntry(mysqlErr.Message)
-			return fmt.Errorf("duplicate entry detected: %s", duplicateField)
+		if duplicate := duplicateEntry(err); duplicate != nil {
+			return duplicate
 		}
-		return fmt.Errorf("failed to create product biller: %w", err)
+		return fmt.Errorf("failed to %s product biller: %w", operation, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to %s product biller: %w", operation, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("failed to %s product biller %d at version %d: %w", operation, id, version, sql.ErrNoRows)
 	}
 
 	return nil
 }
 
-func (r *productBillerRepository) Update(ctx context.Context, id int, productBiller *models.ProductBiller) error {
+// Deactivate marks an active product biller as inactive, recording who or what deactivated it and why.
+func (r *productBillerRepository) Deactivate(ctx context.Context, id int, deactivatedBy, reason string) error {
 	const query = `
 		UPDATE product_billers
-		SET is_active = :is_active, updated_at = NOW(6)
-		WHERE id = :id AND deleted_at IS NULL
+		SET is_active = 0, deactivated_at = NOW(6), deactivated_by = :deactivated_by, deactivation_reason = :reason,
+			updated_at = NOW(6), updated_by = :deactivated_by, version = version + 1
+		WHERE id = :id AND is_active = 1 AND deleted_at IS NULL
 	`
 
 	params := map[string]interface{}{
-		"id":        id,
-		"is_active": productBiller.IsActive,
+		"id":             id,
+		"deactivated_by": deactivatedBy,
+		"reason":         reason,
 	}
 
 	_, err := r.db.NamedExecContext(ctx, query, params)
 	if err != nil {
-		return fmt.Errorf("failed to update product biller: %w", err)
+		return fmt.Errorf("failed to deactivate product biller: %w", err)
 	}
 
 	return nil
 }
 
-func (r *productBillerRepository) Delete(ctx context.Context, id int) error {
-	const query = `
+// DeactivateByProductID marks the active product billers of a product as inactive.
+func (r *productBillerRepository) DeactivateByProductID(ctx context.Context, productID int, deactivatedBy, reason string) error {
+	return r.deactivateBy(ctx, "product_id", productID, deactivatedBy, reason)
+}
+
+// DeactivateByBillerID marks the active product billers of a biller as inactive.
+func (r *productBillerRepository) DeactivateByBillerID(ctx context.Context, billerID int, deactivatedBy, reason string) error {
+	return r.deactivateBy(ctx, "biller_id", billerID, deactivatedBy, reason)
+}
+
+// deactivateBy marks the active product billers whose column equals value as inactive. column is never user input.
+func (r *productBillerRepository) deactivateBy(ctx context.Context, column string, value int, deactivatedBy, reason string) error {
+	query := fmt.Sprintf(`
 		UPDATE product_billers
-		SET deleted_at = NOW(6)
-		WHERE id = :id AND deleted_at IS NULL
-	`
+		SET is_active = 0, deactivated_at = NOW(6), deactivated_by = :deactivated_by, deactivation_reason = :reason,
+			updated_at = NOW(6), updated_by = :deactivated_by, version = version + 1
+		WHERE %s = :value AND is_active = 1 AND deleted_at IS NULL
+	`, column)
 
 	params := map[string]interface{}{
-		"id": id,
+		"value":          value,
+		"deactivated_by": deactivatedBy,
+		"reason":         reason,
 	}
 
 	_, err := r.db.NamedExecContext(ctx, query, params)
 	if err != nil {
-		return fmt.Errorf("failed to delete product biller: %w", err)
+		return fmt.Errorf("failed to deactivate product billers: %w", err)
 	}
 
 	return nil
 }
 
-func (r *productBillerRepository) DeleteByProductID(ctx context.Context, productID int) error {
+func (r *productBillerRepository) DeleteByProductID(ctx context.Context, productID int, batch string) error {
 	const query = `
 		UPDATE product_billers
-		SET deleted_at = NOW(6)
+		SET deleted_at = NOW(6), deleted_batch = :batch, version = version + 1
 		WHERE product_id = :product_id AND deleted_at IS NULL
 	`
 
 	params := map[string]interface{}{
 		"product_id": productID,
+		"batch":      batch,
 	}
 
 	_, err := r.db.NamedExecContext(ctx, query, params)
@@ -115,15 +332,16 @@ func (r *productBillerRepository) DeleteByProductID(ctx context.Context, product
 	return nil
 }
 
-func (r *productBillerRepository) DeleteByBillerID(ctx context.Context, billerID int) error {
+func (r *productBillerRepository) DeleteByBillerID(ctx context.Context, billerID int, batch string) error {
 	const query = `
 		UPDATE product_billers
-		SET deleted_at = NOW(6)
+		SET deleted_at = NOW(6), deleted_batch = :batch, version = version + 1
 		WHERE biller_id = :biller_id AND deleted_at IS NULL
 	`
 
 	params := map[string]interface{}{
 		"biller_id": billerID,
+		"batch":     batch,
 	}
 
 	_, err := r.db.NamedExecContext(ctx, query, params)
@@ -134,73 +352,89 @@ func (r *productBillerRepository) DeleteByBillerID(ctx context.Context, billerID
 	return nil
 }
 
-func (r *productBillerRepository) getBaseQuery(filters map[string]interface{}) (string, []interface{}) {
-	var baseQuery = `
-		SELECT id, product_id, biller_id, is_active, created_at, created_by, updated_at, updated_by
-		FROM product_billers
+// RestoreBatch restores the product billers soft-deleted in batch whose product and biller are not
+// deleted, returning how many were restored. Restore the product or biller of a cascade first.
+func (r *productBillerRepository) RestoreBatch(ctx context.Context, batch string) (int64, error) {
+	const query = `
+		UPDATE product_billers pb
+		SET pb.deleted_at = NULL, pb.deleted_batch = NULL, pb.version = pb.version + 1
+		WHERE pb.deleted_batch = :batch AND pb.deleted_at IS NOT NULL
+			AND EXISTS (SELECT 1 FROM products p WHERE p.id = pb.product_id AND p.deleted_at IS NULL)
+			AND EXISTS (SELECT 1 FROM billers b WHERE b.id = pb.biller_id AND b.deleted_at IS NULL)
 	`
 
-	var conditions []string
-	var args []interface{}
-
-	conditions = append(conditions, "deleted_at IS NULL")
-
-	if id, ok := filters["id"].(int); ok {
-		conditions = append(conditions, "id = ?")
-		args = append(args, id)
-	}
-	if productID, ok := filters["product_id"].(int); ok {
-		conditions = append(conditions, "product_id = ?")
-		args = append(args, productID)
-	}
-	if billerID, ok := filters["biller_id"].(int); ok {
-		conditions = append(conditions, "biller_id = ?")
-		args = append(args, billerID)
+	params := map[string]interface{}{
+		"batch": batch,
 	}
-	if isActive, ok := filters["is_active"].(bool); ok {
-		conditions = append(conditions, "is_active = ?")
-		args = append(args, isActive)
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore product billers: %w", err)
 	}
 
-	if len(conditions) > 0 {
-		baseQuery = fmt.Sprintf("%s WHERE %s", baseQuery, strings.Join(conditions, " AND "))
+	restored, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore product billers: %w", err)
 	}
 
-	return baseQuery, args
+	return restored, nil
 }
 
-func (r *productBillerRepository) FetchOne(ctx context.Context, id int) (*models.ProductBiller, error) {
-	query, args := r.getBaseQuery(map[string]interface{}{
-		"id": id,
-	})
+// Summarize counts product billers with grouped aggregate queries instead of loading every row.
+func (r *productBillerRepository) Summarize(ctx context.Context) (*models.ProductBillerSummary, error) {
+	const activeQuery = `
+		SELECT is_active, COUNT(*) AS total
+		FROM product_billers
+		WHERE deleted_at IS NULL
+		GROUP BY is_active
+	`
 
-	var productBiller models.ProductBiller
-	if err := r.db.GetContext(ctx, &productBiller, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to fetch product biller: %w", err)
+	var activeCounts []struct {
+		IsActive bool
+		Total    int
+	}
+	if err := r.db.SelectContext(ctx, &activeCounts, activeQuery); err != nil {
+		return nil, fmt.Errorf("failed to count product billers: %w", err)
 	}
 
-	return &productBiller, nil
-}
-
-func (r *productBillerRepository) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.ProductBiller, error) {
-	query, args := r.getBaseQuery(filter)
+	summary := &models.ProductBillerSummary{}
+	for _, count := range activeCounts {
+		summary.Total += count.Total
+		if count.IsActive {
+			summary.Active += count.Total
+		} else {
+			summary.Inactive += count.Total
+		}
+	}
 
-	var productBillers []*models.ProductBiller
-	if err := r.db.SelectContext(ctx, &productBillers, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to fetch product billers: %w", err)
+	var err error
+	if summary.ByBiller, err = r.summarizeBy(ctx, "biller_id", "billers"); err != nil {
+		return nil, err
+	}
+	if summary.ByProduct, err = r.summarizeBy(ctx, "product_id", "products"); err != nil {
+		return nil, err
 	}
 
-	return productBillers, nil
+	return summary, nil
 }
 
-func (r *productBillerRepository) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.ProductBiller, *db.Pagination, error) {
-	query, args := r.getBaseQuery(filter)
-
-	pagination := &db.Pagination{Order: "id ASC", Page: page, Limit: limit}
-	var productBillers []*models.ProductBiller
-	if err := db.Paginate(ctx, r.db, query, args, pagination, &productBillers); err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch product billers with pagination: %w", err)
+// summarizeBy counts product billers grouped by a foreign key, labelled from the referenced table.
+// Both arguments are fixed identifiers supplied by Summarize, never user input.
+func (r *productBillerRepository) summarizeBy(ctx context.Context, column, table string) ([]models.ProductBillerGroupSummary, error) {
+	query := fmt.Sprintf(`
+		SELECT pb.%[1]s AS id, COALESCE(t.label, '') AS label, COUNT(*) AS total,
+			COALESCE(SUM(pb.is_active), 0) AS active, COUNT(*) - COALESCE(SUM(pb.is_active), 0) AS inactive
+		FROM product_billers pb
+		LEFT JOIN %[2]s t ON t.id = pb.%[1]s
+		WHERE pb.deleted_at IS NULL
+		GROUP BY pb.%[1]s, t.label
+		ORDER BY pb.%[1]s ASC
+	`, column, table)
+
+	var groups []models.ProductBillerGroupSummary
+	if err := r.db.SelectContext(ctx, &groups, query); err != nil {
+		return nil, fmt.Errorf("failed to summarize product billers by %s: %w", column, err)
 	}
 
-	return productBillers, pagination, nil
+	return groups, nil
 }
@@ -2,6 +2,7 @@ package repositories_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -28,27 +29,35 @@ func TestProductBillerRepository_Create(t *testing.T) {
 		ProductID: 1,
 		BillerID:  2,
 		IsActive:  true,
+		Priority:  1,
+		Weight:    10,
 		CreatedBy: "test_user",
 		UpdatedBy: "test_user",
 	}
 
 	query := `
 		INSERT INTO product_billers 
-		\(product_id, biller_id, is_active, created_at, created_by, updated_at, updated_by\)
-		VALUES \(\?, \?, \?, NOW\(6\), \?, NOW\(6\), \?\)
+		\(product_id, biller_id, is_active, priority, weight, created_at, created_by, updated_at, updated_by\)
+		VALUES \(\?, \?, \?, \?, \?, NOW\(6\), \?, NOW\(6\), \?\)
 	`
 	mock.ExpectExec(query).
 		WithArgs(
 			productBiller.ProductID,
 			productBiller.BillerID,
 			productBiller.IsActive,
+			productBiller.Priority,
+			productBiller.Weight,
 			productBiller.CreatedBy,
 			productBiller.UpdatedBy,
 		).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectQuery(`SELECT id, product_id, .* FROM product_billers WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id"}).AddRow(7, 1, 2))
 
-	err = repo.Create(context.Background(), productBiller)
+	created, err := repo.Create(context.Background(), productBiller)
 	assert.NoError(t, err)
+	assert.Equal(t, 7, created.ID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -60,12 +69,78 @@ func TestProductBillerRepository_Update(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "mysql")
 	repo := repositories.NewProductBillerRepository(sqlxDB)
 
-	query := `UPDATE product_billers SET is_active = \?, updated_at = NOW\(6\) WHERE id = \? AND deleted_at IS NULL`
+	query := `UPDATE product_billers SET deactivated_at = .* is_active = \?, priority = \?, weight = \?, updated_at = NOW\(6\), updated_by = \?, version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`
 	mock.ExpectExec(query).
-		WithArgs(false, 1).
+		WithArgs(false, false, "user1", false, false, 2, 5, "user1", 1, 3).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = repo.Update(context.Background(), 1, &models.ProductBiller{IsActive: false})
+	err = repo.Update(context.Background(), 1, 3, &models.ProductBiller{IsActive: false, Priority: 2, Weight: 5, UpdatedBy: "user1"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerRepository_Patch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	// Only the weight is written, leaving is_active and the deactivation columns alone.
+	weight := 5
+	query := `UPDATE product_billers SET weight = \?, updated_at = NOW\(6\), updated_by = \?, version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`
+	mock.ExpectExec(query).
+		WithArgs(5, "user1", 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Patch(context.Background(), 1, 3, &models.ProductBillerPatch{Weight: &weight, UpdatedBy: "user1"})
+	assert.NoError(t, err)
+
+	// Setting is_active updates the deactivation columns along with it.
+	isActive := false
+	query = `UPDATE product_billers SET deactivated_at = .* is_active = \?, updated_at = NOW\(6\), updated_by = \?, version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`
+	mock.ExpectExec(query).
+		WithArgs(false, false, "user1", false, false, "user1", 1, 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.Patch(context.Background(), 1, 4, &models.ProductBillerPatch{IsActive: &isActive, UpdatedBy: "user1"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerRepository_Deactivate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	query := `UPDATE product_billers SET is_active = 0, deactivated_at = NOW\(6\), deactivated_by = \?, deactivation_reason = \?, updated_at = NOW\(6\), updated_by = \?, version = version \+ 1 WHERE id = \? AND is_active = 1 AND deleted_at IS NULL`
+	mock.ExpectExec(query).
+		WithArgs("worker", "transaction 7 returned status \"failed\"", "worker", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Deactivate(context.Background(), 1, "worker", `transaction 7 returned status "failed"`)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerRepository_DeactivateByProductID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	query := `UPDATE product_billers SET is_active = 0, deactivated_at = NOW\(6\), deactivated_by = \?, deactivation_reason = \?, updated_at = NOW\(6\), updated_by = \?, version = version \+ 1 WHERE product_id = \? AND is_active = 1 AND deleted_at IS NULL`
+	mock.ExpectExec(query).
+		WithArgs("system", "product 3 deleted", "system", 3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.DeactivateByProductID(context.Background(), 3, "system", "product 3 deleted")
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -78,16 +153,38 @@ func TestProductBillerRepository_Delete(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "mysql")
 	repo := repositories.NewProductBillerRepository(sqlxDB)
 
-	query := `UPDATE product_billers SET deleted_at = NOW\(6\) WHERE id = \? AND deleted_at IS NULL`
+	query := `UPDATE product_billers SET deleted_at = NOW\(6\), deleted_batch = \?, version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`
 	mock.ExpectExec(query).
-		WithArgs(1).
+		WithArgs("batch", 1, 3).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = repo.Delete(context.Background(), 1)
+	err = repo.Delete(context.Background(), 1, 3, "batch")
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestProductBillerRepository_RestoreBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	query := `UPDATE product_billers pb SET pb.deleted_at = NULL, pb.deleted_batch = NULL, pb.version = pb.version \+ 1 ` +
+		`WHERE pb.deleted_batch = \? AND pb.deleted_at IS NOT NULL ` +
+		`AND EXISTS \(SELECT 1 FROM products p WHERE p.id = pb.product_id AND p.deleted_at IS NULL\) ` +
+		`AND EXISTS \(SELECT 1 FROM billers b WHERE b.id = pb.biller_id AND b.deleted_at IS NULL\)`
+	mock.ExpectExec(query).
+		WithArgs("batch").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	restored, err := repo.RestoreBatch(context.Background(), "batch")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), restored)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestProductBillerRepository_FetchOne(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -97,11 +194,11 @@ func TestProductBillerRepository_FetchOne(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "mysql")
 	repo := repositories.NewProductBillerRepository(sqlxDB)
 
-	query := `SELECT id, product_id, biller_id, is_active, created_at, created_by, updated_at, updated_by FROM product_billers WHERE deleted_at IS NULL AND id = \?`
+	query := `SELECT id, product_id, biller_id, is_active, priority, weight, deactivated_at, deactivated_by, deactivation_reason, created_at, created_by, updated_at, updated_by, deleted_at, deleted_by, deleted_batch, version FROM product_billers WHERE id = \? AND deleted_at IS NULL`
 	mock.ExpectQuery(query).
 		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id", "is_active", "created_at", "created_by", "updated_at", "updated_by"}).
-			AddRow(1, 1, 2, true, time.Time{}, "user1", time.Time{}, "user1"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id", "is_active", "priority", "weight", "deactivated_at", "deactivated_by", "deactivation_reason", "created_at", "created_by", "updated_at", "updated_by"}).
+			AddRow(1, 1, 2, true, 0, 1, nil, "", "", time.Time{}, "user1", time.Time{}, "user1"))
 
 	result, err := repo.FetchOne(context.Background(), 1)
 	assert.NoError(t, err)
@@ -119,15 +216,108 @@ func TestProductBillerRepository_FetchMany(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "mysql")
 	repo := repositories.NewProductBillerRepository(sqlxDB)
 
-	query := `SELECT id, product_id, biller_id, is_active, created_at, created_by, updated_at, updated_by FROM product_billers WHERE deleted_at IS NULL AND product_id = \?`
+	query := `SELECT id, product_id, biller_id, is_active, priority, weight, deactivated_at, deactivated_by, deactivation_reason, created_at, created_by, updated_at, updated_by, deleted_at, deleted_by, deleted_batch, version FROM product_billers WHERE deleted_at IS NULL AND product_id = \? ORDER BY id ASC`
 	mock.ExpectQuery(query).
 		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id", "is_active", "created_at", "created_by", "updated_at", "updated_by"}).
-			AddRow(1, 1, 2, true, time.Time{}, "user1", time.Time{}, "user1").
-			AddRow(2, 1, 3, false, time.Time{}, "user2", time.Time{}, "user2"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id", "is_active", "priority", "weight", "deactivated_at", "deactivated_by", "deactivation_reason", "created_at", "created_by", "updated_at", "updated_by"}).
+			AddRow(1, 1, 2, true, 0, 1, nil, "", "", time.Time{}, "user1", time.Time{}, "user1").
+			AddRow(2, 1, 3, false, 1, 1, time.Time{}, "worker", "transaction 1 failed", time.Time{}, "user2", time.Time{}, "user2"))
+
+	productID := 1
+	results, err := repo.FetchMany(context.Background(), models.ProductBillerFilter{ProductID: &productID})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerRepository_FetchMany_IDLists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlx.NameMapper = strcase.ToSnake
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	mock.ExpectQuery(`FROM product_billers WHERE deleted_at IS NULL AND product_id IN \(\?, \?, \?\) ORDER BY id ASC`).
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id"}).AddRow(1, 1, 2).AddRow(2, 3, 2))
 
-	results, err := repo.FetchMany(context.Background(), map[string]interface{}{"product_id": 1})
+	results, err := repo.FetchMany(context.Background(), models.ProductBillerFilter{ProductIDs: []int{1, 2, 3}})
 	assert.NoError(t, err)
 	assert.Len(t, results, 2)
+
+	// An empty list matches no rows rather than every row.
+	mock.ExpectQuery(`FROM product_billers WHERE deleted_at IS NULL AND 1 = 0 ORDER BY id ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	results, err = repo.FetchMany(context.Background(), models.ProductBillerFilter{BillerIDs: []int{}})
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerRepository_FetchManyDetailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlx.NameMapper = strcase.ToSnake
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	// The product and the biller are joined in the same query, aliased by their path in ProductBillerDetail.
+	query := "SELECT id, product_id, .*, `product.id`, `product.label`, .*, `biller.id`, `biller.label`, .* " +
+		"FROM \\(SELECT pb.id, .*, p.id AS `product.id`, .*, b.id AS `biller.id`, .* FROM product_billers pb " +
+		"JOIN products p ON p.id = pb.product_id JOIN billers b ON b.id = pb.biller_id\\) AS product_billers " +
+		"WHERE deleted_at IS NULL AND product_id = \\? ORDER BY id ASC"
+	mock.ExpectQuery(query).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "biller_id", "is_active", "product.id", "product.label", "biller.id", "biller.label"}).
+			AddRow(1, 1, 2, true, 1, "Pulsa", 2, "Alpha").
+			AddRow(2, 1, 3, false, 1, "Pulsa", 3, "Beta"))
+
+	productID := 1
+	results, err := repo.FetchManyDetailed(context.Background(), models.ProductBillerFilter{ProductID: &productID})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 2, results[0].BillerID)
+	require.NotNil(t, results[0].Product)
+	assert.Equal(t, "Pulsa", results[0].Product.Label)
+	require.NotNil(t, results[1].Biller)
+	assert.Equal(t, 3, results[1].Biller.ID)
+	assert.Equal(t, "Beta", results[1].Biller.Label)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerRepository_Summarize(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlx.NameMapper = strcase.ToSnake
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+
+	mock.ExpectQuery(`SELECT is_active, COUNT\(\*\) AS total FROM product_billers WHERE deleted_at IS NULL GROUP BY is_active`).
+		WillReturnRows(sqlmock.NewRows([]string{"is_active", "total"}).
+			AddRow(true, 7).
+			AddRow(false, 3))
+	mock.ExpectQuery(`SELECT pb.biller_id AS id, .* FROM product_billers pb LEFT JOIN billers t ON t.id = pb.biller_id .* GROUP BY pb.biller_id, t.label`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label", "total", "active", "inactive"}).
+			AddRow(1, "Biller A", 6, 6, 0).
+			AddRow(2, "Biller B", 4, 1, 3))
+	mock.ExpectQuery(`SELECT pb.product_id AS id, .* FROM product_billers pb LEFT JOIN products t ON t.id = pb.product_id .* GROUP BY pb.product_id, t.label`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label", "total", "active", "inactive"}).
+			AddRow(1, "Pulsa 10K", 10, 7, 3))
+
+	summary, err := repo.Summarize(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 10, summary.Total)
+	assert.Equal(t, 7, summary.Active)
+	assert.Equal(t, 3, summary.Inactive)
+	assert.Len(t, summary.ByBiller, 2)
+	assert.Equal(t, models.ProductBillerGroupSummary{ID: 2, Label: "Biller B", Total: 4, Active: 1, Inactive: 3}, summary.ByBiller[1])
+	assert.Len(t, summary.ByProduct, 1)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
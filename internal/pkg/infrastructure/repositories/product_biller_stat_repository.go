@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ProductBillerStatRepository defines the interface for managing transaction statistics of ProductBiller entities.
+type ProductBillerStatRepository interface {
+	Record(ctx context.Context, transactionID, productBillerID int, success bool, at time.Time) error
+	FetchMany(ctx context.Context, productBillerID int, bucket string, from, to time.Time) ([]*models.ProductBillerStat, error)
+	Purge(ctx context.Context, before time.Time, limit int) (int64, error)
+	PurgeTransactions(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// productBillerStatRepository implements ProductBillerStatRepository.
+type productBillerStatRepository struct {
+	db db.DBExecutor
+}
+
+// NewProductBillerStatRepository creates a new instance of ProductBillerStatRepository.
+func NewProductBillerStatRepository(db db.DBExecutor) ProductBillerStatRepository {
+	return &productBillerStatRepository{
+		db: db,
+	}
+}
+
+// Record increments the counters of every bucket the given moment falls into, once per transaction:
+// recording a transaction again, e.g. when its message is redelivered, leaves the counters unchanged.
+func (r *productBillerStatRepository) Record(ctx context.Context, transactionID, productBillerID int, success bool, at time.Time) error {
+	successCount, failureCount := 0, 1
+	if success {
+		successCount, failureCount = 1, 0
+	}
+
+	var values []string
+	var args []interface{}
+	for _, bucket := range models.StatBuckets {
+		values = append(values, "(?, ?, ?, 1, ?, ?, NOW(6))")
+		args = append(args, productBillerID, bucket, models.StatBucketStart(bucket, at), successCount, failureCount)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO product_biller_stats
+		(product_biller_id, bucket, bucket_start, total, success, failure, updated_at)
+		VALUES %s
+		ON DUPLICATE KEY UPDATE
+			total = total + VALUES(total),
+			success = success + VALUES(success),
+			failure = failure + VALUES(failure),
+			updated_at = VALUES(updated_at)
+	`, strings.Join(values, ", "))
+
+	err := db.WithTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		// The transaction is marked as recorded in the same database transaction as the counters, so they
+		// are incremented exactly when the mark is new.
+		result, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO product_biller_stat_transactions (transaction_id, product_biller_id, recorded_at)
+			VALUES (?, ?, NOW(6))
+		`, transactionID, productBillerID)
+		if err != nil {
+			return err
+		}
+		if recorded, err := result.RowsAffected(); err != nil || recorded == 0 {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record product biller stat: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productBillerStatRepository) FetchMany(ctx context.Context, productBillerID int, bucket string, from, to time.Time) ([]*models.ProductBillerStat, error) {
+	const query = `
+		SELECT product_biller_id, bucket, bucket_start, total, success, failure
+		FROM product_biller_stats
+		WHERE product_biller_id = ? AND bucket = ? AND bucket_start >= ? AND bucket_start < ?
+		ORDER BY bucket_start ASC
+	`
+
+	var stats []*models.ProductBillerStat
+	if err := r.db.SelectContext(ctx, &stats, query, productBillerID, bucket, models.StatBucketStart(bucket, from), to); err != nil {
+		return nil, fmt.Errorf("failed to fetch product biller stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Purge deletes up to limit buckets starting before the given time, returning how many were deleted.
+func (r *productBillerStatRepository) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	const query = `
+		DELETE FROM product_biller_stats
+		WHERE bucket_start < ?
+		ORDER BY bucket_start
+		LIMIT ?
+	`
+
+	purged, err := r.purge(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge product biller stats: %w", err)
+	}
+	return purged, nil
+}
+
+// PurgeTransactions deletes up to limit marks of transactions recorded before the given time, returning how
+// many were deleted. A transaction redelivered after its mark is purged is counted again.
+func (r *productBillerStatRepository) PurgeTransactions(ctx context.Context, before time.Time, limit int) (int64, error) {
+	const query = `
+		DELETE FROM product_biller_stat_transactions
+		WHERE recorded_at < ?
+		ORDER BY recorded_at
+		LIMIT ?
+	`
+
+	purged, err := r.purge(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge product biller stat transactions: %w", err)
+	}
+	return purged, nil
+}
+
+func (r *productBillerStatRepository) purge(ctx context.Context, query string, before time.Time, limit int) (int64, error) {
+	result, err := r.db.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,121 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+)
+
+func TestProductBillerStatRepository_Record(t *testing.T) {
+	at := time.Date(2026, 10, 19, 9, 41, 27, 0, time.UTC)
+	markQuery := `INSERT IGNORE INTO product_biller_stat_transactions`
+	query := `INSERT INTO product_biller_stats .* VALUES \(\?, \?, \?, 1, \?, \?, NOW\(6\)\), \(\?, \?, \?, 1, \?, \?, NOW\(6\)\) ON DUPLICATE KEY UPDATE`
+
+	t.Run("new transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := repositories.NewProductBillerStatRepository(sqlx.NewDb(db, "mysql"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(markQuery).WithArgs(7, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(query).
+			WithArgs(
+				1, "minute", time.Date(2026, 10, 19, 9, 41, 0, 0, time.UTC), 0, 1,
+				1, "hour", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), 0, 1,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err = repo.Record(context.Background(), 7, 1, false, at)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already recorded transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		repo := repositories.NewProductBillerStatRepository(sqlx.NewDb(db, "mysql"))
+
+		// A redelivered transaction leaves the counters untouched.
+		mock.ExpectBegin()
+		mock.ExpectExec(markQuery).WithArgs(7, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err = repo.Record(context.Background(), 7, 1, false, at)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductBillerStatRepository_FetchMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlx.NameMapper = strcase.ToSnake
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repo := repositories.NewProductBillerStatRepository(sqlxDB)
+
+	from := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	query := `SELECT product_biller_id, bucket, bucket_start, total, success, failure FROM product_biller_stats WHERE product_biller_id = \? AND bucket = \? AND bucket_start >= \? AND bucket_start < \? ORDER BY bucket_start ASC`
+	mock.ExpectQuery(query).
+		WithArgs(1, "hour", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), to).
+		WillReturnRows(sqlmock.NewRows([]string{"product_biller_id", "bucket", "bucket_start", "total", "success", "failure"}).
+			AddRow(1, "hour", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), 10, 9, 1).
+			AddRow(1, "hour", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), 4, 2, 2))
+
+	stats, err := repo.FetchMany(context.Background(), 1, "hour", from, to)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, 9, stats[0].Success)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductBillerStatRepository_Purge(t *testing.T) {
+	before := time.Date(2026, 7, 21, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		query string
+		purge func(repo repositories.ProductBillerStatRepository) (int64, error)
+	}{
+		"stats": {
+			query: `DELETE FROM product_biller_stats WHERE bucket_start < \? ORDER BY bucket_start LIMIT \?`,
+			purge: func(repo repositories.ProductBillerStatRepository) (int64, error) {
+				return repo.Purge(context.Background(), before, 100)
+			},
+		},
+		"transactions": {
+			query: `DELETE FROM product_biller_stat_transactions WHERE recorded_at < \? ORDER BY recorded_at LIMIT \?`,
+			purge: func(repo repositories.ProductBillerStatRepository) (int64, error) {
+				return repo.PurgeTransactions(context.Background(), before, 100)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			repo := repositories.NewProductBillerStatRepository(sqlx.NewDb(db, "mysql"))
+			mock.ExpectExec(tc.query).WithArgs(before, 100).WillReturnResult(sqlmock.NewResult(0, 42))
+
+			purged, err := tc.purge(repo)
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), purged)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
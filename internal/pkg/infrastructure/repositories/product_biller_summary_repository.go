@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+// ProductBillerSummaryRepository defines the interface for persisting product biller summary snapshots.
+type ProductBillerSummaryRepository interface {
+	Create(ctx context.Context, summary *models.ProductBillerSummaryNotification) error
+	FetchLatest(ctx context.Context) (*models.ProductBillerSummaryNotification, error)
+}
+
+// productBillerSummaryRepository implements ProductBillerSummaryRepository.
+type productBillerSummaryRepository struct {
+	db db.DBExecutor
+}
+
+// NewProductBillerSummaryRepository creates a new instance of ProductBillerSummaryRepository.
+func NewProductBillerSummaryRepository(db db.DBExecutor) ProductBillerSummaryRepository {
+	return &productBillerSummaryRepository{
+		db: db,
+	}
+}
+
+func (r *productBillerSummaryRepository) Create(ctx context.Context, summary *models.ProductBillerSummaryNotification) error {
+	const query = `
+		INSERT INTO product_biller_summaries
+		(payload, created_at)
+		VALUES (?, ?)
+	`
+
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to marshal product biller summary: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, payload, summary.DateTime); err != nil {
+		return fmt.Errorf("failed to create product biller summary: %w", err)
+	}
+
+	return nil
+}
+
+// FetchLatest returns the most recent snapshot, or nil when none has been stored yet.
+func (r *productBillerSummaryRepository) FetchLatest(ctx context.Context) (*models.ProductBillerSummaryNotification, error) {
+	const query = `
+		SELECT payload
+		FROM product_biller_summaries
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	var payload []byte
+	if err := r.db.GetContext(ctx, &payload, query); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch latest product biller summary: %w", err)
+	}
+
+	var summary models.ProductBillerSummaryNotification
+	if err := json.Unmarshal(payload, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product biller summary: %w", err)
+	}
+
+	return &summary, nil
+}
@@ -2,8 +2,7 @@ package repositories
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"time"
 
 	"golang-boilerplate/internal/pkg/connections/db"
 	"golang-boilerplate/internal/pkg/models"
@@ -11,139 +10,70 @@ import (
 
 // ProductRepository defines the interface for managing Product entities.
 type ProductRepository interface {
-	Create(ctx context.Context, product *models.Product) error
-	Update(ctx context.Context, id int, product *models.Product) error
-	Delete(ctx context.Context, id int) error
+	Create(ctx context.Context, product *models.Product) (*models.Product, error)
+	Update(ctx context.Context, id, version int, product *models.Product) error
+	Patch(ctx context.Context, id, version int, patch *models.ProductPatch) error
+	Delete(ctx context.Context, id, version int, batch string) error
+	Restore(ctx context.Context, id int) error
+	Purge(ctx context.Context, before time.Time, limit int) (int64, error)
 	FetchOne(ctx context.Context, id int) (*models.Product, error)
-	FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Product, error)
-	FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Product, *db.Pagination, error)
+	FetchMany(ctx context.Context, filter models.ProductFilter) ([]*models.Product, error)
+	FetchEach(ctx context.Context, filter models.ProductFilter, fn func(product *models.Product) error) error
+	FetchManyWithPagination(ctx context.Context, filter models.ProductFilter, page, limit int) ([]*models.Product, *db.Pagination, error)
+	FetchManyWithCursor(ctx context.Context, filter models.ProductFilter, pagination *db.CursorPagination) ([]*models.Product, error)
 }
 
-// productRepository implements ProductRepository.
-type productRepository struct {
-	db db.DBExecutor
+var productTable = &Table[models.ProductFilter, models.ProductPatch]{
+	Name:               "products",
+	Entity:             "product",
+	Columns:            []string{"id", "label", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "deleted_batch", "version"},
+	InsertColumns:      []string{"label", "created_at", "created_by", "updated_at", "updated_by"},
+	UpdateColumns:      []string{"label", "updated_at"},
+	SoftDeleteColumn:   "deleted_at",
+	DeletedBatchColumn: "deleted_batch",
+	// Products stay while product billers, even soft-deleted ones, still reference them.
+	PurgeConditions: []string{"NOT EXISTS (SELECT 1 FROM product_billers pb WHERE pb.product_id = products.id)"},
+	VersionColumn:   "version",
+	DefaultOrder:    db.SortTerm{Column: "id"},
+	Where: func(filter models.ProductFilter) ([]string, []interface{}) {
+		var conditions []string
+		var args []interface{}
+
+		conditions = append(conditions, softDeleteConditions(filter.IncludeDeleted)...)
+
+		if filter.ID != nil {
+			conditions = append(conditions, "id = ?")
+			args = append(args, *filter.ID)
+		}
+		if filter.IDs != nil {
+			idCond, idArgs := inCondition("id", filter.IDs)
+			conditions = append(conditions, idCond)
+			args = append(args, idArgs...)
+		}
+
+		labelConds, labelArgs := labelConditions(filter.Label, filter.LabelPrefix)
+		conditions = append(conditions, labelConds...)
+		args = append(args, labelArgs...)
+
+		createdConds, createdArgs := createdConditions(filter.ListFilter)
+		conditions = append(conditions, createdConds...)
+		args = append(args, createdArgs...)
+
+		return conditions, args
+	},
+	Sort: func(filter models.ProductFilter) db.Sort {
+		return filter.Sort
+	},
+	Patch: func(patch models.ProductPatch) map[string]interface{} {
+		values := map[string]interface{}{}
+		if patch.Label != nil {
+			values["label"] = *patch.Label
+		}
+		return values
+	},
 }
 
 // NewProductRepository creates a new instance of ProductRepository.
 func NewProductRepository(db db.DBExecutor) ProductRepository {
-	return &productRepository{
-		db: db,
-	}
-}
-
-func (r *productRepository) Create(ctx context.Context, product *models.Product) error {
-	const query = `
-		INSERT INTO products 
-		(label, created_at, created_by, updated_at, updated_by)
-		VALUES (:label, NOW(6), :created_by, NOW(6), :updated_by)
-	`
-
-	_, err := r.db.NamedExecContext(ctx, query, product)
-	if err != nil {
-		return fmt.Errorf("failed to create product: %w", err)
-	}
-
-	return nil
-}
-
-func (r *productRepository) Update(ctx context.Context, id int, product *models.Product) error {
-	const query = `
-		UPDATE products
-		SET label = :label, updated_at = NOW(6)
-		WHERE id = :id AND deleted_at IS NULL
-	`
-
-	params := map[string]interface{}{
-		"id":    id,
-		"label": product.Label,
-	}
-
-	_, err := r.db.NamedExecContext(ctx, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to update product: %w", err)
-	}
-
-	return nil
-}
-
-func (r *productRepository) Delete(ctx context.Context, id int) error {
-	const query = `
-		UPDATE products
-		SET deleted_at = NOW(6)
-		WHERE id = :id AND deleted_at IS NULL
-	`
-
-	params := map[string]interface{}{
-		"id": id,
-	}
-
-	_, err := r.db.NamedExecContext(ctx, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
-	}
-
-	return nil
-}
-
-func (r *productRepository) getBaseQuery(filters map[string]interface{}) (string, []interface{}) {
-	var baseQuery = `
-		SELECT id, label, created_at, created_by, updated_at, updated_by
-		FROM products
-	`
-
-	var conditions []string
-	var args []interface{}
-
-	conditions = append(conditions, "deleted_at IS NULL")
-
-	if id, ok := filters["id"].(int); ok {
-		conditions = append(conditions, "id = ?")
-		args = append(args, id)
-	}
-	if label, ok := filters["label"].(string); ok {
-		conditions = append(conditions, "label LIKE ?")
-		args = append(args, "%"+label+"%")
-	}
-
-	if len(conditions) > 0 {
-		baseQuery = fmt.Sprintf("%s WHERE %s", baseQuery, strings.Join(conditions, " AND "))
-	}
-
-	return baseQuery, args
-}
-
-func (r *productRepository) FetchOne(ctx context.Context, id int) (*models.Product, error) {
-	query, args := r.getBaseQuery(map[string]interface{}{
-		"id": id,
-	})
-
-	var product models.Product
-	if err := r.db.GetContext(ctx, &product, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to fetch product: %w", err)
-	}
-
-	return &product, nil
-}
-
-func (r *productRepository) FetchMany(ctx context.Context, filter map[string]interface{}) ([]*models.Product, error) {
-	query, args := r.getBaseQuery(filter)
-
-	var products []*models.Product
-	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
-		return nil, fmt.Errorf("failed to fetch products: %w", err)
-	}
-
-	return products, nil
-}
-
-func (r *productRepository) FetchManyWithPagination(ctx context.Context, filter map[string]interface{}, page, limit int) ([]*models.Product, *db.Pagination, error) {
-	query, args := r.getBaseQuery(filter)
-
-	pagination := &db.Pagination{Order: "id ASC", Page: page, Limit: limit}
-	var products []*models.Product
-	if err := db.Paginate(ctx, r.db, query, args, pagination, &products); err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch products with pagination: %w", err)
-	}
-
-	return products, pagination, nil
+	return NewRepository[models.Product](db, productTable)
 }
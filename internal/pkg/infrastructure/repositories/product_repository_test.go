@@ -0,0 +1,50 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestProductRepository_FetchManyWithPagination_Filters(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	sqlx.NameMapper = strcase.ToSnake
+	sqlxDB := sqlx.NewDb(sqlDB, "mysql")
+	repo := repositories.NewProductRepository(sqlxDB)
+
+	sort, err := db.ParseSort("-updated_at,label", repositories.ProductSortColumns)
+	require.NoError(t, err)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	where := `FROM products WHERE label LIKE \? AND label LIKE \? AND created_at >= \?`
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM \( ?SELECT .* `+where+`\) AS count`).
+		WithArgs(`%50\%%`, `Pulsa\_%`, from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT .* `+where+` ORDER BY updated_at DESC, label ASC, id ASC LIMIT 10 OFFSET 0`).
+		WithArgs(`%50\%%`, `Pulsa\_%`, from).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(1, "Pulsa_50%"))
+
+	products, pagination, err := repo.FetchManyWithPagination(context.Background(), models.ProductFilter{
+		Label:          "50%",
+		LabelPrefix:    "Pulsa_",
+		IncludeDeleted: true,
+		ListFilter:     models.ListFilter{Sort: sort, CreatedFrom: &from},
+	}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, 1, pagination.TotalRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,441 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/utils"
+)
+
+// ErrDuplicateEntry is returned by Create, Update and Patch when the entity violates a unique key.
+var ErrDuplicateEntry = errors.New("duplicate entry detected")
+
+// Table describes the table a Repository reads and writes, how the list filter F of its entity applies,
+// and which columns a patch P of its entity changes.
+type Table[F any, P any] struct {
+	// Name is the table name.
+	Name string
+	// Entity names a single row in error messages, e.g. "product biller".
+	Entity string
+	// Columns are selected by every fetch, in order.
+	Columns []string
+	// InsertColumns are bound from the entity on Create, UpdateColumns on Update. Timestamp columns
+	// (created_at, updated_at) are set to the current time instead.
+	InsertColumns []string
+	UpdateColumns []string
+	// SoftDeleteColumn is set on Delete instead of removing the row; rows where it is set are left alone
+	// by Update and Delete and not fetched by FetchOne. Empty means rows are deleted for good.
+	SoftDeleteColumn string
+	// DeletedBatchColumn records the batch given to Delete on soft-deleted rows, so the rows deleted together,
+	// e.g. by a cascade, can be restored together. Empty means batches are not recorded.
+	DeletedBatchColumn string
+	// PurgeConditions are the conditions, joined with AND, a soft-deleted row must also meet to be purged,
+	// e.g. not being referenced by rows that are kept.
+	PurgeConditions []string
+	// PurgeDependents are the tables referencing rows of this one without a foreign key. Their rows
+	// referencing purged rows are deleted in the same transaction.
+	PurgeDependents []Dependent
+	// VersionColumn enables optimistic concurrency control: Update and Delete only apply to the row while
+	// it still has the version they are given, and increment it. Empty means writes are last-write-wins.
+	VersionColumn string
+	// DefaultOrder is used when the filter requests no sort.
+	DefaultOrder db.SortTerm
+	// Where returns the conditions of the filter, joined with AND. It handles soft-deleted rows itself,
+	// so the filter can decide whether to include them.
+	Where func(filter F) ([]string, []interface{})
+	// Sort returns the sort requested by the filter.
+	Sort func(filter F) db.Sort
+	// Patch returns the values of the UpdateColumns the patch changes, keyed by column. Columns it leaves
+	// out keep their value.
+	Patch func(patch P) map[string]interface{}
+}
+
+// Dependent is a table whose Column references the ID of rows of another table.
+type Dependent struct {
+	Table  string
+	Column string
+}
+
+// timestampColumns are set by the database on insert and update rather than bound from the entity.
+var timestampColumns = map[string]bool{"created_at": true, "updated_at": true}
+
+// Repository implements the CRUD and list queries shared by entities stored in a single table,
+// described by its Table. T is the entity model, F its list filter and P its patch.
+type Repository[T any, F any, P any] struct {
+	db    db.DBExecutor
+	table *Table[F, P]
+}
+
+// NewRepository creates a new instance of Repository for the given table.
+func NewRepository[T any, F any, P any](db db.DBExecutor, table *Table[F, P]) *Repository[T, F, P] {
+	return &Repository[T, F, P]{
+		db:    db,
+		table: table,
+	}
+}
+
+// Create inserts the entity and returns it as stored, reloaded by the ID the database assigned to it.
+func (r *Repository[T, F, P]) Create(ctx context.Context, entity *T) (*T, error) {
+	values := make([]string, len(r.table.InsertColumns))
+	for i, column := range r.table.InsertColumns {
+		values[i] = r.namedValue(column)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		r.table.Name, strings.Join(r.table.InsertColumns, ", "), strings.Join(values, ", "),
+	)
+
+	result, err := r.db.NamedExecContext(ctx, query, entity)
+	if err != nil {
+		if duplicate := duplicateEntry(err); duplicate != nil {
+			return nil, duplicate
+		}
+		return nil, fmt.Errorf("failed to create %s: %w", r.table.Entity, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ID of created %s: %w", r.table.Entity, err)
+	}
+
+	return r.FetchOne(ctx, int(id))
+}
+
+// duplicateEntry returns ErrDuplicateEntry, naming the duplicated key, when err is a unique key violation,
+// and nil otherwise.
+func duplicateEntry(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		return fmt.Errorf("%w: %s", ErrDuplicateEntry, utils.ParseDuplicateEntry(mysqlErr.Message))
+	}
+	return nil
+}
+
+// Update writes the entity over the row with the given ID. On a versioned table it returns sql.ErrNoRows
+// when the row does not exist or no longer has the given version.
+func (r *Repository[T, F, P]) Update(ctx context.Context, id, version int, entity *T) error {
+	assignments := make([]string, len(r.table.UpdateColumns))
+	for i, column := range r.table.UpdateColumns {
+		assignments[i] = column + " = " + r.namedValue(column)
+	}
+
+	return r.update(ctx, "update", id, version, assignments, entity)
+}
+
+// Patch writes the columns changed by the patch over the row with the given ID, along with the timestamp
+// columns. Like Update, it returns sql.ErrNoRows on a versioned table when the row does not exist or no
+// longer has the given version.
+func (r *Repository[T, F, P]) Patch(ctx context.Context, id, version int, patch *P) error {
+	values := r.table.Patch(*patch)
+
+	// Assignments follow UpdateColumns, so the statement is the same whatever order the patch was built in.
+	var assignments []string
+	for _, column := range r.table.UpdateColumns {
+		if _, ok := values[column]; ok || timestampColumns[column] {
+			assignments = append(assignments, column+" = "+r.namedValue(column))
+		}
+	}
+
+	return r.update(ctx, "patch", id, version, assignments, values)
+}
+
+// update runs an UPDATE of the row with the given ID setting assignments, whose named values are bound
+// from arg. On a versioned table it also increments the version, and fails unless the row has the given one.
+func (r *Repository[T, F, P]) update(ctx context.Context, operation string, id, version int, assignments []string, arg interface{}) error {
+	if r.table.VersionColumn != "" {
+		assignments = append(assignments, r.table.VersionColumn+" = "+r.table.VersionColumn+" + 1")
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	// The SET clause is bound from arg, which does not necessarily hold the ID, so id is appended afterwards.
+	query, args, err := r.db.BindNamed(
+		fmt.Sprintf("UPDATE %s SET %s", r.table.Name, strings.Join(assignments, ", ")),
+		arg,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w", operation, r.table.Entity, err)
+	}
+	conditions, conditionArgs := r.versionedIDConditions(id, version)
+	query += " WHERE " + strings.Join(conditions, " AND ")
+
+	result, err := r.db.ExecContext(ctx, query, append(args, conditionArgs...)...)
+	if err != nil {
+		if duplicate := duplicateEntry(err); duplicate != nil {
+			return duplicate
+		}
+		return fmt.Errorf("failed to %s %s: %w", operation, r.table.Entity, err)
+	}
+
+	return r.checkVersioned(result, operation, id, version)
+}
+
+// Delete removes the row with the given ID, or soft-deletes it as part of batch. On a versioned table it
+// returns sql.ErrNoRows when the row does not exist or no longer has the given version.
+func (r *Repository[T, F, P]) Delete(ctx context.Context, id, version int, batch string) error {
+	conditions, args := r.versionedIDConditions(id, version)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", r.table.Name, strings.Join(conditions, " AND "))
+	if r.table.SoftDeleteColumn != "" {
+		assignments := []string{r.table.SoftDeleteColumn + " = NOW(6)"}
+		if r.table.DeletedBatchColumn != "" {
+			assignments = append(assignments, r.table.DeletedBatchColumn+" = ?")
+			args = append([]interface{}{batch}, args...)
+		}
+		if r.table.VersionColumn != "" {
+			assignments = append(assignments, r.table.VersionColumn+" = "+r.table.VersionColumn+" + 1")
+		}
+		query = fmt.Sprintf(
+			"UPDATE %s SET %s WHERE %s",
+			r.table.Name, strings.Join(assignments, ", "), strings.Join(conditions, " AND "),
+		)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", r.table.Entity, err)
+	}
+
+	return r.checkVersioned(result, "delete", id, version)
+}
+
+// Restore undoes the soft delete of the row with the given ID, incrementing its version on a versioned
+// table. It returns sql.ErrNoRows when no soft-deleted row has the given ID.
+func (r *Repository[T, F, P]) Restore(ctx context.Context, id int) error {
+	if r.table.SoftDeleteColumn == "" {
+		return fmt.Errorf("failed to restore %s: rows are not soft-deleted", r.table.Entity)
+	}
+
+	assignments := []string{r.table.SoftDeleteColumn + " = NULL"}
+	if r.table.DeletedBatchColumn != "" {
+		assignments = append(assignments, r.table.DeletedBatchColumn+" = NULL")
+	}
+	if r.table.VersionColumn != "" {
+		assignments = append(assignments, r.table.VersionColumn+" = "+r.table.VersionColumn+" + 1")
+	}
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE id = ? AND %s IS NOT NULL",
+		r.table.Name, strings.Join(assignments, ", "), r.table.SoftDeleteColumn,
+	)
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", r.table.Entity, err)
+	}
+
+	// The soft delete column always changes, so a matched row is always counted.
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", r.table.Entity, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("failed to restore %s %d: %w", r.table.Entity, id, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Purge deletes for good at most limit rows soft-deleted before the given time that meet the
+// PurgeConditions, along with the rows of the PurgeDependents referencing them, returning how many
+// were deleted.
+func (r *Repository[T, F, P]) Purge(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if r.table.SoftDeleteColumn == "" {
+		return 0, nil
+	}
+
+	conditions := append([]string{r.table.SoftDeleteColumn + " < ?"}, r.table.PurgeConditions...)
+	if len(r.table.PurgeDependents) == 0 {
+		query := fmt.Sprintf(
+			"DELETE FROM %s WHERE %s ORDER BY %s LIMIT ?",
+			r.table.Name, strings.Join(conditions, " AND "), r.table.SoftDeleteColumn,
+		)
+
+		result, err := r.db.ExecContext(ctx, query, before, limit)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge %ss: %w", r.table.Entity, err)
+		}
+
+		purged, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge %ss: %w", r.table.Entity, err)
+		}
+
+		return purged, nil
+	}
+
+	var purged int64
+	err := db.WithTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		// The batch is locked so rows restored meanwhile are neither purged nor left without dependents.
+		query := fmt.Sprintf(
+			"SELECT id FROM %s WHERE %s ORDER BY %s LIMIT ? FOR UPDATE",
+			r.table.Name, strings.Join(conditions, " AND "), r.table.SoftDeleteColumn,
+		)
+		var ids []int
+		if err := tx.SelectContext(ctx, &ids, query, before, limit); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		for _, dependent := range r.table.PurgeDependents {
+			idCond, idArgs := inCondition(dependent.Column, ids)
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", dependent.Table, idCond), idArgs...); err != nil {
+				return fmt.Errorf("failed to purge %s: %w", dependent.Table, err)
+			}
+		}
+
+		idCond, idArgs := inCondition("id", ids)
+		result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", r.table.Name, idCond), idArgs...)
+		if err != nil {
+			return err
+		}
+		purged, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge %ss: %w", r.table.Entity, err)
+	}
+
+	return purged, nil
+}
+
+func (r *Repository[T, F, P]) FetchOne(ctx context.Context, id int) (*T, error) {
+	query := r.selectQuery(r.idConditions())
+
+	var entity T
+	if err := r.db.GetContext(ctx, &entity, query, id); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", r.table.Entity, err)
+	}
+
+	return &entity, nil
+}
+
+func (r *Repository[T, F, P]) FetchMany(ctx context.Context, filter F) ([]*T, error) {
+	query, args := r.getBaseQuery(filter)
+	query = fmt.Sprintf("%s ORDER BY %s", query, r.table.Sort(filter).OrderOr(r.table.DefaultOrder.String()))
+
+	var entities []*T
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch %ss: %w", r.table.Entity, err)
+	}
+
+	return entities, nil
+}
+
+// FetchEach passes the entities matching the filter to fn one at a time, in the order of the filter,
+// without loading them all in memory. It stops at the first error returned by fn.
+func (r *Repository[T, F, P]) FetchEach(ctx context.Context, filter F, fn func(entity *T) error) error {
+	query, args := r.getBaseQuery(filter)
+	query = fmt.Sprintf("%s ORDER BY %s", query, r.table.Sort(filter).OrderOr(r.table.DefaultOrder.String()))
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %ss: %w", r.table.Entity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entity T
+		if err := rows.StructScan(&entity); err != nil {
+			return fmt.Errorf("failed to scan %s: %w", r.table.Entity, err)
+		}
+		if err := fn(&entity); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to fetch %ss: %w", r.table.Entity, err)
+	}
+
+	return nil
+}
+
+func (r *Repository[T, F, P]) FetchManyWithPagination(ctx context.Context, filter F, page, limit int) ([]*T, *db.Pagination, error) {
+	query, args := r.getBaseQuery(filter)
+
+	pagination := &db.Pagination{Order: r.table.Sort(filter).OrderOr(r.table.DefaultOrder.String()), Page: page, Limit: limit}
+	var entities []*T
+	if err := db.Paginate(ctx, r.db, query, args, pagination, &entities); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch %ss with pagination: %w", r.table.Entity, err)
+	}
+
+	return entities, pagination, nil
+}
+
+func (r *Repository[T, F, P]) FetchManyWithCursor(ctx context.Context, filter F, pagination *db.CursorPagination) ([]*T, error) {
+	query, args := r.getBaseQuery(filter)
+
+	pagination.Order = r.table.Sort(filter).TermsOr(r.table.DefaultOrder)
+	var entities []*T
+	if err := db.PaginateCursor(ctx, r.db, query, args, pagination, &entities); err != nil {
+		return nil, fmt.Errorf("failed to fetch %ss with cursor: %w", r.table.Entity, err)
+	}
+
+	return entities, nil
+}
+
+func (r *Repository[T, F, P]) getBaseQuery(filter F) (string, []interface{}) {
+	conditions, args := r.table.Where(filter)
+	return r.selectQuery(conditions), args
+}
+
+func (r *Repository[T, F, P]) selectQuery(conditions []string) string {
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(r.table.Columns, ", "), r.table.Name)
+	if len(conditions) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(conditions, " AND "))
+	}
+	return query
+}
+
+// idConditions matches the row with a given ID, unless it is soft-deleted.
+func (r *Repository[T, F, P]) idConditions() []string {
+	if r.table.SoftDeleteColumn == "" {
+		return []string{"id = ?"}
+	}
+	return []string{"id = ?", r.table.SoftDeleteColumn + " IS NULL"}
+}
+
+// versionedIDConditions matches the row with a given ID and, on a versioned table, version.
+func (r *Repository[T, F, P]) versionedIDConditions(id, version int) ([]string, []interface{}) {
+	conditions, args := r.idConditions(), []interface{}{id}
+	if r.table.VersionColumn != "" {
+		conditions = append(conditions, r.table.VersionColumn+" = ?")
+		args = append(args, version)
+	}
+	return conditions, args
+}
+
+// checkVersioned reports sql.ErrNoRows when a versioned write matched no row. Unversioned writes are not
+// checked, as MySQL does not count rows an update leaves unchanged.
+func (r *Repository[T, F, P]) checkVersioned(result sql.Result, operation string, id, version int) error {
+	if r.table.VersionColumn == "" {
+		return nil
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w", operation, r.table.Entity, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("failed to %s %s %d at version %d: %w", operation, r.table.Entity, id, version, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository[T, F, P]) namedValue(column string) string {
+	if timestampColumns[column] {
+		return "NOW(6)"
+	}
+	return ":" + column
+}
@@ -0,0 +1,234 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+	"golang-boilerplate/internal/pkg/infrastructure/repositories"
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func newRepositoryDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	sqlx.NameMapper = strcase.ToSnake
+	return sqlx.NewDb(sqlDB, "mysql"), mock
+}
+
+func TestRepository_SoftDeleteTable(t *testing.T) {
+	sqlxDB, mock := newRepositoryDB(t)
+	repo := repositories.NewProductRepository(sqlxDB)
+	ctx := context.Background()
+
+	mock.ExpectExec(`INSERT INTO products \(label, created_at, created_by, updated_at, updated_by\) VALUES \(\?, NOW\(6\), \?, NOW\(6\), \?\)`).
+		WithArgs("Pulsa", "admin", "admin").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT id, label, .* FROM products WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(1, "Pulsa"))
+	created, err := repo.Create(ctx, &models.Product{Label: "Pulsa", CreatedBy: "admin", UpdatedBy: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+
+	mock.ExpectExec(`UPDATE products SET label = \?, updated_at = NOW\(6\), version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`).
+		WithArgs("Data", 1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Update(ctx, 1, 1, &models.Product{Label: "Data"}))
+
+	// An empty patch still bumps the timestamp and version.
+	mock.ExpectExec(`UPDATE products SET updated_at = NOW\(6\), version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Patch(ctx, 1, 2, &models.ProductPatch{}))
+
+	// A stale version matches no row.
+	mock.ExpectExec(`UPDATE products SET deleted_at = NOW\(6\), deleted_batch = \?, version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`).
+		WithArgs("batch", 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Delete(ctx, 1, 2, "batch"), sql.ErrNoRows)
+
+	mock.ExpectExec(`UPDATE products SET deleted_at = NOW\(6\), deleted_batch = \?, version = version \+ 1 WHERE id = \? AND deleted_at IS NULL AND version = \?`).
+		WithArgs("batch", 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Delete(ctx, 1, 3, "batch"))
+
+	mock.ExpectExec(`UPDATE products SET deleted_at = NULL, deleted_batch = NULL, version = version \+ 1 WHERE id = \? AND deleted_at IS NOT NULL`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Restore(ctx, 1))
+
+	// Restoring a row that is not deleted matches no row.
+	mock.ExpectExec(`UPDATE products SET deleted_at = NULL, .* WHERE id = \? AND deleted_at IS NOT NULL`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Restore(ctx, 1), sql.ErrNoRows)
+
+	mock.ExpectQuery(`SELECT id, label, .* FROM products WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(1, "Data"))
+	product, err := repo.FetchOne(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Data", product.Label)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_DuplicateEntry(t *testing.T) {
+	sqlxDB, mock := newRepositoryDB(t)
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+	ctx := context.Background()
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1-2' for key 'product_billers.uq_product_biller'"}
+
+	mock.ExpectExec(`INSERT INTO product_billers`).WillReturnError(duplicate)
+	_, err := repo.Create(ctx, &models.ProductBiller{ProductID: 1, BillerID: 2})
+	assert.ErrorIs(t, err, repositories.ErrDuplicateEntry)
+
+	mock.ExpectExec(`UPDATE product_billers SET`).WillReturnError(duplicate)
+	assert.ErrorIs(t, repo.Update(ctx, 7, 1, &models.ProductBiller{ProductID: 1, BillerID: 2}), repositories.ErrDuplicateEntry)
+
+	mock.ExpectExec(`UPDATE product_billers SET`).WillReturnError(duplicate)
+	weight := 2
+	assert.ErrorIs(t, repo.Patch(ctx, 7, 1, &models.ProductBillerPatch{Weight: &weight}), repositories.ErrDuplicateEntry)
+
+	productRepo := repositories.NewProductRepository(sqlxDB)
+	mock.ExpectExec(`UPDATE products SET`).WillReturnError(duplicate)
+	assert.ErrorIs(t, productRepo.Update(ctx, 1, 1, &models.Product{Label: "Data"}), repositories.ErrDuplicateEntry)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Purge(t *testing.T) {
+	sqlxDB, mock := newRepositoryDB(t)
+	repo := repositories.NewProductRepository(sqlxDB)
+	before := time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`DELETE FROM products WHERE deleted_at < \? AND NOT EXISTS \(SELECT 1 FROM product_billers pb WHERE pb.product_id = products.id\) ORDER BY deleted_at LIMIT \?`).
+		WithArgs(before, 500).
+		WillReturnResult(sqlmock.NewResult(0, 42))
+
+	purged, err := repo.Purge(context.Background(), before, 500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_PurgeDependents(t *testing.T) {
+	sqlxDB, mock := newRepositoryDB(t)
+	repo := repositories.NewProductBillerRepository(sqlxDB)
+	before := time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM product_billers WHERE deleted_at < \? ORDER BY deleted_at LIMIT \? FOR UPDATE`).
+		WithArgs(before, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(9))
+	mock.ExpectExec(`DELETE FROM product_biller_stats WHERE product_biller_id IN \(\?, \?\)`).
+		WithArgs(4, 9).
+		WillReturnResult(sqlmock.NewResult(0, 30))
+	mock.ExpectExec(`DELETE FROM product_biller_stat_transactions WHERE product_biller_id IN \(\?, \?\)`).
+		WithArgs(4, 9).
+		WillReturnResult(sqlmock.NewResult(0, 12))
+	mock.ExpectExec(`DELETE FROM product_billers WHERE id IN \(\?, \?\)`).
+		WithArgs(4, 9).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	purged, err := repo.Purge(context.Background(), before, 500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	// An empty batch deletes nothing.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM product_billers`).WithArgs(before, 500).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	purged, err = repo.Purge(context.Background(), before, 500)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_HardDeleteTable(t *testing.T) {
+	type tag struct {
+		ID    int
+		Label string
+	}
+	type tagFilter struct {
+		Label string
+		Sort  db.Sort
+	}
+	type tagPatch struct {
+		Label *string
+	}
+
+	sqlxDB, mock := newRepositoryDB(t)
+	repo := repositories.NewRepository[tag](sqlxDB, &repositories.Table[tagFilter, tagPatch]{
+		Name:          "tags",
+		Entity:        "tag",
+		Columns:       []string{"id", "label"},
+		InsertColumns: []string{"label"},
+		UpdateColumns: []string{"label"},
+		DefaultOrder:  db.SortTerm{Column: "label"},
+		Where: func(filter tagFilter) ([]string, []interface{}) {
+			if filter.Label == "" {
+				return nil, nil
+			}
+			return []string{"label = ?"}, []interface{}{filter.Label}
+		},
+		Sort: func(filter tagFilter) db.Sort {
+			return filter.Sort
+		},
+		Patch: func(patch tagPatch) map[string]interface{} {
+			values := map[string]interface{}{}
+			if patch.Label != nil {
+				values["label"] = *patch.Label
+			}
+			return values
+		},
+	})
+	ctx := context.Background()
+
+	label := "promo"
+	mock.ExpectExec(`UPDATE tags SET label = \? WHERE id = \?`).
+		WithArgs(label, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Patch(ctx, 3, 0, &tagPatch{Label: &label}))
+
+	// Nothing to write on an unversioned table without timestamps.
+	require.NoError(t, repo.Patch(ctx, 3, 0, &tagPatch{}))
+
+	mock.ExpectExec(`DELETE FROM tags WHERE id = \?`).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Delete(ctx, 3, 0, ""))
+
+	mock.ExpectQuery(`SELECT id, label FROM tags WHERE label = \? ORDER BY label ASC`).
+		WithArgs("promo").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(3, "promo"))
+	tags, err := repo.FetchMany(ctx, tagFilter{Label: "promo"})
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, 3, tags[0].ID)
+
+	mock.ExpectQuery(`SELECT id, label FROM tags ORDER BY label ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(3, "promo").AddRow(4, "sale"))
+	var labels []string
+	require.NoError(t, repo.FetchEach(ctx, tagFilter{}, func(t *tag) error {
+		labels = append(labels, t.Label)
+		return nil
+	}))
+	assert.Equal(t, []string{"promo", "sale"}, labels)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
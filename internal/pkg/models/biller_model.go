@@ -13,23 +13,28 @@ type Biller struct {
 	UpdatedBy string
 	DeletedAt *time.Time
 	DeletedBy string
+	// DeletedBatch is shared by the rows soft-deleted together, so they can be restored together.
+	DeletedBatch *string
+	Version      int
 }
 
 func (b *Biller) ToResponse() *BillerResponse {
 	return &BillerResponse{
-		ID:        b.ID,
-		Label:     b.Label,
-		CreatedAt: b.CreatedAt,
-		CreatedBy: b.CreatedBy,
-		UpdatedAt: b.UpdatedAt,
-		UpdatedBy: b.UpdatedBy,
-		DeletedAt: b.DeletedAt,
-		DeletedBy: b.DeletedBy,
+		ID:           b.ID,
+		Label:        b.Label,
+		CreatedAt:    b.CreatedAt,
+		CreatedBy:    b.CreatedBy,
+		UpdatedAt:    b.UpdatedAt,
+		UpdatedBy:    b.UpdatedBy,
+		DeletedAt:    b.DeletedAt,
+		DeletedBy:    b.DeletedBy,
+		DeletedBatch: b.DeletedBatch,
+		Version:      b.Version,
 	}
 }
 
 type CreateBillerRequest struct {
-	Label string `json:"label" validate:"required"`
+	Label string `json:"label" validate:"required,label"`
 }
 
 func (b *CreateBillerRequest) ToEntity() *Biller {
@@ -39,7 +44,7 @@ func (b *CreateBillerRequest) ToEntity() *Biller {
 }
 
 type UpdateBillerRequest struct {
-	Label string `json:"label" validate:"required"`
+	Label string `json:"label" validate:"required,label"`
 }
 
 func (b *UpdateBillerRequest) ToEntity() *Biller {
@@ -48,13 +53,31 @@ func (b *UpdateBillerRequest) ToEntity() *Biller {
 	}
 }
 
+// PatchBillerRequest is a JSON Merge Patch of a Biller: fields left out of the body keep their value.
+type PatchBillerRequest struct {
+	Label *string `json:"label" validate:"omitempty,label"`
+}
+
+func (b *PatchBillerRequest) ToPatch() *BillerPatch {
+	return &BillerPatch{
+		Label: b.Label,
+	}
+}
+
+// BillerPatch holds the fields of a Biller to change; nil fields are left unchanged.
+type BillerPatch struct {
+	Label *string
+}
+
 type BillerResponse struct {
-	ID        int        `json:"id"`
-	Label     string     `json:"label"`
-	CreatedAt time.Time  `json:"created_at"`
-	CreatedBy string     `json:"created_by"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	UpdatedBy string     `json:"updated_by"`
-	DeletedAt *time.Time `json:"deleted_at"`
-	DeletedBy string     `json:"deleted_by"`
+	ID           int        `json:"id"`
+	Label        string     `json:"label"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    string     `json:"created_by"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	UpdatedBy    string     `json:"updated_by"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+	DeletedBy    string     `json:"deleted_by"`
+	DeletedBatch *string    `json:"deleted_batch"`
+	Version      int        `json:"version"`
 }
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"golang-boilerplate/internal/pkg/connections/db"
+)
+
+// ListFilter holds the filters shared by the list queries of every entity.
+type ListFilter struct {
+	// Sort is parsed separately, against the sort whitelist of the entity.
+	Sort        db.Sort
+	CreatedFrom *time.Time `query:"created_from"`
+	CreatedTo   *time.Time `query:"created_to"`
+}
+
+// Untagged fields of the filters below are only set by code, never bound from query parameters.
+
+type ProductFilter struct {
+	ID             *int
+	IDs            []int
+	Label          string `query:"label"`
+	LabelPrefix    string `query:"label_prefix"`
+	IncludeDeleted bool   `query:"include_deleted"`
+	ListFilter
+}
+
+type BillerFilter struct {
+	ID             *int
+	IDs            []int
+	Label          string `query:"label"`
+	LabelPrefix    string `query:"label_prefix"`
+	IncludeDeleted bool   `query:"include_deleted"`
+	ListFilter
+}
+
+type ProductBillerFilter struct {
+	ID               *int
+	ProductID        *int  `query:"product_id" validate:"omitempty,id"`
+	BillerID         *int  `query:"biller_id" validate:"omitempty,id"`
+	IsActive         *bool `query:"is_active"`
+	IncludeDeleted   bool  `query:"include_deleted"`
+	ProductIDs       []int
+	BillerIDs        []int
+	DeactivatedSince *time.Time
+	ListFilter
+}
+
+type NotificationFilter struct {
+	ID         *int
+	Status     string `query:"status" validate:"omitempty,oneof=pending sent failed"`
+	Type       string `query:"type"`
+	ClaimToken string
+	ListFilter
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCanceled  = "canceled"
+)
+
+const (
+	// JobTypeProductBillerImport imports product billers in bulk; its payload is a ProductBillerImportJob.
+	JobTypeProductBillerImport = "product_biller_import"
+	// JobTypeProductBillerActivation activates or deactivates product billers in bulk; its payload is a
+	// ProductBillerActivationRequest.
+	JobTypeProductBillerActivation = "product_biller_activation"
+)
+
+// Job is a unit of background work queued by the HTTP service and run by a worker.
+type Job struct {
+	ID              int
+	Type            string
+	Payload         json.RawMessage
+	Status          string
+	ProgressDone    int
+	ProgressTotal   int
+	Result          json.RawMessage
+	LastError       *string
+	CancelRequested bool
+	ClaimToken      *string
+	HeartbeatAt     *time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	CreatedAt       time.Time
+	CreatedBy       string
+	UpdatedAt       time.Time
+}
+
+// Finished reports whether the job reached a final status.
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCanceled
+}
+
+func (j *Job) ToResponse() *JobResponse {
+	lastError := ""
+	if j.LastError != nil {
+		lastError = *j.LastError
+	}
+
+	// A job that does not report a total has made no measurable progress until it succeeds.
+	progress := 0.0
+	switch {
+	case j.Status == JobStatusSucceeded:
+		progress = 100
+	case j.ProgressTotal > 0:
+		progress = float64(j.ProgressDone) * 100 / float64(j.ProgressTotal)
+	}
+
+	return &JobResponse{
+		ID:              j.ID,
+		Type:            j.Type,
+		Status:          j.Status,
+		Progress:        progress,
+		ProgressDone:    j.ProgressDone,
+		ProgressTotal:   j.ProgressTotal,
+		Result:          j.Result,
+		Error:           lastError,
+		CancelRequested: j.CancelRequested,
+		StartedAt:       j.StartedAt,
+		FinishedAt:      j.FinishedAt,
+		CreatedAt:       j.CreatedAt,
+		CreatedBy:       j.CreatedBy,
+		UpdatedAt:       j.UpdatedAt,
+	}
+}
+
+type JobResponse struct {
+	ID              int             `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Progress        float64         `json:"progress"`
+	ProgressDone    int             `json:"progress_done"`
+	ProgressTotal   int             `json:"progress_total"`
+	Result          json.RawMessage `json:"result"`
+	Error           string          `json:"error"`
+	CancelRequested bool            `json:"cancel_requested"`
+	StartedAt       *time.Time      `json:"started_at"`
+	FinishedAt      *time.Time      `json:"finished_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	CreatedBy       string          `json:"created_by"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// ProductBillerImportJob is the payload of a JobTypeProductBillerImport job.
+type ProductBillerImportJob struct {
+	Rows []*ProductBillerImportRow `json:"rows"`
+}
+
+// ProductBillerActivationRequest activates or deactivates the product billers of a product, of a biller,
+// or of both. At least one of them must be given, so a mistake cannot switch off the whole catalog.
+// The user who queued the job is recorded as having made the change.
+type ProductBillerActivationRequest struct {
+	ProductID *int  `json:"product_id" validate:"required_without=BillerID,omitempty,id,exists=product"`
+	BillerID  *int  `json:"biller_id" validate:"required_without=ProductID,omitempty,id,exists=biller"`
+	IsActive  *bool `json:"is_active" validate:"required"`
+}
+
+// ProductBillerActivationResult is the result of a JobTypeProductBillerActivation job.
+type ProductBillerActivationResult struct {
+	Updated int `json:"updated"`
+	// Conflicts counts the product billers modified concurrently, which are left as they are.
+	Conflicts int `json:"conflicts"`
+}
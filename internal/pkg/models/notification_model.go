@@ -1,10 +1,149 @@
 package models
 
-import "time"
+import (
+	"cmp"
+	"slices"
+	"time"
+)
 
 type ProductBillerSummaryNotification struct {
-	DateTime time.Time `json:"datetime"`
-	Total    int       `json:"total"`
-	Active   int       `json:"active"`
-	Inactive int       `json:"inactive"`
+	DateTime    time.Time                    `json:"datetime"`
+	Total       int                          `json:"total"`
+	Active      int                          `json:"active"`
+	Inactive    int                          `json:"inactive"`
+	Billers     []ProductBillerGroupSummary  `json:"billers"`
+	Products    []ProductBillerGroupSummary  `json:"products"`
+	Deactivated []DeactivatedProductBiller   `json:"deactivated"`
+	Changes     *ProductBillerSummaryChanges `json:"changes"` // Nil when there is no previous summary.
+}
+
+// SummaryProductLimit caps the products broken down in a summary message. The others only count in the totals.
+const SummaryProductLimit = 20
+
+// ListedProducts returns the products broken down in the summary message, those with the most inactive product
+// billers first.
+func (s ProductBillerSummaryNotification) ListedProducts() []ProductBillerGroupSummary {
+	products := slices.Clone(s.Products)
+	slices.SortStableFunc(products, func(a, b ProductBillerGroupSummary) int {
+		return cmp.Compare(b.Inactive, a.Inactive)
+	})
+	return products[:min(len(products), SummaryProductLimit)]
+}
+
+// UnlistedProducts counts the products left out of the summary message breakdown.
+func (s ProductBillerSummaryNotification) UnlistedProducts() int {
+	return max(len(s.Products)-SummaryProductLimit, 0)
+}
+
+// ProductBillerGroupSummary counts the product billers of a single biller or product.
+type ProductBillerGroupSummary struct {
+	ID       int    `json:"id"`
+	Label    string `json:"label"`
+	Total    int    `json:"total"`
+	Active   int    `json:"active"`
+	Inactive int    `json:"inactive"`
+}
+
+// DeactivatedProductBiller describes a product biller deactivated within the summary window.
+type DeactivatedProductBiller struct {
+	ID            int       `json:"id"`
+	ProductID     int       `json:"product_id"`
+	ProductLabel  string    `json:"product_label"`
+	BillerID      int       `json:"biller_id"`
+	BillerLabel   string    `json:"biller_label"`
+	DeactivatedAt time.Time `json:"deactivated_at"`
+	DeactivatedBy string    `json:"deactivated_by"`
+	Reason        string    `json:"reason"`
+}
+
+// ProductBillerSummaryChanges holds the differences against the previous summary.
+// Only billers and products whose counts changed are listed.
+type ProductBillerSummaryChanges struct {
+	Since    time.Time                   `json:"since"`
+	Total    int                         `json:"total"`
+	Active   int                         `json:"active"`
+	Inactive int                         `json:"inactive"`
+	Billers  []ProductBillerGroupSummary `json:"billers"`
+	Products []ProductBillerGroupSummary `json:"products"`
+}
+
+// ListedProducts returns the changed products broken down in the summary message.
+func (c ProductBillerSummaryChanges) ListedProducts() []ProductBillerGroupSummary {
+	return c.Products[:min(len(c.Products), SummaryProductLimit)]
+}
+
+// UnlistedProducts counts the changed products left out of the summary message breakdown.
+func (c ProductBillerSummaryChanges) UnlistedProducts() int {
+	return max(len(c.Products)-SummaryProductLimit, 0)
+}
+
+// DiffFrom computes the changes of s against the previous summary.
+func (s *ProductBillerSummaryNotification) DiffFrom(previous *ProductBillerSummaryNotification) *ProductBillerSummaryChanges {
+	return &ProductBillerSummaryChanges{
+		Since:    previous.DateTime,
+		Total:    s.Total - previous.Total,
+		Active:   s.Active - previous.Active,
+		Inactive: s.Inactive - previous.Inactive,
+		Billers:  diffGroups(s.Billers, previous.Billers),
+		Products: diffGroups(s.Products, previous.Products),
+	}
+}
+
+func diffGroups(current, previous []ProductBillerGroupSummary) []ProductBillerGroupSummary {
+	previousByID := make(map[int]ProductBillerGroupSummary, len(previous))
+	for _, group := range previous {
+		previousByID[group.ID] = group
+	}
+
+	diffs := make([]ProductBillerGroupSummary, 0)
+	for _, group := range current {
+		prev := previousByID[group.ID]
+		delete(previousByID, group.ID)
+
+		diff := ProductBillerGroupSummary{
+			ID:       group.ID,
+			Label:    group.Label,
+			Total:    group.Total - prev.Total,
+			Active:   group.Active - prev.Active,
+			Inactive: group.Inactive - prev.Inactive,
+		}
+		if diff.Total != 0 || diff.Active != 0 || diff.Inactive != 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	// Groups that disappeared since the previous summary.
+	for _, group := range previous {
+		if _, ok := previousByID[group.ID]; ok {
+			diffs = append(diffs, ProductBillerGroupSummary{
+				ID:       group.ID,
+				Label:    group.Label,
+				Total:    -group.Total,
+				Active:   -group.Active,
+				Inactive: -group.Inactive,
+			})
+		}
+	}
+
+	return diffs
+}
+
+// ProductBillerDeactivationNotification groups automatic deactivations that happened close together.
+type ProductBillerDeactivationNotification struct {
+	DateTime      time.Time                   `json:"datetime"`
+	Deactivations []ProductBillerDeactivation `json:"deactivations"`
+	// Suppressed counts deactivations left out because their product biller was alerted recently.
+	Suppressed int `json:"suppressed"`
+}
+
+// ProductBillerDeactivation describes a product biller deactivated by a failed transaction.
+type ProductBillerDeactivation struct {
+	ProductBillerID   int       `json:"product_biller_id"`
+	ProductID         int       `json:"product_id"`
+	ProductLabel      string    `json:"product_label"`
+	BillerID          int       `json:"biller_id"`
+	BillerLabel       string    `json:"biller_label"`
+	TransactionID     int       `json:"transaction_id"`
+	TransactionStatus string    `json:"transaction_status"`
+	DeactivatedAt     time.Time `json:"deactivated_at"`
 }
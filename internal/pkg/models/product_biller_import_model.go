@@ -0,0 +1,47 @@
+package models
+
+// ProductBillerImportStatus is the outcome of importing a single row.
+type ProductBillerImportStatus string
+
+const (
+	ProductBillerImportCreated   ProductBillerImportStatus = "created"
+	ProductBillerImportDuplicate ProductBillerImportStatus = "duplicate"
+	ProductBillerImportInvalid   ProductBillerImportStatus = "invalid"
+)
+
+// ProductBillerImportRow is a row of a bulk import. Error is set when the row could not be parsed or
+// validated, in which case it is reported invalid without being imported.
+type ProductBillerImportRow struct {
+	Row     int                        `json:"row"`
+	Request CreateProductBillerRequest `json:"request"`
+	Error   string                     `json:"error,omitempty"`
+}
+
+// ProductBillerImportResult reports what happened to a row of a bulk import.
+type ProductBillerImportResult struct {
+	Row    int                       `json:"row"`
+	Status ProductBillerImportStatus `json:"status"`
+	ID     int                       `json:"id,omitempty"`
+	Error  string                    `json:"error,omitempty"`
+}
+
+// ProductBillerImportReport reports the outcome of a bulk import, row by row and in total.
+type ProductBillerImportReport struct {
+	Created   int                         `json:"created"`
+	Duplicate int                         `json:"duplicate"`
+	Invalid   int                         `json:"invalid"`
+	Rows      []ProductBillerImportResult `json:"rows"`
+}
+
+// Add records the result of a row.
+func (r *ProductBillerImportReport) Add(result ProductBillerImportResult) {
+	switch result.Status {
+	case ProductBillerImportCreated:
+		r.Created++
+	case ProductBillerImportDuplicate:
+		r.Duplicate++
+	case ProductBillerImportInvalid:
+		r.Invalid++
+	}
+	r.Rows = append(r.Rows, result)
+}
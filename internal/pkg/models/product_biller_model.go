@@ -5,66 +5,171 @@ import (
 )
 
 type ProductBiller struct {
-	ID        int
-	ProductID int
-	BillerID  int
-	IsActive  bool
-	CreatedAt time.Time
-	CreatedBy string
-	UpdatedAt time.Time
-	UpdatedBy string
-	DeletedAt *time.Time
-	DeletedBy string
+	ID                 int
+	ProductID          int
+	BillerID           int
+	IsActive           bool
+	Priority           int
+	Weight             int
+	DeactivatedAt      *time.Time
+	DeactivatedBy      string
+	DeactivationReason string
+	CreatedAt          time.Time
+	CreatedBy          string
+	UpdatedAt          time.Time
+	UpdatedBy          string
+	DeletedAt          *time.Time
+	DeletedBy          string
+	DeletedBatch       *string
+	Version            int
 }
 
 func (pb *ProductBiller) ToResponse() *ProductBillerResponse {
 	return &ProductBillerResponse{
-		ID:        pb.ID,
-		ProductID: pb.ProductID,
-		BillerID:  pb.BillerID,
-		IsActive:  pb.IsActive,
-		CreatedAt: pb.CreatedAt,
-		CreatedBy: pb.CreatedBy,
-		UpdatedAt: pb.UpdatedAt,
-		UpdatedBy: pb.UpdatedBy,
-		DeletedAt: pb.DeletedAt,
-		DeletedBy: pb.DeletedBy,
+		ID:                 pb.ID,
+		ProductID:          pb.ProductID,
+		BillerID:           pb.BillerID,
+		IsActive:           pb.IsActive,
+		Priority:           pb.Priority,
+		Weight:             pb.Weight,
+		DeactivatedAt:      pb.DeactivatedAt,
+		DeactivatedBy:      pb.DeactivatedBy,
+		DeactivationReason: pb.DeactivationReason,
+		CreatedAt:          pb.CreatedAt,
+		CreatedBy:          pb.CreatedBy,
+		UpdatedAt:          pb.UpdatedAt,
+		UpdatedBy:          pb.UpdatedBy,
+		DeletedAt:          pb.DeletedAt,
+		DeletedBy:          pb.DeletedBy,
+		DeletedBatch:       pb.DeletedBatch,
+		Version:            pb.Version,
+	}
+}
+
+// ProductBillerDetail is a ProductBiller along with its product and biller, fetched together by a join.
+type ProductBillerDetail struct {
+	ProductBiller
+	Product *Product
+	Biller  *Biller
+}
+
+// ProductBillerExpand selects the related resources embedded in a ProductBillerResponse.
+type ProductBillerExpand struct {
+	Product bool
+	Biller  bool
+}
+
+// Any reports whether any related resource is selected.
+func (e ProductBillerExpand) Any() bool {
+	return e.Product || e.Biller
+}
+
+// ToResponse returns the response of the product biller, embedding the related resources selected by expand.
+func (d *ProductBillerDetail) ToResponse(expand ProductBillerExpand) *ProductBillerResponse {
+	response := d.ProductBiller.ToResponse()
+	if expand.Product && d.Product != nil {
+		response.Product = d.Product.ToResponse()
+	}
+	if expand.Biller && d.Biller != nil {
+		response.Biller = d.Biller.ToResponse()
 	}
+	return response
 }
 
+// DefaultProductBillerPriority and DefaultProductBillerWeight are the routing priority and weight of product
+// billers created or replaced without them, as the columns default to.
+const (
+	DefaultProductBillerPriority = 0
+	DefaultProductBillerWeight   = 1
+)
+
 type CreateProductBillerRequest struct {
-	ProductID int  `json:"product_id" validate:"required"`
-	BillerID  int  `json:"biller_id" validate:"required"`
-	IsActive  bool `json:"is_active" validate:"required"`
+	ProductID int `json:"product_id" validate:"required,id,exists=product"`
+	BillerID  int `json:"biller_id" validate:"required,id,exists=biller"`
+	// IsActive is a pointer so that "required" rejects a missing value rather than false.
+	IsActive *bool `json:"is_active" validate:"required"`
+	// Priority and Weight are pointers so that missing values take their defaults rather than 0.
+	Priority *int `json:"priority" validate:"omitempty,gte=0"`
+	Weight   *int `json:"weight" validate:"omitempty,gte=1"`
 }
 
 func (pb *CreateProductBillerRequest) ToEntity() *ProductBiller {
 	return &ProductBiller{
 		ProductID: pb.ProductID,
 		BillerID:  pb.BillerID,
-		IsActive:  pb.IsActive,
+		IsActive:  *pb.IsActive,
+		Priority:  valueOr(pb.Priority, DefaultProductBillerPriority),
+		Weight:    valueOr(pb.Weight, DefaultProductBillerWeight),
 	}
 }
 
 type UpdateProductBillerRequest struct {
-	IsActive bool `json:"is_active" validate:"required"`
+	// IsActive is a pointer so that "required" rejects a missing value rather than false.
+	IsActive *bool `json:"is_active" validate:"required"`
+	// Priority and Weight are pointers so that missing values take their defaults rather than 0.
+	Priority *int `json:"priority" validate:"omitempty,gte=0"`
+	Weight   *int `json:"weight" validate:"omitempty,gte=1"`
 }
 
 func (pb *UpdateProductBillerRequest) ToEntity() *ProductBiller {
 	return &ProductBiller{
+		IsActive: *pb.IsActive,
+		Priority: valueOr(pb.Priority, DefaultProductBillerPriority),
+		Weight:   valueOr(pb.Weight, DefaultProductBillerWeight),
+	}
+}
+
+// valueOr returns the value v points to, or def when v is nil.
+func valueOr(v *int, def int) int {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// PatchProductBillerRequest is a JSON Merge Patch of a ProductBiller: fields left out of the body keep their value.
+type PatchProductBillerRequest struct {
+	IsActive *bool `json:"is_active"`
+	Priority *int  `json:"priority" validate:"omitempty,gte=0"`
+	Weight   *int  `json:"weight" validate:"omitempty,gte=1"`
+}
+
+func (pb *PatchProductBillerRequest) ToPatch() *ProductBillerPatch {
+	return &ProductBillerPatch{
 		IsActive: pb.IsActive,
+		Priority: pb.Priority,
+		Weight:   pb.Weight,
 	}
 }
 
+// ProductBillerPatch holds the fields of a ProductBiller to change; nil fields are left unchanged.
+// UpdatedBy is always written.
+type ProductBillerPatch struct {
+	IsActive  *bool
+	Priority  *int
+	Weight    *int
+	UpdatedBy string
+}
+
 type ProductBillerResponse struct {
-	ID        int        `json:"id"`
-	ProductID int        `json:"product_id"`
-	BillerID  int        `json:"biller_id"`
-	IsActive  bool       `json:"is_active"`
-	CreatedAt time.Time  `json:"created_at"`
-	CreatedBy string     `json:"created_by"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	UpdatedBy string     `json:"updated_by"`
-	DeletedAt *time.Time `json:"deleted_at"`
-	DeletedBy string     `json:"deleted_by"`
+	ID                 int        `json:"id"`
+	ProductID          int        `json:"product_id"`
+	BillerID           int        `json:"biller_id"`
+	IsActive           bool       `json:"is_active"`
+	Priority           int        `json:"priority"`
+	Weight             int        `json:"weight"`
+	DeactivatedAt      *time.Time `json:"deactivated_at"`
+	DeactivatedBy      string     `json:"deactivated_by"`
+	DeactivationReason string     `json:"deactivation_reason"`
+	CreatedAt          time.Time  `json:"created_at"`
+	CreatedBy          string     `json:"created_by"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	UpdatedBy          string     `json:"updated_by"`
+	DeletedAt          *time.Time `json:"deleted_at"`
+	DeletedBy          string     `json:"deleted_by"`
+	DeletedBatch       *string    `json:"deleted_batch"`
+	Version            int        `json:"version"`
+	// Product and Biller are embedded when requested with expand.
+	Product *ProductResponse `json:"product,omitempty"`
+	Biller  *BillerResponse  `json:"biller,omitempty"`
 }
@@ -0,0 +1,32 @@
+package models
+
+type ProductBillerRoute struct {
+	ProductID int
+	Strategy  string
+	Selected  *ProductBiller
+	Biller    *Biller
+	Fallbacks []*ProductBiller
+}
+
+func (r *ProductBillerRoute) ToResponse() *ProductBillerRouteResponse {
+	fallbacks := make([]*ProductBillerResponse, len(r.Fallbacks))
+	for i, pb := range r.Fallbacks {
+		fallbacks[i] = pb.ToResponse()
+	}
+
+	return &ProductBillerRouteResponse{
+		ProductID:     r.ProductID,
+		Strategy:      r.Strategy,
+		ProductBiller: r.Selected.ToResponse(),
+		Biller:        r.Biller.ToResponse(),
+		Fallbacks:     fallbacks,
+	}
+}
+
+type ProductBillerRouteResponse struct {
+	ProductID     int                      `json:"product_id"`
+	Strategy      string                   `json:"strategy"`
+	ProductBiller *ProductBillerResponse   `json:"product_biller"`
+	Biller        *BillerResponse          `json:"biller"`
+	Fallbacks     []*ProductBillerResponse `json:"fallbacks"`
+}
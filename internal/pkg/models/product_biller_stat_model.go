@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StatBucketMinute = "minute"
+	StatBucketHour   = "hour"
+)
+
+// StatBuckets lists the bucket sizes every transaction outcome is aggregated into.
+var StatBuckets = []string{StatBucketMinute, StatBucketHour}
+
+// StatBucketStart truncates t to the start of the bucket it belongs to.
+func StatBucketStart(bucket string, t time.Time) time.Time {
+	switch bucket {
+	case StatBucketMinute:
+		return t.Truncate(time.Minute)
+	default:
+		return t.Truncate(time.Hour)
+	}
+}
+
+// IsValidStatBucket reports whether bucket is a supported bucket size.
+func IsValidStatBucket(bucket string) bool {
+	for _, b := range StatBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+type ProductBillerStat struct {
+	ProductBillerID int
+	Bucket          string
+	BucketStart     time.Time
+	Total           int
+	Success         int
+	Failure         int
+}
+
+func (s *ProductBillerStat) ToResponse() *ProductBillerStatResponse {
+	return &ProductBillerStatResponse{
+		BucketStart: s.BucketStart,
+		Total:       s.Total,
+		Success:     s.Success,
+		Failure:     s.Failure,
+		SuccessRate: successRate(s.Success, s.Total),
+	}
+}
+
+type ProductBillerStatResponse struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Total       int       `json:"total"`
+	Success     int       `json:"success"`
+	Failure     int       `json:"failure"`
+	SuccessRate float64   `json:"success_rate"`
+}
+
+type ProductBillerStatsResponse struct {
+	ProductBillerID int                          `json:"product_biller_id"`
+	Bucket          string                       `json:"bucket"`
+	From            time.Time                    `json:"from"`
+	To              time.Time                    `json:"to"`
+	Total           int                          `json:"total"`
+	Success         int                          `json:"success"`
+	Failure         int                          `json:"failure"`
+	SuccessRate     float64                      `json:"success_rate"`
+	Buckets         []*ProductBillerStatResponse `json:"buckets"`
+}
+
+// NewProductBillerStatsResponse aggregates the given buckets into a single response.
+func NewProductBillerStatsResponse(productBillerID int, bucket string, from, to time.Time, stats []*ProductBillerStat) *ProductBillerStatsResponse {
+	response := &ProductBillerStatsResponse{
+		ProductBillerID: productBillerID,
+		Bucket:          bucket,
+		From:            from,
+		To:              to,
+		Buckets:         make([]*ProductBillerStatResponse, len(stats)),
+	}
+
+	for i, stat := range stats {
+		response.Total += stat.Total
+		response.Success += stat.Success
+		response.Failure += stat.Failure
+		response.Buckets[i] = stat.ToResponse()
+	}
+	response.SuccessRate = successRate(response.Success, response.Total)
+
+	return response
+}
+
+// successRate returns the success ratio in the range [0, 1], or 0 when there is no traffic.
+func successRate(success, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(success) / float64(total)
+}
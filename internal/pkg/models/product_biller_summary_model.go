@@ -0,0 +1,10 @@
+package models
+
+// ProductBillerSummary holds aggregated product biller counts, overall and per group.
+type ProductBillerSummary struct {
+	Total     int
+	Active    int
+	Inactive  int
+	ByBiller  []ProductBillerGroupSummary
+	ByProduct []ProductBillerGroupSummary
+}
@@ -13,23 +13,28 @@ type Product struct {
 	UpdatedBy string
 	DeletedAt *time.Time
 	DeletedBy string
+	// DeletedBatch is shared by the rows soft-deleted together, so they can be restored together.
+	DeletedBatch *string
+	Version      int
 }
 
 func (p *Product) ToResponse() *ProductResponse {
 	return &ProductResponse{
-		ID:        p.ID,
-		Label:     p.Label,
-		CreatedAt: p.CreatedAt,
-		CreatedBy: p.CreatedBy,
-		UpdatedAt: p.UpdatedAt,
-		UpdatedBy: p.UpdatedBy,
-		DeletedAt: p.DeletedAt,
-		DeletedBy: p.DeletedBy,
+		ID:           p.ID,
+		Label:        p.Label,
+		CreatedAt:    p.CreatedAt,
+		CreatedBy:    p.CreatedBy,
+		UpdatedAt:    p.UpdatedAt,
+		UpdatedBy:    p.UpdatedBy,
+		DeletedAt:    p.DeletedAt,
+		DeletedBy:    p.DeletedBy,
+		DeletedBatch: p.DeletedBatch,
+		Version:      p.Version,
 	}
 }
 
 type CreateProductRequest struct {
-	Label string `json:"label" validate:"required"`
+	Label string `json:"label" validate:"required,label"`
 }
 
 func (p *CreateProductRequest) ToEntity() *Product {
@@ -39,7 +44,7 @@ func (p *CreateProductRequest) ToEntity() *Product {
 }
 
 type UpdateProductRequest struct {
-	Label string `json:"label" validate:"required"`
+	Label string `json:"label" validate:"required,label"`
 }
 
 func (p *UpdateProductRequest) ToEntity() *Product {
@@ -48,13 +53,31 @@ func (p *UpdateProductRequest) ToEntity() *Product {
 	}
 }
 
+// PatchProductRequest is a JSON Merge Patch of a Product: fields left out of the body keep their value.
+type PatchProductRequest struct {
+	Label *string `json:"label" validate:"omitempty,label"`
+}
+
+func (p *PatchProductRequest) ToPatch() *ProductPatch {
+	return &ProductPatch{
+		Label: p.Label,
+	}
+}
+
+// ProductPatch holds the fields of a Product to change; nil fields are left unchanged.
+type ProductPatch struct {
+	Label *string
+}
+
 type ProductResponse struct {
-	ID        int        `json:"id"`
-	Label     string     `json:"label"`
-	CreatedAt time.Time  `json:"created_at"`
-	CreatedBy string     `json:"created_by"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	UpdatedBy string     `json:"updated_by"`
-	DeletedAt *time.Time `json:"deleted_at"`
-	DeletedBy string     `json:"deleted_by"`
+	ID           int        `json:"id"`
+	Label        string     `json:"label"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    string     `json:"created_by"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	UpdatedBy    string     `json:"updated_by"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+	DeletedBy    string     `json:"deleted_by"`
+	DeletedBatch *string    `json:"deleted_batch"`
+	Version      int        `json:"version"`
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// PurgeReport counts the soft-deleted rows purged for good, which were deleted before Before.
+type PurgeReport struct {
+	Before         time.Time
+	ProductBillers int64
+	Products       int64
+	Billers        int64
+}
+
+// StatPurgeReport counts the transaction stats purged, whose bucket started before StatsBefore, and the
+// recorded transactions purged, which were recorded before TransactionsBefore.
+type StatPurgeReport struct {
+	StatsBefore        time.Time
+	TransactionsBefore time.Time
+	Stats              int64
+	Transactions       int64
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	NotificationStatusPending = "pending"
+	NotificationStatusSent    = "sent"
+	NotificationStatusFailed  = "failed"
+)
+
+// QueuedNotification is a notification stored in the delivery queue. ClaimToken identifies the dispatcher
+// delivering it, whose claim lasts until NextAttemptAt, and DeliveredChannels lists, comma-separated, the
+// channels it was already sent to.
+type QueuedNotification struct {
+	ID                int
+	Type              string
+	Subject           string
+	Payload           json.RawMessage
+	Status            string
+	ClaimToken        *string
+	Attempts          int
+	DeliveredChannels string
+	NextAttemptAt     *time.Time
+	LastError         *string
+	SentAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (n *QueuedNotification) ToResponse() *QueuedNotificationResponse {
+	lastError := ""
+	if n.LastError != nil {
+		lastError = *n.LastError
+	}
+
+	return &QueuedNotificationResponse{
+		ID:                n.ID,
+		Type:              n.Type,
+		Subject:           n.Subject,
+		Payload:           n.Payload,
+		Status:            n.Status,
+		Attempts:          n.Attempts,
+		DeliveredChannels: n.Delivered(),
+		NextAttemptAt:     n.NextAttemptAt,
+		LastError:         lastError,
+		SentAt:            n.SentAt,
+		CreatedAt:         n.CreatedAt,
+		UpdatedAt:         n.UpdatedAt,
+	}
+}
+
+// Delivered returns the channels the notification was already sent to.
+func (n *QueuedNotification) Delivered() []string {
+	if n.DeliveredChannels == "" {
+		return []string{}
+	}
+	return strings.Split(n.DeliveredChannels, ",")
+}
+
+// MarkDelivered records that the notification was sent to channels.
+func (n *QueuedNotification) MarkDelivered(channels ...string) {
+	n.DeliveredChannels = strings.Join(append(n.Delivered(), channels...), ",")
+}
+
+type QueuedNotificationResponse struct {
+	ID                int             `json:"id"`
+	Type              string          `json:"type"`
+	Subject           string          `json:"subject"`
+	Payload           json.RawMessage `json:"payload"`
+	Status            string          `json:"status"`
+	Attempts          int             `json:"attempts"`
+	DeliveredChannels []string        `json:"delivered_channels"`
+	NextAttemptAt     *time.Time      `json:"next_attempt_at"`
+	LastError         string          `json:"last_error"`
+	SentAt            *time.Time      `json:"sent_at"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
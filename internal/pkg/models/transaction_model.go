@@ -1,5 +1,8 @@
 package models
 
+// TransactionStatusSuccess is the status reported for a successful transaction.
+const TransactionStatusSuccess = "success"
+
 // Transaction represents the structure of the transaction message.
 type Transaction struct {
 	ID        int    `json:"id"`
@@ -8,3 +11,8 @@ type Transaction struct {
 	BillerID  int    `json:"biller_id"`
 	Status    string `json:"status"`
 }
+
+// IsSuccess reports whether the transaction completed successfully.
+func (t *Transaction) IsSuccess() bool {
+	return t.Status == TransactionStatusSuccess
+}
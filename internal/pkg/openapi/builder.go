@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route documents a route of an API.
+type Route struct {
+	Method string
+	// Path is the Echo path of the route relative to the API prefix, e.g. /products/:id.
+	Path    string
+	Tag     string
+	Summary string
+	// Query is a struct whose query tagged fields are bound from the query parameters.
+	Query interface{}
+	// Params lists the query and header parameters read besides those of Query.
+	Params []Parameter
+	// Body is the JSON request body, or a Content for other media types.
+	Body interface{}
+	// Responses holds the response bodies by status, JSON unless a Content, or nil for no body.
+	Responses map[int]interface{}
+}
+
+// Content holds bodies by media type, where nil stands for a file.
+type Content map[string]interface{}
+
+// Builder builds the document of an API.
+type Builder struct {
+	Info Info
+	// Prefix is the path the routes of the API are registered under, e.g. /api/v1.
+	Prefix string
+	// Error is the body of the error responses every operation may answer.
+	Error interface{}
+	// Response, when set, returns the schema of the JSON response bodies documented as body, for APIs that
+	// answer them in a different shape, such as wrapped in an envelope. Content responses are left as is.
+	Response func(g *Generator, body interface{}) *Schema
+	// Deprecated marks every operation of the API as deprecated.
+	Deprecated bool
+}
+
+// Build generates the document of the routes registered under the prefix that docs document. Documentation
+// of routes that are not registered is left out, as are registered routes without documentation.
+func (b Builder) Build(registered []*echo.Route, docs []Route) *Document {
+	g := newGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    b.Info,
+		Paths:   map[string]*PathItem{},
+	}
+
+	byRoute := make(map[string]Route, len(docs))
+	for _, route := range docs {
+		byRoute[route.Method+" "+b.Prefix+route.Path] = route
+	}
+
+	for _, route := range registered {
+		documented, ok := byRoute[route.Method+" "+route.Path]
+		if !ok {
+			continue
+		}
+
+		path := openAPIPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = b.operation(g, route.Path, documented)
+	}
+
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+func (b Builder) operation(g *Generator, path string, route Route) *Operation {
+	op := &Operation{
+		Summary:     route.Summary,
+		OperationID: operationID(route.Method, strings.TrimPrefix(path, b.Prefix)),
+		Deprecated:  b.Deprecated,
+		Responses:   map[string]*Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	op.Parameters = append(op.Parameters, pathParameters(path)...)
+	op.Parameters = append(op.Parameters, g.parameters(route.Query)...)
+	op.Parameters = append(op.Parameters, route.Params...)
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: content(g, route.Body)}
+	}
+
+	statuses := make([]int, 0, len(route.Responses))
+	for status := range route.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	for _, status := range statuses {
+		response := &Response{Description: http.StatusText(status)}
+		if body := route.Responses[status]; body != nil {
+			response.Content = b.responseContent(g, body)
+		}
+		op.Responses[strconv.Itoa(status)] = response
+	}
+	if b.Error != nil {
+		op.Responses["default"] = &Response{Description: "Error", Content: content(g, b.Error)}
+	}
+
+	return op
+}
+
+func (b Builder) responseContent(g *Generator, body interface{}) map[string]*MediaType {
+	if _, ok := body.(Content); ok || b.Response == nil {
+		return content(g, body)
+	}
+	return map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: b.Response(g, body)}}
+}
+
+func content(g *Generator, body interface{}) map[string]*MediaType {
+	bodies, ok := body.(Content)
+	if !ok {
+		bodies = Content{echo.MIMEApplicationJSON: body}
+	}
+
+	media := make(map[string]*MediaType, len(bodies))
+	for mediaType, body := range bodies {
+		schema := &Schema{Type: "string", Format: "binary"}
+		if body != nil {
+			schema = g.Schema(body)
+		}
+		media[mediaType] = &MediaType{Schema: schema}
+	}
+	return media
+}
+
+// openAPIPath turns the parameters of an Echo path, such as :id, into OpenAPI ones, such as {id}.
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParameters documents the parameters of an Echo path, which are IDs when named id or *_id.
+func pathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+		schema := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			schema = &Schema{Type: "integer", Minimum: float(1)}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return params
+}
+
+// operationID derives an ID from the method and path of a route, e.g. getProductsById for GET /products/:id.
+func operationID(method, path string) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			id.WriteString("By")
+			segment = name
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' }) {
+			id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return id.String()
+}
@@ -0,0 +1,127 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+type audit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type widget struct {
+	ID     int     `json:"id"`
+	Label  string  `json:"label" validate:"required,label"`
+	Weight int     `json:"weight" validate:"gte=0"`
+	Parent *widget `json:"parent"`
+	Secret string  `json:"-"`
+	audit
+}
+
+type widgetFilter struct {
+	ID       *int   `query:"id" validate:"omitempty,id"`
+	Status   string `query:"status" validate:"omitempty,oneof=on off"`
+	Internal bool
+}
+
+type page[T any] struct {
+	Data []T `json:"data"`
+}
+
+func TestBuilder_Build(t *testing.T) {
+	e := echo.New()
+	handler := func(echo.Context) error { return nil }
+	e.GET("/api/v1/widgets", handler)
+	e.PUT("/api/v1/widgets/:id", handler)
+	e.GET("/api/v1/undocumented", handler)
+
+	doc := openapi.Builder{Info: openapi.Info{Title: "Widgets", Version: "1.0.0"}, Prefix: "/api/v1"}.Build(e.Routes(), []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/widgets", Tag: "Widgets",
+			Query:     widgetFilter{},
+			Responses: map[int]interface{}{http.StatusOK: page[widget]{}},
+		},
+		{
+			Method: http.MethodPut, Path: "/widgets/:id",
+			Body:      widget{},
+			Responses: map[int]interface{}{http.StatusNoContent: nil},
+		},
+		{Method: http.MethodDelete, Path: "/widgets/:id"},
+	})
+
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	require.Len(t, doc.Paths, 2, "undocumented routes are left out")
+	item := *doc.Paths["/api/v1/widgets/{id}"]
+	require.Contains(t, item, "put")
+	assert.NotContains(t, item, "delete")
+
+	put := item["put"]
+	assert.Equal(t, "putWidgetsById", put.OperationID)
+	require.Len(t, put.Parameters, 1)
+	assert.Equal(t, "path", put.Parameters[0].In)
+	assert.Equal(t, "integer", put.Parameters[0].Schema.Type)
+	assert.Equal(t, "#/components/schemas/Widget", put.RequestBody.Content[echo.MIMEApplicationJSON].Schema.Ref)
+	assert.Empty(t, put.Responses["204"].Content)
+
+	list := (*doc.Paths["/api/v1/widgets"])["get"]
+	assert.Equal(t, []string{"Widgets"}, list.Tags)
+	require.Len(t, list.Parameters, 2)
+	assert.Equal(t, "id", list.Parameters[0].Name)
+	assert.Equal(t, 1.0, *list.Parameters[0].Schema.Minimum)
+	assert.Equal(t, []string{"on", "off"}, list.Parameters[1].Schema.Enum)
+	assert.Equal(t, "#/components/schemas/PageWidget", list.Responses["200"].Content[echo.MIMEApplicationJSON].Schema.Ref)
+
+	schema := doc.Components.Schemas["Widget"]
+	require.NotNil(t, schema)
+	assert.Equal(t, []string{"label"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.Equal(t, "#/components/schemas/Widget", schema.Properties["parent"].Ref)
+	assert.Equal(t, 0.0, *schema.Properties["weight"].Minimum)
+	assert.NotEmpty(t, schema.Properties["label"].Pattern)
+	assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+	assert.True(t, schema.Properties["deleted_at"].Nullable)
+}
+
+func TestBuilder_Build_Version(t *testing.T) {
+	e := echo.New()
+	handler := func(echo.Context) error { return nil }
+	e.GET("/api/v2/widgets/:id", handler)
+	e.GET("/api/v2/widgets/:id/export", handler)
+
+	type envelope struct {
+		Data interface{} `json:"data"`
+	}
+	doc := openapi.Builder{
+		Prefix: "/api/v2",
+		Error:  envelope{},
+		Response: func(g *openapi.Generator, body interface{}) *openapi.Schema {
+			return &openapi.Schema{AllOf: []*openapi.Schema{
+				g.Schema(envelope{}),
+				{Type: "object", Properties: map[string]*openapi.Schema{"data": g.Schema(body)}},
+			}}
+		},
+		Deprecated: true,
+	}.Build(e.Routes(), []openapi.Route{
+		{Method: http.MethodGet, Path: "/widgets/:id", Responses: map[int]interface{}{http.StatusOK: widget{}}},
+		{Method: http.MethodGet, Path: "/widgets/:id/export", Responses: map[int]interface{}{http.StatusOK: openapi.Content{"text/csv": nil}}},
+	})
+
+	get := (*doc.Paths["/api/v2/widgets/{id}"])["get"]
+	assert.True(t, get.Deprecated)
+	wrapped := get.Responses["200"].Content[echo.MIMEApplicationJSON].Schema
+	require.Len(t, wrapped.AllOf, 2)
+	assert.Equal(t, "#/components/schemas/Envelope", wrapped.AllOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/Widget", wrapped.AllOf[1].Properties["data"].Ref)
+	assert.Equal(t, "#/components/schemas/Envelope", get.Responses["default"].Content[echo.MIMEApplicationJSON].Schema.Ref)
+
+	export := (*doc.Paths["/api/v2/widgets/{id}/export"])["get"]
+	assert.Equal(t, "binary", export.Responses["200"].Content["text/csv"].Schema.Format, "content responses are not wrapped")
+}
@@ -0,0 +1,261 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+// Schemer is implemented by types that describe their own schema, such as a field holding one of several types.
+type Schemer interface {
+	OpenAPISchema(g *Generator) *Schema
+}
+
+var (
+	schemerType    = reflect.TypeOf((*Schemer)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Generator generates schemas from Go types, following their json and validate tags. Structs become
+// components of the document, referenced wherever they are used.
+type Generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *Generator {
+	return &Generator{
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// Schema returns the schema of the type of v.
+func (g *Generator) Schema(v interface{}) *Schema {
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t.Implements(schemerType) {
+		return reflect.Zero(t).Interface().(Schemer).OpenAPISchema(g)
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := g.schemaOf(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: float(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return g.ref(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// ref returns a reference to the component of struct type t, adding it on first use.
+func (g *Generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = g.componentName(t)
+		// Named before its fields are generated, so recursive types end in a reference.
+		g.names[t] = name
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		g.schemas[name] = schema
+		g.addFields(schema, t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			if embedded := indirect(field.Type); embedded.Kind() == reflect.Struct {
+				g.addFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaOf(field.Type)
+		if options == "string" {
+			property = &Schema{Type: "string"}
+		}
+		if applyRules(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// parameters returns the query parameters bound into the fields of query by their query tags.
+func (g *Generator) parameters(query interface{}) []Parameter {
+	if query == nil {
+		return nil
+	}
+	return g.queryParameters(indirect(reflect.TypeOf(query)))
+}
+
+func (g *Generator) queryParameters(t reflect.Type) []Parameter {
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("query")
+		if field.Anonymous && name == "" {
+			if embedded := indirect(field.Type); embedded.Kind() == reflect.Struct {
+				params = append(params, g.queryParameters(embedded)...)
+			}
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+
+		schema := g.schemaOf(field.Type)
+		schema.Nullable = false
+		required := applyRules(schema, field.Tag.Get("validate"))
+		params = append(params, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+// applyRules documents the validate rules of a field on its schema, reporting whether the field is required.
+// Rules cannot be added to references, which would change the referenced component.
+func applyRules(schema *Schema, rules string) bool {
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		tag, param, _ := strings.Cut(rule, "=")
+		if tag == "required" {
+			required = true
+		}
+		if schema.Ref != "" {
+			continue
+		}
+
+		bound, err := strconv.ParseFloat(param, 64)
+		hasBound := err == nil
+		switch {
+		case tag == "id":
+			schema.Minimum = float(1)
+		case tag == "label":
+			schema.MinLength = length(1)
+			schema.MaxLength = length(validation.MaxLabelLength)
+			schema.Pattern = validation.LabelPattern
+		case tag == "oneof":
+			schema.Enum = strings.Fields(param)
+		case tag == "exists":
+			schema.Description = "ID of an existing " + param
+		case (tag == "gte" || tag == "gt" || tag == "min") && hasBound:
+			if schema.Type == "string" {
+				schema.MinLength = length(int(bound))
+			} else {
+				schema.Minimum = float(bound)
+				schema.ExclusiveMinimum = tag == "gt"
+			}
+		case (tag == "lte" || tag == "lt" || tag == "max") && hasBound:
+			if schema.Type == "string" {
+				schema.MaxLength = length(int(bound))
+			} else {
+				schema.Maximum = float(bound)
+				schema.ExclusiveMaximum = tag == "lt"
+			}
+		}
+	}
+	return required
+}
+
+// componentName names the component of t after its type name, turning generic instances such as
+// pageOf[models.ProductResponse] into PageOfProductResponse.
+func (g *Generator) componentName(t reflect.Type) string {
+	name := t.Name()
+	if base, args, ok := strings.Cut(name, "["); ok {
+		name = capitalize(base)
+		for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+			name += capitalize(arg[strings.LastIndex(arg, ".")+1:])
+		}
+	}
+	name = capitalize(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name))
+	if name == "" {
+		name = "Object"
+	}
+
+	unique := name
+	for i := 2; g.schemas[unique] != nil; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	return unique
+}
+
+func capitalize(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func float(f float64) *float64 {
+	return &f
+}
+
+func length(n int) *int {
+	return &n
+}
@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"io/fs"
+)
+
+//go:embed swagger_ui.html
+var swaggerUIPage string
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(swaggerUIPage))
+
+// swaggerUIFiles holds the vendored swagger-ui-dist assets, see swaggerui/README.md.
+//
+//go:embed swaggerui
+var swaggerUIFiles embed.FS
+
+// SwaggerUI renders the Swagger UI page browsing the document served at specURL. The page loads the
+// Swagger UI assets from assetsURL, where SwaggerUIAssets are served.
+func SwaggerUI(title, specURL, assetsURL string) ([]byte, error) {
+	var page bytes.Buffer
+	err := swaggerUITemplate.Execute(&page, map[string]string{
+		"Title":     title,
+		"SpecURL":   specURL,
+		"AssetsURL": assetsURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page.Bytes(), nil
+}
+
+// SwaggerUIAssets returns the Swagger UI assets, embedded into the binary.
+func SwaggerUIAssets() fs.FS {
+	assets, err := fs.Sub(swaggerUIFiles, "swaggerui")
+	if err != nil {
+		// Unreachable: the directory is embedded above.
+		panic(err)
+	}
+	return assets
+}
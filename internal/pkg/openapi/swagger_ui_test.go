@@ -0,0 +1,29 @@
+package openapi_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/openapi"
+)
+
+func TestSwaggerUI(t *testing.T) {
+	page, err := openapi.SwaggerUI("Widgets", "/api/docs/v1/openapi.json", "/api/docs/assets")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `href="/api/docs/assets/swagger-ui.css"`)
+	assert.Contains(t, string(page), `src="/api/docs/assets/swagger-ui-bundle.js"`)
+	assert.NotContains(t, string(page), "https://", "the page loads nothing from third party hosts")
+}
+
+func TestSwaggerUIAssets(t *testing.T) {
+	// The page loads these assets, which make swagger-ui vendors.
+	for _, name := range []string{"swagger-ui.css", "swagger-ui-bundle.js", "LICENSE"} {
+		info, err := fs.Stat(openapi.SwaggerUIAssets(), name)
+		if assert.NoErrorf(t, err, "%s is not vendored, run make swagger-ui", name) {
+			assert.NotZerof(t, info.Size(), "%s is empty", name)
+		}
+	}
+}
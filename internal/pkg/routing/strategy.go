@@ -0,0 +1,110 @@
+package routing
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+const (
+	StrategyWeighted   = "weighted"
+	StrategyRoundRobin = "round_robin"
+)
+
+// Strategy selects one product biller out of a set of equally prioritized candidates.
+type Strategy interface {
+	Name() string
+	Select(key string, candidates []*models.ProductBiller) *models.ProductBiller
+}
+
+// NewStrategy returns the Strategy registered under the given name.
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case StrategyWeighted:
+		return NewWeightedStrategy(rand.New(rand.NewSource(time.Now().UnixNano()))), nil
+	case StrategyRoundRobin:
+		return NewRoundRobinStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown routing strategy: %q", name)
+	}
+}
+
+// weightedStrategy picks a candidate at random, proportionally to its weight.
+type weightedStrategy struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewWeightedStrategy creates a weighted random Strategy backed by the given source.
+func NewWeightedStrategy(rnd *rand.Rand) Strategy {
+	return &weightedStrategy{rnd: rnd}
+}
+
+func (s *weightedStrategy) Name() string {
+	return StrategyWeighted
+}
+
+// Select treats non-positive weights as 1 so that every candidate stays reachable.
+func (s *weightedStrategy) Select(_ string, candidates []*models.ProductBiller) *models.ProductBiller {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, candidate := range candidates {
+		total += effectiveWeight(candidate)
+	}
+
+	s.mu.Lock()
+	n := s.rnd.Intn(total)
+	s.mu.Unlock()
+
+	for _, candidate := range candidates {
+		n -= effectiveWeight(candidate)
+		if n < 0 {
+			return candidate
+		}
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// roundRobinStrategy cycles through candidates, keeping a separate cursor per key.
+type roundRobinStrategy struct {
+	mu       sync.Mutex
+	counters map[string]uint64
+}
+
+// NewRoundRobinStrategy creates a round-robin Strategy.
+func NewRoundRobinStrategy() Strategy {
+	return &roundRobinStrategy{counters: make(map[string]uint64)}
+}
+
+func (s *roundRobinStrategy) Name() string {
+	return StrategyRoundRobin
+}
+
+func (s *roundRobinStrategy) Select(key string, candidates []*models.ProductBiller) *models.ProductBiller {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	n := s.counters[key]
+	s.counters[key] = n + 1
+	s.mu.Unlock()
+
+	return candidates[n%uint64(len(candidates))]
+}
+
+// effectiveWeight returns the weight of pb, counting weights below 1, which the API no longer accepts but older
+// rows may hold, as 1.
+func effectiveWeight(pb *models.ProductBiller) int {
+	if pb.Weight <= 0 {
+		return 1
+	}
+	return pb.Weight
+}
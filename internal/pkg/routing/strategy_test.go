@@ -0,0 +1,56 @@
+package routing
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golang-boilerplate/internal/pkg/models"
+)
+
+func TestNewStrategy(t *testing.T) {
+	weighted, err := NewStrategy(StrategyWeighted)
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyWeighted, weighted.Name())
+
+	roundRobin, err := NewStrategy(StrategyRoundRobin)
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyRoundRobin, roundRobin.Name())
+
+	_, err = NewStrategy("random")
+	assert.EqualError(t, err, `unknown routing strategy: "random"`)
+}
+
+func TestWeightedStrategy_Select(t *testing.T) {
+	candidates := []*models.ProductBiller{
+		{ID: 1, Weight: 0},
+		{ID: 2, Weight: 9},
+	}
+	strategy := NewWeightedStrategy(rand.New(rand.NewSource(1)))
+
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		counts[strategy.Select("1", candidates).ID]++
+	}
+
+	// A zero weight counts as 1, so the first candidate still gets roughly 10% of the traffic.
+	assert.Greater(t, counts[1], 50)
+	assert.Greater(t, counts[2], counts[1]*5)
+	assert.Nil(t, strategy.Select("1", nil))
+}
+
+func TestRoundRobinStrategy_Select(t *testing.T) {
+	candidates := []*models.ProductBiller{{ID: 1}, {ID: 2}, {ID: 3}}
+	strategy := NewRoundRobinStrategy()
+
+	var got []int
+	for i := 0; i < 4; i++ {
+		got = append(got, strategy.Select("1", candidates).ID)
+	}
+	assert.Equal(t, []int{1, 2, 3, 1}, got)
+
+	// Each key keeps its own cursor.
+	assert.Equal(t, 1, strategy.Select("2", candidates).ID)
+	assert.Nil(t, strategy.Select("1", nil))
+}
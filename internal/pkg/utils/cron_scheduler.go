@@ -20,3 +20,11 @@ func (c *CronJob) ScheduleDaily(hour int, minute int) {
 		c.Task()
 	}
 }
+
+// ScheduleEvery runs the task repeatedly, waiting interval between the end of one run and the start of the next.
+func (c *CronJob) ScheduleEvery(interval time.Duration) {
+	for {
+		c.Task()
+		time.Sleep(interval)
+	}
+}
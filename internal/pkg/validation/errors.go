@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Codes of FieldError, which clients can rely on unlike the messages.
+const (
+	CodeRequired     = "required"
+	CodeInvalidLabel = "invalid_label"
+	CodeInvalidID    = "invalid_id"
+	CodeNotFound     = "not_found"
+	CodeOutOfRange   = "out_of_range"
+	CodeNotAllowed   = "not_allowed"
+	CodeInvalid      = "invalid"
+)
+
+// FieldError describes why a field is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors lists every invalid field of a validated struct.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func newErrors(fieldErrs validator.ValidationErrors) Errors {
+	errs := make(Errors, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		code, message := describe(fieldErr)
+		errs[i] = FieldError{
+			Field:   fieldPath(fieldErr.Namespace()),
+			Code:    code,
+			Message: message,
+		}
+	}
+	return errs
+}
+
+// fieldPath drops the name of the validated struct from the namespace of a field.
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func describe(fieldErr validator.FieldError) (code, message string) {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required", "required_without":
+		return CodeRequired, "is required"
+	case "label":
+		return CodeInvalidLabel, fmt.Sprintf("must be 1 to %d letters, digits, spaces or ._&()/'- characters, without surrounding spaces", MaxLabelLength)
+	case "id":
+		return CodeInvalidID, "must be a positive integer"
+	case "exists":
+		return CodeNotFound, fmt.Sprintf("must be the ID of an existing %s", param)
+	case "gt":
+		return CodeOutOfRange, "must be greater than " + param
+	case "gte", "min":
+		return CodeOutOfRange, "must be at least " + param
+	case "lt":
+		return CodeOutOfRange, "must be less than " + param
+	case "lte", "max":
+		return CodeOutOfRange, "must be at most " + param
+	case "oneof":
+		return CodeNotAllowed, "must be one of " + strings.Join(strings.Fields(param), ", ")
+	default:
+		return CodeInvalid, fmt.Sprintf("does not satisfy the %s rule", fieldErr.Tag())
+	}
+}
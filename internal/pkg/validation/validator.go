@@ -0,0 +1,174 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxLabelLength is the maximum length, in characters, of a label.
+const MaxLabelLength = 100
+
+// LabelPattern matches the characters allowed in a label.
+const LabelPattern = `^[\p{L}\p{N} ._&()/'-]+$`
+
+var labelPattern = regexp.MustCompile(LabelPattern)
+
+// LookupFunc reports whether the entity with the given ID exists.
+type LookupFunc func(ctx context.Context, id int) (bool, error)
+
+// Validator validates structs against the rules of their validate tags. It implements echo.Validator.
+//
+// Besides the built-in rules, it provides:
+//   - label: 1 to MaxLabelLength letters, digits, spaces or ._&()/'- characters, without surrounding spaces
+//   - id: a positive integer
+//   - exists=<entity>: the ID of an existing entity, checked by the LookupFunc registered under that name
+type Validator struct {
+	validate *validator.Validate
+	lookups  map[string]LookupFunc
+}
+
+// Option configures a Validator.
+type Option func(*Validator)
+
+// WithLookup registers lookup as the check of the exists=<name> rule. Rules without a registered lookup
+// pass, leaving the check to the usecase.
+func WithLookup(name string, lookup LookupFunc) Option {
+	return func(v *Validator) {
+		v.lookups[name] = lookup
+	}
+}
+
+// New creates a Validator that reports fields by their json or query name.
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		validate: validator.New(validator.WithRequiredStructEnabled()),
+		lookups:  map[string]LookupFunc{},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	v.validate.RegisterTagNameFunc(fieldName)
+	// The rules are registered once at startup with fixed tags, so registration cannot fail.
+	_ = v.validate.RegisterValidation("label", isLabel)
+	_ = v.validate.RegisterValidation("id", isID)
+	_ = v.validate.RegisterValidationCtx("exists", v.exists)
+
+	return v
+}
+
+// Validate validates i without a request context; prefer ValidateCtx when one is at hand.
+func (v *Validator) Validate(i interface{}) error {
+	return v.ValidateCtx(context.Background(), i)
+}
+
+// ValidateCtx validates i, returning Errors listing every invalid field. Other errors mean a lookup failed.
+func (v *Validator) ValidateCtx(ctx context.Context, i interface{}) error {
+	state := &lookupState{}
+	err := v.validate.StructCtx(context.WithValue(ctx, lookupStateKey{}, state), i)
+	if state.err != nil {
+		return state.err
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		return newErrors(fieldErrs)
+	}
+	return err
+}
+
+type skipLookupsKey struct{}
+
+// SkipLookups returns a context under which exists rules pass without a lookup, for callers that check
+// existence themselves, e.g. in bulk.
+func SkipLookups(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipLookupsKey{}, true)
+}
+
+type lookupStateKey struct{}
+
+// lookupState records the first lookup failure of a validation, since validation funcs can only
+// report whether a field is valid.
+type lookupState struct {
+	err error
+}
+
+func (v *Validator) exists(ctx context.Context, fl validator.FieldLevel) bool {
+	lookup, ok := v.lookups[fl.Param()]
+	if !ok || ctx.Value(skipLookupsKey{}) != nil {
+		return true
+	}
+
+	id, ok := intValue(fl.Field())
+	if !ok || id <= 0 {
+		// Malformed IDs are for the id rule to report.
+		return true
+	}
+
+	found, err := lookup(ctx, id)
+	if err != nil {
+		if state, ok := ctx.Value(lookupStateKey{}).(*lookupState); ok && state.err == nil {
+			state.err = fmt.Errorf("failed to look up %s %d: %w", fl.Param(), id, err)
+		}
+		return true
+	}
+	return found
+}
+
+func isLabel(fl validator.FieldLevel) bool {
+	label := fl.Field().String()
+	return label == strings.TrimSpace(label) &&
+		utf8.RuneCountInString(label) <= MaxLabelLength &&
+		labelPattern.MatchString(label)
+}
+
+func isID(fl validator.FieldLevel) bool {
+	id, ok := intValue(fl.Field())
+	return ok && id > 0
+}
+
+func intValue(field reflect.Value) (int, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(field.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// fieldName names a struct field by its json name, or else its query name, as clients know it.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "query"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// FetchLookup adapts a repository FetchOne, which fails with sql.ErrNoRows for a missing entity, to a LookupFunc.
+func FetchLookup[T any](fetch func(context.Context, int) (T, error)) LookupFunc {
+	return func(ctx context.Context, id int) (bool, error) {
+		if _, err := fetch(ctx, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,102 @@
+package validation_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang-boilerplate/internal/pkg/validation"
+)
+
+func TestValidator_Label(t *testing.T) {
+	type request struct {
+		Label string `json:"label" validate:"required,label"`
+	}
+	v := validation.New()
+
+	for _, label := range []string{"Pulsa", "Token PLN 20.000", "Tagihan & Iuran (BPJS)", "Kuota/Data", "Jum'at", "Café"} {
+		assert.NoError(t, v.Validate(request{Label: label}), label)
+	}
+
+	for name, label := range map[string]string{
+		"surrounding spaces": " Pulsa ",
+		"forbidden charset":  "Pulsa<script>",
+		"too long":           strings.Repeat("a", validation.MaxLabelLength+1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var errs validation.Errors
+			require.ErrorAs(t, v.Validate(request{Label: label}), &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, "label", errs[0].Field)
+			assert.Equal(t, validation.CodeInvalidLabel, errs[0].Code)
+		})
+	}
+}
+
+func TestValidator_Exists(t *testing.T) {
+	type request struct {
+		ProductID *int `json:"product_id" validate:"omitempty,id,exists=product"`
+	}
+	var lookups int
+	v := validation.New(validation.WithLookup("product", func(ctx context.Context, id int) (bool, error) {
+		lookups++
+		if id == 99 {
+			return false, errors.New("connection refused")
+		}
+		return id == 1, nil
+	}))
+	id := func(id int) *int { return &id }
+	ctx := context.Background()
+
+	assert.NoError(t, v.ValidateCtx(ctx, request{}))
+	assert.NoError(t, v.ValidateCtx(ctx, request{ProductID: id(1)}))
+
+	var errs validation.Errors
+	require.ErrorAs(t, v.ValidateCtx(ctx, request{ProductID: id(2)}), &errs)
+	assert.Equal(t, validation.CodeNotFound, errs[0].Code)
+
+	// A malformed ID is reported by the id rule, without a lookup.
+	lookups = 0
+	require.ErrorAs(t, v.ValidateCtx(ctx, request{ProductID: id(-1)}), &errs)
+	assert.Equal(t, validation.CodeInvalidID, errs[0].Code)
+	assert.Zero(t, lookups)
+
+	err := v.ValidateCtx(ctx, request{ProductID: id(99)})
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &errs), "a failed lookup is not a validation error")
+
+	lookups = 0
+	assert.NoError(t, v.ValidateCtx(validation.SkipLookups(ctx), request{ProductID: id(2)}))
+	assert.Zero(t, lookups)
+}
+
+func TestFetchLookup(t *testing.T) {
+	lookup := validation.FetchLookup(func(ctx context.Context, id int) (*struct{}, error) {
+		switch id {
+		case 1:
+			return &struct{}{}, nil
+		case 2:
+			return nil, fmt.Errorf("failed to fetch: %w", sql.ErrNoRows)
+		default:
+			return nil, errors.New("connection refused")
+		}
+	})
+	ctx := context.Background()
+
+	found, err := lookup(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = lookup(ctx, 2)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, err = lookup(ctx, 3)
+	assert.Error(t, err)
+}
@@ -0,0 +1,4 @@
+ALTER TABLE product_billers
+    DROP INDEX idx_product_billers_routing,
+    DROP COLUMN weight,
+    DROP COLUMN priority;
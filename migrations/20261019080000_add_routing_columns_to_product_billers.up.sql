@@ -0,0 +1,4 @@
+ALTER TABLE product_billers
+    ADD COLUMN priority INT NOT NULL DEFAULT 0 AFTER is_active,
+    ADD COLUMN weight INT NOT NULL DEFAULT 1 AFTER priority,
+    ADD INDEX idx_product_billers_routing (product_id, is_active, priority);
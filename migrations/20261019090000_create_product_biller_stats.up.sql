@@ -0,0 +1,10 @@
+CREATE TABLE product_biller_stats (
+    product_biller_id INT NOT NULL,
+    bucket VARCHAR(16) NOT NULL,
+    bucket_start DATETIME(6) NOT NULL,
+    total INT NOT NULL DEFAULT 0,
+    success INT NOT NULL DEFAULT 0,
+    failure INT NOT NULL DEFAULT 0,
+    updated_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (product_biller_id, bucket, bucket_start)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
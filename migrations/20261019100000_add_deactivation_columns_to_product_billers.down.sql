@@ -0,0 +1,5 @@
+ALTER TABLE product_billers
+    DROP INDEX idx_product_billers_deactivated_at,
+    DROP COLUMN deactivation_reason,
+    DROP COLUMN deactivated_by,
+    DROP COLUMN deactivated_at;
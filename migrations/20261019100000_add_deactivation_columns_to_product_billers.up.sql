@@ -0,0 +1,5 @@
+ALTER TABLE product_billers
+    ADD COLUMN deactivated_at DATETIME(6) NULL AFTER weight,
+    ADD COLUMN deactivated_by VARCHAR(255) NOT NULL DEFAULT '' AFTER deactivated_at,
+    ADD COLUMN deactivation_reason VARCHAR(255) NOT NULL DEFAULT '' AFTER deactivated_by,
+    ADD INDEX idx_product_billers_deactivated_at (deactivated_at);
@@ -0,0 +1,7 @@
+CREATE TABLE product_biller_summaries (
+    id INT NOT NULL AUTO_INCREMENT,
+    payload JSON NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_product_biller_summaries_created_at (created_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,15 @@
+CREATE TABLE notifications (
+    id INT NOT NULL AUTO_INCREMENT,
+    type VARCHAR(100) NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NULL,
+    last_error TEXT NULL,
+    sent_at DATETIME(6) NULL,
+    created_at DATETIME(6) NOT NULL,
+    updated_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_notifications_status_next_attempt_at (status, next_attempt_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,6 @@
+ALTER TABLE product_billers
+    DROP COLUMN version;
+ALTER TABLE billers
+    DROP COLUMN version;
+ALTER TABLE products
+    DROP COLUMN version;
@@ -0,0 +1,6 @@
+ALTER TABLE products
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_by;
+ALTER TABLE billers
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_by;
+ALTER TABLE product_billers
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_by;
@@ -0,0 +1,21 @@
+CREATE TABLE jobs (
+    id INT NOT NULL AUTO_INCREMENT,
+    type VARCHAR(100) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    progress_done INT NOT NULL DEFAULT 0,
+    progress_total INT NOT NULL DEFAULT 0,
+    result JSON NULL,
+    last_error TEXT NULL,
+    cancel_requested TINYINT(1) NOT NULL DEFAULT 0,
+    claim_token VARCHAR(36) NULL,
+    heartbeat_at DATETIME(6) NULL,
+    started_at DATETIME(6) NULL,
+    finished_at DATETIME(6) NULL,
+    created_at DATETIME(6) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_jobs_status_heartbeat_at (status, heartbeat_at),
+    INDEX idx_jobs_claim_token (claim_token)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,12 @@
+ALTER TABLE billers
+    DROP INDEX idx_billers_deleted_at;
+ALTER TABLE products
+    DROP INDEX idx_products_deleted_at;
+ALTER TABLE product_billers
+    DROP INDEX idx_product_billers_deleted_at,
+    DROP INDEX idx_product_billers_deleted_batch,
+    DROP COLUMN deleted_batch;
+ALTER TABLE billers
+    DROP COLUMN deleted_batch;
+ALTER TABLE products
+    DROP COLUMN deleted_batch;
@@ -0,0 +1,12 @@
+ALTER TABLE products
+    ADD COLUMN deleted_batch CHAR(36) NULL AFTER deleted_by;
+ALTER TABLE billers
+    ADD COLUMN deleted_batch CHAR(36) NULL AFTER deleted_by;
+ALTER TABLE product_billers
+    ADD COLUMN deleted_batch CHAR(36) NULL AFTER deleted_by,
+    ADD INDEX idx_product_billers_deleted_batch (deleted_batch),
+    ADD INDEX idx_product_billers_deleted_at (deleted_at);
+ALTER TABLE products
+    ADD INDEX idx_products_deleted_at (deleted_at);
+ALTER TABLE billers
+    ADD INDEX idx_billers_deleted_at (deleted_at);
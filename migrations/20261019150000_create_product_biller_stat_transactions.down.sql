@@ -0,0 +1 @@
+DROP TABLE product_biller_stat_transactions;
@@ -0,0 +1,7 @@
+CREATE TABLE product_biller_stat_transactions (
+    transaction_id INT NOT NULL,
+    product_biller_id INT NOT NULL,
+    recorded_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (transaction_id),
+    INDEX idx_product_biller_stat_transactions_product_biller_id (product_biller_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,4 @@
+ALTER TABLE notifications
+    DROP INDEX idx_notifications_claim_token,
+    DROP COLUMN delivered_channels,
+    DROP COLUMN claim_token;
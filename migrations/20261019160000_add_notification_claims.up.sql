@@ -0,0 +1,4 @@
+ALTER TABLE notifications
+    ADD COLUMN claim_token CHAR(36) NULL AFTER status,
+    ADD COLUMN delivered_channels VARCHAR(255) NOT NULL DEFAULT '' AFTER attempts,
+    ADD INDEX idx_notifications_claim_token (claim_token);
@@ -0,0 +1,5 @@
+ALTER TABLE product_biller_stat_transactions
+    DROP INDEX idx_product_biller_stat_transactions_recorded_at;
+
+ALTER TABLE product_biller_stats
+    DROP INDEX idx_product_biller_stats_bucket_start;
@@ -0,0 +1,5 @@
+ALTER TABLE product_biller_stats
+    ADD INDEX idx_product_biller_stats_bucket_start (bucket_start);
+
+ALTER TABLE product_biller_stat_transactions
+    ADD INDEX idx_product_biller_stat_transactions_recorded_at (recorded_at);